@@ -2,37 +2,57 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"expvar"
 	"flag"
 	"fmt"
 	"log"
+	"net/http"
+	"os"
+	"slices"
+	"time"
 
 	"buf.build/go/protovalidate"
 	"go.uber.org/zap"
 	"golang.org/x/time/rate"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
-	"google.golang.org/grpc/status"
-	"google.golang.org/protobuf/proto"
-
-	orderpb "github.com/chilly266futon/exchange-service-contracts/gen/pb/order"
 
+	spotpb "github.com/chilly266futon/exchange-service-contracts/gen/pb/spot"
 	"github.com/chilly266futon/exchange-shared/pkg/breaker"
 	"github.com/chilly266futon/exchange-shared/pkg/grpcutil"
 	"github.com/chilly266futon/exchange-shared/pkg/health"
 	"github.com/chilly266futon/exchange-shared/pkg/interceptors"
 	"github.com/chilly266futon/exchange-shared/pkg/logger"
 
+	orderpb "github.com/chilly266futon/orderService/gen/pb/order"
+	"github.com/chilly266futon/orderService/internal/auth"
 	"github.com/chilly266futon/orderService/internal/clients"
 	"github.com/chilly266futon/orderService/internal/config"
+	"github.com/chilly266futon/orderService/internal/matching"
+	"github.com/chilly266futon/orderService/internal/middleware"
+	"github.com/chilly266futon/orderService/internal/outbox"
+	"github.com/chilly266futon/orderService/internal/roles"
 	"github.com/chilly266futon/orderService/internal/service"
 	"github.com/chilly266futon/orderService/internal/storage"
+	"github.com/chilly266futon/orderService/internal/storage/postgres"
+	"github.com/chilly266futon/orderService/internal/sweeper"
 	transport "github.com/chilly266futon/orderService/internal/transport/grpc"
+	"github.com/chilly266futon/orderService/internal/trigger"
 )
 
 const serviceName = "order-service"
 
+// publicMethods методы, доступные без аутентификации
+var publicMethods = []string{
+	"/grpc.health.v1.Health/",
+	"/grpc.reflection.",
+}
+
 func main() {
 	// Парсинг флагов
 	configPath := flag.String("config", "configs/config.yaml", "Path to config file")
@@ -51,6 +71,11 @@ func main() {
 		zap.String("config", *configPath),
 	)
 
+	marketRules, err := newRulesTable(cfg.SpotService.MarketRules)
+	if err != nil {
+		log.Fatalf("failed to parse market rules: %v", err)
+	}
+
 	spotClient, err := clients.NewSpotClient(clients.Config{
 		Address:       cfg.SpotService.Addr,
 		Timeout:       cfg.SpotService.Timeout,
@@ -61,6 +86,13 @@ func main() {
 			Timeout:     cfg.SpotService.Breaker.Timeout,
 			Attempts:    cfg.SpotService.Breaker.Attempts,
 		},
+		Cache: clients.CacheConfig{
+			Enabled:         cfg.SpotService.Cache.Enabled,
+			TTL:             cfg.SpotService.Cache.TTL,
+			RefreshInterval: cfg.SpotService.Cache.RefreshInterval,
+			MaxStale:        cfg.SpotService.Cache.MaxStale,
+		},
+		Rules: marketRules,
 	}, l)
 	if err != nil {
 		log.Fatalf("failed to create spot client: %v", err)
@@ -70,48 +102,122 @@ func main() {
 	l.Info("connected to spot service",
 		zap.String("address", cfg.SpotService.Addr),
 		zap.Bool("circuit_creaker", cfg.SpotService.EnableBreaker),
+		zap.Bool("market_cache", cfg.SpotService.Cache.Enabled),
 	)
 
-	orderStorage := storage.NewOrderStorage()
+	orderRepo, closeStorage, err := newOrderRepository(context.Background(), cfg.Storage, l)
+	if err != nil {
+		log.Fatalf("failed to init storage: %v", err)
+	}
+	defer closeStorage()
+
+	sinks := []outbox.Sink{}
+	if cfg.Outbox.Enabled {
+		sink, closeSink, err := newOutboxSink(cfg.Outbox, l)
+		if err != nil {
+			log.Fatalf("failed to init outbox sink: %v", err)
+		}
+		defer closeSink()
+
+		sinks = append(sinks, sink)
+		l.Info("outbox sink enabled", zap.String("sink", cfg.Outbox.Sink))
+	}
+
+	relay := outbox.NewRelay(orderRepo, outbox.Fanout(sinks...), outbox.Config{
+		PollInterval: cfg.Outbox.PollInterval,
+		BatchSize:    cfg.Outbox.BatchSize,
+	}, l)
+	relay.Start()
+	defer relay.Stop()
+
+	// хаб читает outbox сам, а не через релей, поэтому видит события всех экземпляров
+	updates := outbox.NewHub(cfg.Outbox.SubscriberBuffer)
+	tailer := outbox.NewTailer(orderRepo, updates, outbox.Config{
+		PollInterval: cfg.Outbox.TailInterval,
+		BatchSize:    cfg.Outbox.BatchSize,
+	}, l)
+	tailer.Start()
+	defer tailer.Stop()
+
+	roleProvider, err := newRoleProvider(cfg.Roles, orderRepo, l)
+	if err != nil {
+		log.Fatalf("failed to init role provider: %v", err)
+	}
+
+	useCase := service.NewOrderUseCase(orderRepo, spotClient, roleProvider, updates, l)
+
+	// цены сделок движка сопоставления для стоп-заказов, если trigger.feed = matching
+	var tradeFeed *trigger.MemoryFeed
+
+	if cfg.Trigger.Enabled {
+		var feed trigger.Feed
+		switch cfg.Trigger.Feed {
+		case "", "file":
+			feed = trigger.NewFileFeed(cfg.Trigger.FilePath, cfg.Trigger.PollInterval, l)
+		case "matching":
+			if !cfg.Matching.Enabled {
+				log.Fatal("trigger.feed matching requires matching.enabled")
+			}
+			tradeFeed = trigger.NewMemoryFeed(cfg.Matching.QueueSize)
+			feed = tradeFeed
+		default:
+			log.Fatalf("unknown trigger feed: %s", cfg.Trigger.Feed)
+		}
+
+		stopOrders := trigger.NewEngine(orderRepo, updates, feed, useCase, l)
+		stopOrders.Start()
+		defer stopOrders.Stop()
+		l.Info("stop order trigger enabled", zap.String("price_feed", cfg.Trigger.Feed))
+	}
+
+	if cfg.Matching.Enabled {
+		var trades matching.TradeSink
+		if tradeFeed != nil {
+			trades = tradeFeed
+		}
+
+		// останавливается раньше движка стоп-заказов, который читает его сделки.
+		// Аренда своя, не общая с очисткой: движок отпускает ее сам, когда рынки закончат текущие сделки
+		if cfg.Sweeper.Enabled && cfg.Matching.LeaseKey == cfg.Sweeper.LeaseKey {
+			log.Fatal("matching.lease_key must differ from sweeper.lease_key")
+		}
+		matcher := matching.NewEngine(orderRepo, updates, trades, newLease(orderRepo, cfg.Matching.LeaseKey), matching.Config{
+			QueueSize: cfg.Matching.QueueSize,
+		}, l)
+		matcher.Start()
+		defer matcher.Stop()
+		l.Info("matching engine enabled")
+	}
 
-	useCase := service.NewOrderUseCase(orderStorage, spotClient, l)
+	if cfg.Sweeper.Enabled {
+		sweep := sweeper.NewSweeper(orderRepo, newLease(orderRepo, cfg.Sweeper.LeaseKey), sweeper.Config{
+			Interval:       cfg.Sweeper.Interval,
+			CreatedTimeout: cfg.Sweeper.CreatedTimeout,
+			BatchSize:      cfg.Sweeper.BatchSize,
+		}, l)
+		sweep.Start()
+		defer sweep.Stop()
+		l.Info("order sweeper enabled", zap.Duration("created_timeout", cfg.Sweeper.CreatedTimeout))
+	}
 
 	validator, err := protovalidate.New()
 	if err != nil {
 		l.Fatal("failed to initialize protovalidate", zap.Error(err))
 	}
 
-	var interceptorChain []grpc.ServerOption
-
-	interceptorChain = append(interceptorChain,
-		grpc.ChainUnaryInterceptor(
-			func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
-				msg, ok := req.(proto.Message)
-				if !ok {
-					// на всякий случай, хотя в gRPC unary RPC все запросы всегда proto.Message
-					l.Warn("request is not a proto message", zap.String("type", fmt.Sprintf("%T", req)))
-					return handler(ctx, req)
-				}
-
-				if err := validator.Validate(msg); err != nil {
-					l.Warn("request validation failed",
-						zap.String("method", info.FullMethod),
-						zap.Error(err),
-					)
-					return nil, status.Errorf(codes.InvalidArgument, "invalid request: %v", err)
-				}
-				return handler(ctx, req)
-			},
-		),
-	)
-
-	interceptorChain = append(interceptorChain,
-		grpc.ChainUnaryInterceptor(interceptors.TraceIDInterceptor()),
-	)
+	// порядок слоев задает middleware.Stage, а не порядок вызовов Use
+	pipeline := middleware.NewPipeline().
+		Use(middleware.StageRecovery, interceptors.UnaryPanicRecoveryInterceptor(l), nil).
+		Use(middleware.StageTrace, interceptors.TraceIDInterceptor(), nil).
+		Use(middleware.StageValidation, middleware.UnaryValidator(validator, l), middleware.StreamValidator(validator, l)).
+		Use(middleware.StageLogging, interceptors.LoggerInterceptor(l), nil)
 
-	interceptorChain = append(interceptorChain,
-		grpc.UnaryInterceptor(interceptors.UnaryPanicRecoveryInterceptor(l)),
-	)
+	authenticator, err := newAuthenticator(cfg.Auth, l)
+	if err != nil {
+		log.Fatalf("failed to init authentication: %v", err)
+	}
+	authInterceptor := auth.NewInterceptor(authenticator, l, publicMethods...)
+	pipeline.Use(middleware.StageAuth, authInterceptor.Unary(), authInterceptor.Stream())
 
 	if cfg.RateLimit.Enabled {
 		rateLimiter := interceptors.NewMethodRateLimiterInterceptor(
@@ -123,22 +229,35 @@ func main() {
 			rateLimiter.SetMethodLimit(method, rate.Limit(limit.RequestsPerSecond), limit.Burst)
 		}
 
-		interceptorChain = append(interceptorChain,
-			grpc.ChainUnaryInterceptor(rateLimiter.Interceptor()))
+		pipeline.Use(middleware.StageRateLimit, rateLimiter.Interceptor(), nil)
 
 		l.Info("rate limiting enabled")
 	}
 
-	interceptorChain = append(interceptorChain,
-		grpc.ChainUnaryInterceptor(interceptors.LoggerInterceptor(l)),
-	)
+	if cfg.Metrics.Enabled {
+		metrics := middleware.NewMetrics()
+		expvar.Publish("grpc", metrics)
+		pipeline.Use(middleware.StageMetrics, metrics.Unary(), metrics.Stream())
+
+		stopMetrics := serveMetrics(cfg.Metrics.Addr, l)
+		defer stopMetrics()
+	}
+
+	serverOptions := pipeline.ServerOptions()
+	if cfg.Auth.MTLS.Enabled {
+		tlsConfig, err := newServerTLSConfig(cfg.Auth.MTLS)
+		if err != nil {
+			log.Fatalf("failed to init TLS: %v", err)
+		}
+		serverOptions = append(serverOptions, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
 
 	grpcServer, err := grpcutil.NewServer(
 		grpcutil.ServerConfig{
 			Host:            cfg.Server.Host,
 			Port:            cfg.Server.Port,
 			ShutdownTimeout: cfg.Server.ShutdownTimeout,
-		}, l, interceptorChain...,
+		}, l, serverOptions...,
 	)
 	if err != nil {
 		log.Fatalf("failed to create server: %v", err)
@@ -146,6 +265,19 @@ func main() {
 
 	orderpb.RegisterOrderServiceServer(grpcServer.GRPCServer(), transport.NewOrderServer(useCase))
 
+	if cfg.Admin.Enabled {
+		if slices.Contains(roles.ParseRoles(cfg.Roles.Default), spotpb.UserRole_USER_ROLE_ADMIN) {
+			log.Fatalf("roles.default must not contain USER_ROLE_ADMIN when admin service is enabled")
+		}
+		auditRepo, err := newAuditRepository(orderRepo)
+		if err != nil {
+			log.Fatalf("failed to init audit log: %v", err)
+		}
+		adminUseCase := service.NewAdminUseCase(orderRepo, auditRepo, roleProvider, l)
+		orderpb.RegisterOrderAdminServiceServer(grpcServer.GRPCServer(), transport.NewAdminServer(adminUseCase))
+		l.Info("admin service enabled")
+	}
+
 	// health check
 	if cfg.Health.Enabled {
 		healthServer := health.NewServer()
@@ -161,3 +293,210 @@ func main() {
 		log.Fatalf("server error: %v", err)
 	}
 }
+
+// newOrderRepository создает хранилище заказов по конфигу. Возвращаемая функция закрывает соединения
+func newOrderRepository(ctx context.Context, cfg config.StorageConfig, l *zap.Logger) (storage.OrderRepository, func(), error) {
+	switch cfg.Driver {
+	case "", config.StorageDriverMemory:
+		l.Info("using in-memory order storage")
+		return storage.NewMemoryOrderRepository(), func() {}, nil
+
+	case config.StorageDriverPostgres:
+		pg, err := postgres.NewOrderRepository(ctx, postgres.Config{
+			DSN:             cfg.Postgres.DSN,
+			MaxOpenConns:    cfg.Postgres.MaxOpenConns,
+			MaxIdleConns:    cfg.Postgres.MaxIdleConns,
+			ConnMaxLifetime: cfg.Postgres.ConnMaxLifetime,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if cfg.Postgres.Migrate {
+			if err := postgres.Migrate(ctx, pg.DB()); err != nil {
+				pg.Close()
+				return nil, nil, fmt.Errorf("failed to apply migrations: %w", err)
+			}
+			l.Info("postgres migrations applied")
+		}
+
+		l.Info("using postgres order storage")
+		return pg, func() { pg.Close() }, nil
+
+	default:
+		return nil, nil, fmt.Errorf("unknown storage driver %q", cfg.Driver)
+	}
+}
+
+// newOutboxSink создает получателя событий заказов по конфигу. Возвращаемая функция закрывает его
+func newOutboxSink(cfg config.OutboxConfig, l *zap.Logger) (outbox.Sink, func(), error) {
+	switch cfg.Sink {
+	case "", config.OutboxSinkLog:
+		return outbox.NewLogSink(l), func() {}, nil
+
+	case config.OutboxSinkFile:
+		sink, err := outbox.NewFileSink(cfg.FilePath)
+		if err != nil {
+			return nil, nil, err
+		}
+		return sink, func() { sink.Close() }, nil
+
+	default:
+		return nil, nil, fmt.Errorf("unknown outbox sink %q", cfg.Sink)
+	}
+}
+
+// serveMetrics отдает expvar-метрики по HTTP на /debug/vars. Возвращаемая функция останавливает сервер
+func serveMetrics(addr string, l *zap.Logger) func() {
+	mux := http.NewServeMux()
+	mux.Handle("/debug/vars", expvar.Handler())
+	srv := &http.Server{Addr: addr, Handler: mux}
+
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			l.Error("metrics server error", zap.Error(err))
+		}
+	}()
+	l.Info("metrics enabled", zap.String("addr", addr))
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		srv.Shutdown(ctx)
+	}
+}
+
+// newAuthenticator собирает аутентификацию по конфигу: клиентский сертификат, затем bearer JWT.
+// При выключенной аутентификации пользователь берется из метаданных без проверки
+func newAuthenticator(cfg config.AuthConfig, l *zap.Logger) (auth.Authenticator, error) {
+	if !cfg.Enabled {
+		if !cfg.Insecure {
+			return nil, errors.New("authentication disabled without auth.insecure: refusing to trust user_id metadata")
+		}
+		l.Warn("authentication disabled, trusting user_id metadata")
+		return auth.NewMetadataAuthenticator(), nil
+	}
+
+	var authenticators []auth.Authenticator
+	if cfg.MTLS.Enabled {
+		authenticators = append(authenticators, auth.NewMTLSAuthenticator())
+	}
+
+	jwtEnabled := cfg.JWT.HSSecret != "" || cfg.JWT.RSPublicKeyFile != "" || cfg.JWT.JWKSFile != ""
+	if jwtEnabled {
+		jwtAuth, err := auth.NewJWTAuthenticator(auth.JWTConfig{
+			HSSecret:        cfg.JWT.HSSecret,
+			RSPublicKeyFile: cfg.JWT.RSPublicKeyFile,
+			JWKSFile:        cfg.JWT.JWKSFile,
+			Issuer:          cfg.JWT.Issuer,
+			Audience:        cfg.JWT.Audience,
+			RolesClaim:      cfg.JWT.RolesClaim,
+		})
+		if err != nil {
+			return nil, err
+		}
+		authenticators = append(authenticators, jwtAuth)
+	}
+
+	if len(authenticators) == 0 {
+		return nil, errors.New("authentication enabled but neither JWT keys nor mTLS configured")
+	}
+
+	l.Info("authentication enabled",
+		zap.Bool("mtls", cfg.MTLS.Enabled),
+		zap.Bool("jwt", jwtEnabled),
+	)
+	return auth.Chain(authenticators...), nil
+}
+
+// newServerTLSConfig TLS сервера. Клиентский сертификат необязателен, чтобы оставался вход по JWT,
+// но если он передан, то проверяется по ClientCAFile
+func newServerTLSConfig(cfg config.MTLSConfig) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load server certificate: %w", err)
+	}
+
+	caPEM, err := os.ReadFile(cfg.ClientCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client CA: %w", err)
+	}
+	clientCAs := x509.NewCertPool()
+	if !clientCAs.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("no certificates in %s", cfg.ClientCAFile)
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    clientCAs,
+		ClientAuth:   tls.VerifyClientCertIfGiven,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// newRulesTable разбирает торговые правила рынков из конфигурации
+func newRulesTable(cfg config.MarketRulesConfig) (clients.RulesTable, error) {
+	markets := make(map[string]clients.RuleValues, len(cfg.Markets))
+	for marketID, ruleCfg := range cfg.Markets {
+		markets[marketID] = clients.RuleValues(ruleCfg)
+	}
+	return clients.NewRulesTable(clients.RuleValues(cfg.Default), markets)
+}
+
+// newAuditRepository хранит журнал аудита рядом с заказами: в Postgres при postgres-хранилище, иначе в памяти
+func newAuditRepository(orderRepo storage.OrderRepository) (storage.AuditRepository, error) {
+	if pg, ok := orderRepo.(*postgres.OrderRepository); ok {
+		return postgres.NewAuditRepository(pg.DB()), nil
+	}
+	return storage.NewMemoryAuditRepository(), nil
+}
+
+// newLease аренда фоновых задач: advisory lock при postgres-хранилище, иначе аренда в памяти
+func newLease(orderRepo storage.OrderRepository, key int64) storage.Lease {
+	if pg, ok := orderRepo.(*postgres.OrderRepository); ok {
+		return postgres.NewAdvisoryLease(pg.DB(), key)
+	}
+	return storage.NewMemoryLease()
+}
+
+// newRoleProvider собирает цепочку источников ролей: claims личности, хранилище ролей с кэшем, роли по умолчанию
+func newRoleProvider(cfg config.RolesConfig, orderRepo storage.OrderRepository, l *zap.Logger) (roles.Provider, error) {
+	providers := []roles.Provider{roles.NewClaimsProvider()}
+
+	var store roles.Store
+	switch cfg.Store {
+	case "":
+	case config.RolesStoreFile:
+		fileStore, err := roles.NewFileStore(cfg.FilePath)
+		if err != nil {
+			return nil, err
+		}
+		store = fileStore
+	case config.RolesStorePostgres:
+		pg, ok := orderRepo.(*postgres.OrderRepository)
+		if !ok {
+			return nil, errors.New("postgres roles store requires postgres order storage")
+		}
+		store = postgres.NewUserRoleRepository(pg.DB())
+	default:
+		return nil, fmt.Errorf("unknown roles store %q", cfg.Store)
+	}
+	if store != nil {
+		providers = append(providers, roles.NewCachedProvider(roles.NewStoreProvider(store), cfg.CacheTTL))
+	}
+
+	defaults := roles.ParseRoles(cfg.Default)
+	if len(defaults) != len(cfg.Default) {
+		return nil, fmt.Errorf("unknown role in roles.default %v", cfg.Default)
+	}
+	if len(defaults) == 0 {
+		defaults = []spotpb.UserRole{spotpb.UserRole_USER_ROLE_COMMON}
+	}
+	providers = append(providers, roles.Static(defaults...))
+
+	l.Info("role provider configured",
+		zap.String("store", cfg.Store),
+		zap.Strings("default", cfg.Default),
+	)
+	return roles.Chain(providers...), nil
+}
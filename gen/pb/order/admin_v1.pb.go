@@ -0,0 +1,719 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: order/admin_v1.proto
+
+package orderv1
+
+import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type AdminListOrdersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`       // UUID, пусто - все пользователи
+	MarketId      string                 `protobuf:"bytes,2,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"` // UUID торговой пары, пусто - все пары
+	Statuses      []OrderStatus          `protobuf:"varint,3,rep,packed,name=statuses,proto3,enum=order.v1.OrderStatus" json:"statuses,omitempty"`
+	OrderTypes    []OrderType            `protobuf:"varint,4,rep,packed,name=order_types,json=orderTypes,proto3,enum=order.v1.OrderType" json:"order_types,omitempty"`
+	CreatedFrom   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"` // включительно
+	CreatedTo     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`       // не включительно
+	PageSize      uint32                 `protobuf:"varint,7,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,8,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdminListOrdersRequest) Reset() {
+	*x = AdminListOrdersRequest{}
+	mi := &file_order_admin_v1_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminListOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminListOrdersRequest) ProtoMessage() {}
+
+func (x *AdminListOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_admin_v1_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminListOrdersRequest.ProtoReflect.Descriptor instead.
+func (*AdminListOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_order_admin_v1_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AdminListOrdersRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AdminListOrdersRequest) GetMarketId() string {
+	if x != nil {
+		return x.MarketId
+	}
+	return ""
+}
+
+func (x *AdminListOrdersRequest) GetStatuses() []OrderStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *AdminListOrdersRequest) GetOrderTypes() []OrderType {
+	if x != nil {
+		return x.OrderTypes
+	}
+	return nil
+}
+
+func (x *AdminListOrdersRequest) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *AdminListOrdersRequest) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
+
+func (x *AdminListOrdersRequest) GetPageSize() uint32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *AdminListOrdersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type AdminGetOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"` // UUID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdminGetOrderRequest) Reset() {
+	*x = AdminGetOrderRequest{}
+	mi := &file_order_admin_v1_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminGetOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminGetOrderRequest) ProtoMessage() {}
+
+func (x *AdminGetOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_admin_v1_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminGetOrderRequest.ProtoReflect.Descriptor instead.
+func (*AdminGetOrderRequest) Descriptor() ([]byte, []int) {
+	return file_order_admin_v1_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AdminGetOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type ForceTransitionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`                               // UUID
+	ToStatus      OrderStatus            `protobuf:"varint,2,opt,name=to_status,json=toStatus,proto3,enum=order.v1.OrderStatus" json:"to_status,omitempty"` // CANCELLED или REJECTED
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForceTransitionRequest) Reset() {
+	*x = ForceTransitionRequest{}
+	mi := &file_order_admin_v1_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForceTransitionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForceTransitionRequest) ProtoMessage() {}
+
+func (x *ForceTransitionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_admin_v1_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForceTransitionRequest.ProtoReflect.Descriptor instead.
+func (*ForceTransitionRequest) Descriptor() ([]byte, []int) {
+	return file_order_admin_v1_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ForceTransitionRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *ForceTransitionRequest) GetToStatus() OrderStatus {
+	if x != nil {
+		return x.ToStatus
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+func (x *ForceTransitionRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ForceTransitionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForceTransitionResponse) Reset() {
+	*x = ForceTransitionResponse{}
+	mi := &file_order_admin_v1_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForceTransitionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForceTransitionResponse) ProtoMessage() {}
+
+func (x *ForceTransitionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_admin_v1_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForceTransitionResponse.ProtoReflect.Descriptor instead.
+func (*ForceTransitionResponse) Descriptor() ([]byte, []int) {
+	return file_order_admin_v1_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ForceTransitionResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type CancelMarketOrdersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MarketId      string                 `protobuf:"bytes,1,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"` // UUID торговой пары
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelMarketOrdersRequest) Reset() {
+	*x = CancelMarketOrdersRequest{}
+	mi := &file_order_admin_v1_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelMarketOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelMarketOrdersRequest) ProtoMessage() {}
+
+func (x *CancelMarketOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_admin_v1_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelMarketOrdersRequest.ProtoReflect.Descriptor instead.
+func (*CancelMarketOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_order_admin_v1_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CancelMarketOrdersRequest) GetMarketId() string {
+	if x != nil {
+		return x.MarketId
+	}
+	return ""
+}
+
+func (x *CancelMarketOrdersRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type CancelMarketOrdersResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	CancelledOrderIds []string               `protobuf:"bytes,1,rep,name=cancelled_order_ids,json=cancelledOrderIds,proto3" json:"cancelled_order_ids,omitempty"`
+	FailedOrderIds    []string               `protobuf:"bytes,2,rep,name=failed_order_ids,json=failedOrderIds,proto3" json:"failed_order_ids,omitempty"` // не удалось отменить, например из-за конкурирующего изменения
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CancelMarketOrdersResponse) Reset() {
+	*x = CancelMarketOrdersResponse{}
+	mi := &file_order_admin_v1_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelMarketOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelMarketOrdersResponse) ProtoMessage() {}
+
+func (x *CancelMarketOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_admin_v1_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelMarketOrdersResponse.ProtoReflect.Descriptor instead.
+func (*CancelMarketOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_order_admin_v1_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CancelMarketOrdersResponse) GetCancelledOrderIds() []string {
+	if x != nil {
+		return x.CancelledOrderIds
+	}
+	return nil
+}
+
+func (x *CancelMarketOrdersResponse) GetFailedOrderIds() []string {
+	if x != nil {
+		return x.FailedOrderIds
+	}
+	return nil
+}
+
+type ListAuditRecordsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"` // UUID, пусто - все записи
+	Limit         uint32                 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`                   // 0 - размер по умолчанию
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditRecordsRequest) Reset() {
+	*x = ListAuditRecordsRequest{}
+	mi := &file_order_admin_v1_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditRecordsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditRecordsRequest) ProtoMessage() {}
+
+func (x *ListAuditRecordsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_admin_v1_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditRecordsRequest.ProtoReflect.Descriptor instead.
+func (*ListAuditRecordsRequest) Descriptor() ([]byte, []int) {
+	return file_order_admin_v1_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListAuditRecordsRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *ListAuditRecordsRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListAuditRecordsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Records       []*AuditRecord         `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"` // от новых к старым
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditRecordsResponse) Reset() {
+	*x = ListAuditRecordsResponse{}
+	mi := &file_order_admin_v1_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditRecordsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditRecordsResponse) ProtoMessage() {}
+
+func (x *ListAuditRecordsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_admin_v1_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditRecordsResponse.ProtoReflect.Descriptor instead.
+func (*ListAuditRecordsResponse) Descriptor() ([]byte, []int) {
+	return file_order_admin_v1_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListAuditRecordsResponse) GetRecords() []*AuditRecord {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+type AuditRecord struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Actor         string                 `protobuf:"bytes,2,opt,name=actor,proto3" json:"actor,omitempty"` // "admin:<id>"
+	Action        string                 `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	OrderId       string                 `protobuf:"bytes,4,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	MarketId      string                 `protobuf:"bytes,5,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	Outcome       string                 `protobuf:"bytes,7,opt,name=outcome,proto3" json:"outcome,omitempty"` // success, denied или failed
+	Details       string                 `protobuf:"bytes,8,opt,name=details,proto3" json:"details,omitempty"`
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditRecord) Reset() {
+	*x = AuditRecord{}
+	mi := &file_order_admin_v1_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditRecord) ProtoMessage() {}
+
+func (x *AuditRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_order_admin_v1_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditRecord.ProtoReflect.Descriptor instead.
+func (*AuditRecord) Descriptor() ([]byte, []int) {
+	return file_order_admin_v1_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *AuditRecord) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AuditRecord) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *AuditRecord) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *AuditRecord) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *AuditRecord) GetMarketId() string {
+	if x != nil {
+		return x.MarketId
+	}
+	return ""
+}
+
+func (x *AuditRecord) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *AuditRecord) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+func (x *AuditRecord) GetDetails() string {
+	if x != nil {
+		return x.Details
+	}
+	return ""
+}
+
+func (x *AuditRecord) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+var File_order_admin_v1_proto protoreflect.FileDescriptor
+
+const file_order_admin_v1_proto_rawDesc = "" +
+	"\n" +
+	"\x14order/admin_v1.proto\x12\border.v1\x1a\x1bbuf/validate/validate.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x14order/order_v1.proto\"\xb3\x03\n" +
+	"\x16AdminListOrdersRequest\x12$\n" +
+	"\auser_id\x18\x01 \x01(\tB\v\xbaH\b\xd8\x01\x01r\x03\xb0\x01\x01R\x06userId\x12(\n" +
+	"\tmarket_id\x18\x02 \x01(\tB\v\xbaH\b\xd8\x01\x01r\x03\xb0\x01\x01R\bmarketId\x12B\n" +
+	"\bstatuses\x18\x03 \x03(\x0e2\x15.order.v1.OrderStatusB\x0f\xbaH\f\x92\x01\t\"\a\x82\x01\x04\x10\x01 \x00R\bstatuses\x12E\n" +
+	"\vorder_types\x18\x04 \x03(\x0e2\x13.order.v1.OrderTypeB\x0f\xbaH\f\x92\x01\t\"\a\x82\x01\x04\x10\x01 \x00R\n" +
+	"orderTypes\x12=\n" +
+	"\fcreated_from\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\vcreatedFrom\x129\n" +
+	"\n" +
+	"created_to\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedTo\x12%\n" +
+	"\tpage_size\x18\a \x01(\rB\b\xbaH\x05*\x03\x18\xf4\x03R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\b \x01(\tR\tpageToken\">\n" +
+	"\x14AdminGetOrderRequest\x12&\n" +
+	"\border_id\x18\x01 \x01(\tB\v\xbaH\b\xc8\x01\x01r\x03\xb0\x01\x01R\aorderId\"\xa4\x01\n" +
+	"\x16ForceTransitionRequest\x12&\n" +
+	"\border_id\x18\x01 \x01(\tB\v\xbaH\b\xc8\x01\x01r\x03\xb0\x01\x01R\aorderId\x12>\n" +
+	"\tto_status\x18\x02 \x01(\x0e2\x15.order.v1.OrderStatusB\n" +
+	"\xbaH\a\x82\x01\x04\x18\x04\x18\x05R\btoStatus\x12\"\n" +
+	"\x06reason\x18\x03 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80\x04R\x06reason\"@\n" +
+	"\x17ForceTransitionResponse\x12%\n" +
+	"\x05order\x18\x01 \x01(\v2\x0f.order.v1.OrderR\x05order\"i\n" +
+	"\x19CancelMarketOrdersRequest\x12(\n" +
+	"\tmarket_id\x18\x01 \x01(\tB\v\xbaH\b\xc8\x01\x01r\x03\xb0\x01\x01R\bmarketId\x12\"\n" +
+	"\x06reason\x18\x02 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80\x04R\x06reason\"v\n" +
+	"\x1aCancelMarketOrdersResponse\x12.\n" +
+	"\x13cancelled_order_ids\x18\x01 \x03(\tR\x11cancelledOrderIds\x12(\n" +
+	"\x10failed_order_ids\x18\x02 \x03(\tR\x0efailedOrderIds\"a\n" +
+	"\x17ListAuditRecordsRequest\x12&\n" +
+	"\border_id\x18\x01 \x01(\tB\v\xbaH\b\xd8\x01\x01r\x03\xb0\x01\x01R\aorderId\x12\x1e\n" +
+	"\x05limit\x18\x02 \x01(\rB\b\xbaH\x05*\x03\x18\xf4\x03R\x05limit\"K\n" +
+	"\x18ListAuditRecordsResponse\x12/\n" +
+	"\arecords\x18\x01 \x03(\v2\x15.order.v1.AuditRecordR\arecords\"\x8c\x02\n" +
+	"\vAuditRecord\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05actor\x18\x02 \x01(\tR\x05actor\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\x12\x19\n" +
+	"\border_id\x18\x04 \x01(\tR\aorderId\x12\x1b\n" +
+	"\tmarket_id\x18\x05 \x01(\tR\bmarketId\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\x12\x18\n" +
+	"\aoutcome\x18\a \x01(\tR\aoutcome\x12\x18\n" +
+	"\adetails\x18\b \x01(\tR\adetails\x12;\n" +
+	"\voccurred_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt2\xbd\x03\n" +
+	"\x11OrderAdminService\x12L\n" +
+	"\n" +
+	"ListOrders\x12 .order.v1.AdminListOrdersRequest\x1a\x1c.order.v1.ListOrdersResponse\x12F\n" +
+	"\bGetOrder\x12\x1e.order.v1.AdminGetOrderRequest\x1a\x1a.order.v1.GetOrderResponse\x12V\n" +
+	"\x0fForceTransition\x12 .order.v1.ForceTransitionRequest\x1a!.order.v1.ForceTransitionResponse\x12_\n" +
+	"\x12CancelMarketOrders\x12#.order.v1.CancelMarketOrdersRequest\x1a$.order.v1.CancelMarketOrdersResponse\x12Y\n" +
+	"\x10ListAuditRecords\x12!.order.v1.ListAuditRecordsRequest\x1a\".order.v1.ListAuditRecordsResponseB=Z;github.com/chilly266futon/orderService/gen/pb/order;orderv1b\x06proto3"
+
+var (
+	file_order_admin_v1_proto_rawDescOnce sync.Once
+	file_order_admin_v1_proto_rawDescData []byte
+)
+
+func file_order_admin_v1_proto_rawDescGZIP() []byte {
+	file_order_admin_v1_proto_rawDescOnce.Do(func() {
+		file_order_admin_v1_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_order_admin_v1_proto_rawDesc), len(file_order_admin_v1_proto_rawDesc)))
+	})
+	return file_order_admin_v1_proto_rawDescData
+}
+
+var file_order_admin_v1_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_order_admin_v1_proto_goTypes = []any{
+	(*AdminListOrdersRequest)(nil),     // 0: order.v1.AdminListOrdersRequest
+	(*AdminGetOrderRequest)(nil),       // 1: order.v1.AdminGetOrderRequest
+	(*ForceTransitionRequest)(nil),     // 2: order.v1.ForceTransitionRequest
+	(*ForceTransitionResponse)(nil),    // 3: order.v1.ForceTransitionResponse
+	(*CancelMarketOrdersRequest)(nil),  // 4: order.v1.CancelMarketOrdersRequest
+	(*CancelMarketOrdersResponse)(nil), // 5: order.v1.CancelMarketOrdersResponse
+	(*ListAuditRecordsRequest)(nil),    // 6: order.v1.ListAuditRecordsRequest
+	(*ListAuditRecordsResponse)(nil),   // 7: order.v1.ListAuditRecordsResponse
+	(*AuditRecord)(nil),                // 8: order.v1.AuditRecord
+	(OrderStatus)(0),                   // 9: order.v1.OrderStatus
+	(OrderType)(0),                     // 10: order.v1.OrderType
+	(*timestamppb.Timestamp)(nil),      // 11: google.protobuf.Timestamp
+	(*Order)(nil),                      // 12: order.v1.Order
+	(*ListOrdersResponse)(nil),         // 13: order.v1.ListOrdersResponse
+	(*GetOrderResponse)(nil),           // 14: order.v1.GetOrderResponse
+}
+var file_order_admin_v1_proto_depIdxs = []int32{
+	9,  // 0: order.v1.AdminListOrdersRequest.statuses:type_name -> order.v1.OrderStatus
+	10, // 1: order.v1.AdminListOrdersRequest.order_types:type_name -> order.v1.OrderType
+	11, // 2: order.v1.AdminListOrdersRequest.created_from:type_name -> google.protobuf.Timestamp
+	11, // 3: order.v1.AdminListOrdersRequest.created_to:type_name -> google.protobuf.Timestamp
+	9,  // 4: order.v1.ForceTransitionRequest.to_status:type_name -> order.v1.OrderStatus
+	12, // 5: order.v1.ForceTransitionResponse.order:type_name -> order.v1.Order
+	8,  // 6: order.v1.ListAuditRecordsResponse.records:type_name -> order.v1.AuditRecord
+	11, // 7: order.v1.AuditRecord.occurred_at:type_name -> google.protobuf.Timestamp
+	0,  // 8: order.v1.OrderAdminService.ListOrders:input_type -> order.v1.AdminListOrdersRequest
+	1,  // 9: order.v1.OrderAdminService.GetOrder:input_type -> order.v1.AdminGetOrderRequest
+	2,  // 10: order.v1.OrderAdminService.ForceTransition:input_type -> order.v1.ForceTransitionRequest
+	4,  // 11: order.v1.OrderAdminService.CancelMarketOrders:input_type -> order.v1.CancelMarketOrdersRequest
+	6,  // 12: order.v1.OrderAdminService.ListAuditRecords:input_type -> order.v1.ListAuditRecordsRequest
+	13, // 13: order.v1.OrderAdminService.ListOrders:output_type -> order.v1.ListOrdersResponse
+	14, // 14: order.v1.OrderAdminService.GetOrder:output_type -> order.v1.GetOrderResponse
+	3,  // 15: order.v1.OrderAdminService.ForceTransition:output_type -> order.v1.ForceTransitionResponse
+	5,  // 16: order.v1.OrderAdminService.CancelMarketOrders:output_type -> order.v1.CancelMarketOrdersResponse
+	7,  // 17: order.v1.OrderAdminService.ListAuditRecords:output_type -> order.v1.ListAuditRecordsResponse
+	13, // [13:18] is the sub-list for method output_type
+	8,  // [8:13] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_order_admin_v1_proto_init() }
+func file_order_admin_v1_proto_init() {
+	if File_order_admin_v1_proto != nil {
+		return
+	}
+	file_order_order_v1_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_order_admin_v1_proto_rawDesc), len(file_order_admin_v1_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_order_admin_v1_proto_goTypes,
+		DependencyIndexes: file_order_admin_v1_proto_depIdxs,
+		MessageInfos:      file_order_admin_v1_proto_msgTypes,
+	}.Build()
+	File_order_admin_v1_proto = out.File
+	file_order_admin_v1_proto_goTypes = nil
+	file_order_admin_v1_proto_depIdxs = nil
+}
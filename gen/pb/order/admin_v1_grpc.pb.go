@@ -0,0 +1,279 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.1
+// - protoc             (unknown)
+// source: order/admin_v1.proto
+
+package orderv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	OrderAdminService_ListOrders_FullMethodName         = "/order.v1.OrderAdminService/ListOrders"
+	OrderAdminService_GetOrder_FullMethodName           = "/order.v1.OrderAdminService/GetOrder"
+	OrderAdminService_ForceTransition_FullMethodName    = "/order.v1.OrderAdminService/ForceTransition"
+	OrderAdminService_CancelMarketOrders_FullMethodName = "/order.v1.OrderAdminService/CancelMarketOrders"
+	OrderAdminService_ListAuditRecords_FullMethodName   = "/order.v1.OrderAdminService/ListAuditRecords"
+)
+
+// OrderAdminServiceClient is the client API for OrderAdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// OrderAdminService операции администраторов над заказами любых пользователей.
+// Доступен только с ролью администратора, каждое действие пишется в журнал аудита
+type OrderAdminServiceClient interface {
+	ListOrders(ctx context.Context, in *AdminListOrdersRequest, opts ...grpc.CallOption) (*ListOrdersResponse, error)
+	GetOrder(ctx context.Context, in *AdminGetOrderRequest, opts ...grpc.CallOption) (*GetOrderResponse, error)
+	ForceTransition(ctx context.Context, in *ForceTransitionRequest, opts ...grpc.CallOption) (*ForceTransitionResponse, error)
+	CancelMarketOrders(ctx context.Context, in *CancelMarketOrdersRequest, opts ...grpc.CallOption) (*CancelMarketOrdersResponse, error)
+	ListAuditRecords(ctx context.Context, in *ListAuditRecordsRequest, opts ...grpc.CallOption) (*ListAuditRecordsResponse, error)
+}
+
+type orderAdminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewOrderAdminServiceClient(cc grpc.ClientConnInterface) OrderAdminServiceClient {
+	return &orderAdminServiceClient{cc}
+}
+
+func (c *orderAdminServiceClient) ListOrders(ctx context.Context, in *AdminListOrdersRequest, opts ...grpc.CallOption) (*ListOrdersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOrdersResponse)
+	err := c.cc.Invoke(ctx, OrderAdminService_ListOrders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderAdminServiceClient) GetOrder(ctx context.Context, in *AdminGetOrderRequest, opts ...grpc.CallOption) (*GetOrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetOrderResponse)
+	err := c.cc.Invoke(ctx, OrderAdminService_GetOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderAdminServiceClient) ForceTransition(ctx context.Context, in *ForceTransitionRequest, opts ...grpc.CallOption) (*ForceTransitionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ForceTransitionResponse)
+	err := c.cc.Invoke(ctx, OrderAdminService_ForceTransition_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderAdminServiceClient) CancelMarketOrders(ctx context.Context, in *CancelMarketOrdersRequest, opts ...grpc.CallOption) (*CancelMarketOrdersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelMarketOrdersResponse)
+	err := c.cc.Invoke(ctx, OrderAdminService_CancelMarketOrders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderAdminServiceClient) ListAuditRecords(ctx context.Context, in *ListAuditRecordsRequest, opts ...grpc.CallOption) (*ListAuditRecordsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAuditRecordsResponse)
+	err := c.cc.Invoke(ctx, OrderAdminService_ListAuditRecords_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OrderAdminServiceServer is the server API for OrderAdminService service.
+// All implementations must embed UnimplementedOrderAdminServiceServer
+// for forward compatibility.
+//
+// OrderAdminService операции администраторов над заказами любых пользователей.
+// Доступен только с ролью администратора, каждое действие пишется в журнал аудита
+type OrderAdminServiceServer interface {
+	ListOrders(context.Context, *AdminListOrdersRequest) (*ListOrdersResponse, error)
+	GetOrder(context.Context, *AdminGetOrderRequest) (*GetOrderResponse, error)
+	ForceTransition(context.Context, *ForceTransitionRequest) (*ForceTransitionResponse, error)
+	CancelMarketOrders(context.Context, *CancelMarketOrdersRequest) (*CancelMarketOrdersResponse, error)
+	ListAuditRecords(context.Context, *ListAuditRecordsRequest) (*ListAuditRecordsResponse, error)
+	mustEmbedUnimplementedOrderAdminServiceServer()
+}
+
+// UnimplementedOrderAdminServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedOrderAdminServiceServer struct{}
+
+func (UnimplementedOrderAdminServiceServer) ListOrders(context.Context, *AdminListOrdersRequest) (*ListOrdersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListOrders not implemented")
+}
+func (UnimplementedOrderAdminServiceServer) GetOrder(context.Context, *AdminGetOrderRequest) (*GetOrderResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetOrder not implemented")
+}
+func (UnimplementedOrderAdminServiceServer) ForceTransition(context.Context, *ForceTransitionRequest) (*ForceTransitionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ForceTransition not implemented")
+}
+func (UnimplementedOrderAdminServiceServer) CancelMarketOrders(context.Context, *CancelMarketOrdersRequest) (*CancelMarketOrdersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CancelMarketOrders not implemented")
+}
+func (UnimplementedOrderAdminServiceServer) ListAuditRecords(context.Context, *ListAuditRecordsRequest) (*ListAuditRecordsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListAuditRecords not implemented")
+}
+func (UnimplementedOrderAdminServiceServer) mustEmbedUnimplementedOrderAdminServiceServer() {}
+func (UnimplementedOrderAdminServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafeOrderAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to OrderAdminServiceServer will
+// result in compilation errors.
+type UnsafeOrderAdminServiceServer interface {
+	mustEmbedUnimplementedOrderAdminServiceServer()
+}
+
+func RegisterOrderAdminServiceServer(s grpc.ServiceRegistrar, srv OrderAdminServiceServer) {
+	// If the following call panics, it indicates UnimplementedOrderAdminServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&OrderAdminService_ServiceDesc, srv)
+}
+
+func _OrderAdminService_ListOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminListOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderAdminServiceServer).ListOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderAdminService_ListOrders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderAdminServiceServer).ListOrders(ctx, req.(*AdminListOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderAdminService_GetOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminGetOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderAdminServiceServer).GetOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderAdminService_GetOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderAdminServiceServer).GetOrder(ctx, req.(*AdminGetOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderAdminService_ForceTransition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForceTransitionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderAdminServiceServer).ForceTransition(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderAdminService_ForceTransition_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderAdminServiceServer).ForceTransition(ctx, req.(*ForceTransitionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderAdminService_CancelMarketOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelMarketOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderAdminServiceServer).CancelMarketOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderAdminService_CancelMarketOrders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderAdminServiceServer).CancelMarketOrders(ctx, req.(*CancelMarketOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderAdminService_ListAuditRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditRecordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderAdminServiceServer).ListAuditRecords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderAdminService_ListAuditRecords_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderAdminServiceServer).ListAuditRecords(ctx, req.(*ListAuditRecordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// OrderAdminService_ServiceDesc is the grpc.ServiceDesc for OrderAdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var OrderAdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "order.v1.OrderAdminService",
+	HandlerType: (*OrderAdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListOrders",
+			Handler:    _OrderAdminService_ListOrders_Handler,
+		},
+		{
+			MethodName: "GetOrder",
+			Handler:    _OrderAdminService_GetOrder_Handler,
+		},
+		{
+			MethodName: "ForceTransition",
+			Handler:    _OrderAdminService_ForceTransition_Handler,
+		},
+		{
+			MethodName: "CancelMarketOrders",
+			Handler:    _OrderAdminService_CancelMarketOrders_Handler,
+		},
+		{
+			MethodName: "ListAuditRecords",
+			Handler:    _OrderAdminService_ListAuditRecords_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "order/admin_v1.proto",
+}
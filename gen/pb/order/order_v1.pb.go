@@ -0,0 +1,1970 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: order/order_v1.proto
+
+package orderv1
+
+import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type OrderEventType int32
+
+const (
+	OrderEventType_ORDER_EVENT_TYPE_UNSPECIFIED    OrderEventType = 0
+	OrderEventType_ORDER_EVENT_TYPE_CREATED        OrderEventType = 1
+	OrderEventType_ORDER_EVENT_TYPE_CANCELLED      OrderEventType = 2
+	OrderEventType_ORDER_EVENT_TYPE_FILLED         OrderEventType = 3 // исполнение сделки
+	OrderEventType_ORDER_EVENT_TYPE_REJECTED       OrderEventType = 4
+	OrderEventType_ORDER_EVENT_TYPE_STATUS_CHANGED OrderEventType = 5 // прочие смены статуса
+	OrderEventType_ORDER_EVENT_TYPE_AMENDED        OrderEventType = 6 // изменение цены или объема
+)
+
+// Enum value maps for OrderEventType.
+var (
+	OrderEventType_name = map[int32]string{
+		0: "ORDER_EVENT_TYPE_UNSPECIFIED",
+		1: "ORDER_EVENT_TYPE_CREATED",
+		2: "ORDER_EVENT_TYPE_CANCELLED",
+		3: "ORDER_EVENT_TYPE_FILLED",
+		4: "ORDER_EVENT_TYPE_REJECTED",
+		5: "ORDER_EVENT_TYPE_STATUS_CHANGED",
+		6: "ORDER_EVENT_TYPE_AMENDED",
+	}
+	OrderEventType_value = map[string]int32{
+		"ORDER_EVENT_TYPE_UNSPECIFIED":    0,
+		"ORDER_EVENT_TYPE_CREATED":        1,
+		"ORDER_EVENT_TYPE_CANCELLED":      2,
+		"ORDER_EVENT_TYPE_FILLED":         3,
+		"ORDER_EVENT_TYPE_REJECTED":       4,
+		"ORDER_EVENT_TYPE_STATUS_CHANGED": 5,
+		"ORDER_EVENT_TYPE_AMENDED":        6,
+	}
+)
+
+func (x OrderEventType) Enum() *OrderEventType {
+	p := new(OrderEventType)
+	*p = x
+	return p
+}
+
+func (x OrderEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrderEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_order_order_v1_proto_enumTypes[0].Descriptor()
+}
+
+func (OrderEventType) Type() protoreflect.EnumType {
+	return &file_order_order_v1_proto_enumTypes[0]
+}
+
+func (x OrderEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrderEventType.Descriptor instead.
+func (OrderEventType) EnumDescriptor() ([]byte, []int) {
+	return file_order_order_v1_proto_rawDescGZIP(), []int{0}
+}
+
+type OrderType int32
+
+const (
+	OrderType_ORDER_TYPE_UNSPECIFIED OrderType = 0
+	OrderType_ORDER_TYPE_LIMIT       OrderType = 1
+	OrderType_ORDER_TYPE_MARKET      OrderType = 2
+	OrderType_ORDER_TYPE_STOP_LIMIT  OrderType = 3
+	OrderType_ORDER_TYPE_STOP_MARKET OrderType = 4
+)
+
+// Enum value maps for OrderType.
+var (
+	OrderType_name = map[int32]string{
+		0: "ORDER_TYPE_UNSPECIFIED",
+		1: "ORDER_TYPE_LIMIT",
+		2: "ORDER_TYPE_MARKET",
+		3: "ORDER_TYPE_STOP_LIMIT",
+		4: "ORDER_TYPE_STOP_MARKET",
+	}
+	OrderType_value = map[string]int32{
+		"ORDER_TYPE_UNSPECIFIED": 0,
+		"ORDER_TYPE_LIMIT":       1,
+		"ORDER_TYPE_MARKET":      2,
+		"ORDER_TYPE_STOP_LIMIT":  3,
+		"ORDER_TYPE_STOP_MARKET": 4,
+	}
+)
+
+func (x OrderType) Enum() *OrderType {
+	p := new(OrderType)
+	*p = x
+	return p
+}
+
+func (x OrderType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrderType) Descriptor() protoreflect.EnumDescriptor {
+	return file_order_order_v1_proto_enumTypes[1].Descriptor()
+}
+
+func (OrderType) Type() protoreflect.EnumType {
+	return &file_order_order_v1_proto_enumTypes[1]
+}
+
+func (x OrderType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrderType.Descriptor instead.
+func (OrderType) EnumDescriptor() ([]byte, []int) {
+	return file_order_order_v1_proto_rawDescGZIP(), []int{1}
+}
+
+type TimeInForce int32
+
+const (
+	TimeInForce_TIME_IN_FORCE_UNSPECIFIED TimeInForce = 0
+	TimeInForce_TIME_IN_FORCE_GTC         TimeInForce = 1 // до отмены
+	TimeInForce_TIME_IN_FORCE_IOC         TimeInForce = 2 // исполнить сразу в доступном объеме, остаток отменить
+	TimeInForce_TIME_IN_FORCE_FOK         TimeInForce = 3 // исполнить сразу целиком или отменить
+	TimeInForce_TIME_IN_FORCE_GTD         TimeInForce = 4 // до expires_at
+)
+
+// Enum value maps for TimeInForce.
+var (
+	TimeInForce_name = map[int32]string{
+		0: "TIME_IN_FORCE_UNSPECIFIED",
+		1: "TIME_IN_FORCE_GTC",
+		2: "TIME_IN_FORCE_IOC",
+		3: "TIME_IN_FORCE_FOK",
+		4: "TIME_IN_FORCE_GTD",
+	}
+	TimeInForce_value = map[string]int32{
+		"TIME_IN_FORCE_UNSPECIFIED": 0,
+		"TIME_IN_FORCE_GTC":         1,
+		"TIME_IN_FORCE_IOC":         2,
+		"TIME_IN_FORCE_FOK":         3,
+		"TIME_IN_FORCE_GTD":         4,
+	}
+)
+
+func (x TimeInForce) Enum() *TimeInForce {
+	p := new(TimeInForce)
+	*p = x
+	return p
+}
+
+func (x TimeInForce) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TimeInForce) Descriptor() protoreflect.EnumDescriptor {
+	return file_order_order_v1_proto_enumTypes[2].Descriptor()
+}
+
+func (TimeInForce) Type() protoreflect.EnumType {
+	return &file_order_order_v1_proto_enumTypes[2]
+}
+
+func (x TimeInForce) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TimeInForce.Descriptor instead.
+func (TimeInForce) EnumDescriptor() ([]byte, []int) {
+	return file_order_order_v1_proto_rawDescGZIP(), []int{2}
+}
+
+type OrderSide int32
+
+const (
+	OrderSide_ORDER_SIDE_UNSPECIFIED OrderSide = 0
+	OrderSide_ORDER_SIDE_BUY         OrderSide = 1 // покупка (bid)
+	OrderSide_ORDER_SIDE_SELL        OrderSide = 2 // продажа (ask)
+)
+
+// Enum value maps for OrderSide.
+var (
+	OrderSide_name = map[int32]string{
+		0: "ORDER_SIDE_UNSPECIFIED",
+		1: "ORDER_SIDE_BUY",
+		2: "ORDER_SIDE_SELL",
+	}
+	OrderSide_value = map[string]int32{
+		"ORDER_SIDE_UNSPECIFIED": 0,
+		"ORDER_SIDE_BUY":         1,
+		"ORDER_SIDE_SELL":        2,
+	}
+)
+
+func (x OrderSide) Enum() *OrderSide {
+	p := new(OrderSide)
+	*p = x
+	return p
+}
+
+func (x OrderSide) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrderSide) Descriptor() protoreflect.EnumDescriptor {
+	return file_order_order_v1_proto_enumTypes[3].Descriptor()
+}
+
+func (OrderSide) Type() protoreflect.EnumType {
+	return &file_order_order_v1_proto_enumTypes[3]
+}
+
+func (x OrderSide) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrderSide.Descriptor instead.
+func (OrderSide) EnumDescriptor() ([]byte, []int) {
+	return file_order_order_v1_proto_rawDescGZIP(), []int{3}
+}
+
+type OrderStatus int32
+
+const (
+	OrderStatus_ORDER_STATUS_UNSPECIFIED      OrderStatus = 0
+	OrderStatus_ORDER_STATUS_CREATED          OrderStatus = 1 // принят, но ещё не в стакане
+	OrderStatus_ORDER_STATUS_OPEN             OrderStatus = 2 // активен в стакане
+	OrderStatus_ORDER_STATUS_FILLED           OrderStatus = 3 // полностью исполнен
+	OrderStatus_ORDER_STATUS_CANCELLED        OrderStatus = 4 // отменен
+	OrderStatus_ORDER_STATUS_REJECTED         OrderStatus = 5 // отклонен (ошибка при создании)
+	OrderStatus_ORDER_STATUS_PARTIALLY_FILLED OrderStatus = 6 // частично исполнен, остаток в стакане
+	OrderStatus_ORDER_STATUS_EXPIRED          OrderStatus = 7 // истек срок действия GTD
+)
+
+// Enum value maps for OrderStatus.
+var (
+	OrderStatus_name = map[int32]string{
+		0: "ORDER_STATUS_UNSPECIFIED",
+		1: "ORDER_STATUS_CREATED",
+		2: "ORDER_STATUS_OPEN",
+		3: "ORDER_STATUS_FILLED",
+		4: "ORDER_STATUS_CANCELLED",
+		5: "ORDER_STATUS_REJECTED",
+		6: "ORDER_STATUS_PARTIALLY_FILLED",
+		7: "ORDER_STATUS_EXPIRED",
+	}
+	OrderStatus_value = map[string]int32{
+		"ORDER_STATUS_UNSPECIFIED":      0,
+		"ORDER_STATUS_CREATED":          1,
+		"ORDER_STATUS_OPEN":             2,
+		"ORDER_STATUS_FILLED":           3,
+		"ORDER_STATUS_CANCELLED":        4,
+		"ORDER_STATUS_REJECTED":         5,
+		"ORDER_STATUS_PARTIALLY_FILLED": 6,
+		"ORDER_STATUS_EXPIRED":          7,
+	}
+)
+
+func (x OrderStatus) Enum() *OrderStatus {
+	p := new(OrderStatus)
+	*p = x
+	return p
+}
+
+func (x OrderStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrderStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_order_order_v1_proto_enumTypes[4].Descriptor()
+}
+
+func (OrderStatus) Type() protoreflect.EnumType {
+	return &file_order_order_v1_proto_enumTypes[4]
+}
+
+func (x OrderStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrderStatus.Descriptor instead.
+func (OrderStatus) EnumDescriptor() ([]byte, []int) {
+	return file_order_order_v1_proto_rawDescGZIP(), []int{4}
+}
+
+type GetOrderStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"` // UUID
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`    // UUID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderStatusRequest) Reset() {
+	*x = GetOrderStatusRequest{}
+	mi := &file_order_order_v1_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderStatusRequest) ProtoMessage() {}
+
+func (x *GetOrderStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_v1_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderStatusRequest) Descriptor() ([]byte, []int) {
+	return file_order_order_v1_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetOrderStatusRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *GetOrderStatusRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetOrderStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"` // UUID
+	Status        OrderStatus            `protobuf:"varint,2,opt,name=status,proto3,enum=order.v1.OrderStatus" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderStatusResponse) Reset() {
+	*x = GetOrderStatusResponse{}
+	mi := &file_order_order_v1_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderStatusResponse) ProtoMessage() {}
+
+func (x *GetOrderStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_v1_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetOrderStatusResponse) Descriptor() ([]byte, []int) {
+	return file_order_order_v1_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetOrderStatusResponse) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *GetOrderStatusResponse) GetStatus() OrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+type GetOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"` // UUID
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`    // UUID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderRequest) Reset() {
+	*x = GetOrderRequest{}
+	mi := &file_order_order_v1_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderRequest) ProtoMessage() {}
+
+func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_v1_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderRequest) Descriptor() ([]byte, []int) {
+	return file_order_order_v1_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *GetOrderRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderResponse) Reset() {
+	*x = GetOrderResponse{}
+	mi := &file_order_order_v1_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderResponse) ProtoMessage() {}
+
+func (x *GetOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_v1_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderResponse.ProtoReflect.Descriptor instead.
+func (*GetOrderResponse) Descriptor() ([]byte, []int) {
+	return file_order_order_v1_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetOrderResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type CreateOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`       // UUID
+	MarketId      string                 `protobuf:"bytes,2,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"` // UUID торговой пары
+	OrderType     OrderType              `protobuf:"varint,3,opt,name=order_type,json=orderType,proto3,enum=order.v1.OrderType" json:"order_type,omitempty"`
+	Price         string                 `protobuf:"bytes,4,opt,name=price,proto3" json:"price,omitempty"`       // лимитная цена; обязательна для LIMIT и STOP_LIMIT, запрещена для MARKET и STOP_MARKET
+	Quantity      string                 `protobuf:"bytes,5,opt,name=quantity,proto3" json:"quantity,omitempty"` // количество в минимальных единицах
+	Side          OrderSide              `protobuf:"varint,6,opt,name=side,proto3,enum=order.v1.OrderSide" json:"side,omitempty"`
+	ClientOrderId string                 `protobuf:"bytes,7,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"`                      // ключ идемпотентности, уникален в пределах пользователя
+	StopPrice     string                 `protobuf:"bytes,8,opt,name=stop_price,json=stopPrice,proto3" json:"stop_price,omitempty"`                                    // цена активации; обязательна для STOP_LIMIT и STOP_MARKET, запрещена для остальных. Лимит STOP_LIMIT на покупку не ниже нее, на продажу не выше
+	TimeInForce   TimeInForce            `protobuf:"varint,9,opt,name=time_in_force,json=timeInForce,proto3,enum=order.v1.TimeInForce" json:"time_in_force,omitempty"` // не указан - GTC для лимитных заказов, IOC для рыночных
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`                                   // обязателен для GTD, запрещен для остальных
+	PostOnly      bool                   `protobuf:"varint,11,opt,name=post_only,json=postOnly,proto3" json:"post_only,omitempty"`                                     // только добавлять ликвидность: отклоняется, если сразу исполнился бы; только LIMIT и STOP_LIMIT без IOC и FOK
+	ReduceOnly    bool                   `protobuf:"varint,12,opt,name=reduce_only,json=reduceOnly,proto3" json:"reduce_only,omitempty"`                               // только сокращать позицию на рынке: встречно исполненному объему и не больше него
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrderRequest) Reset() {
+	*x = CreateOrderRequest{}
+	mi := &file_order_order_v1_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrderRequest) ProtoMessage() {}
+
+func (x *CreateOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_v1_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrderRequest.ProtoReflect.Descriptor instead.
+func (*CreateOrderRequest) Descriptor() ([]byte, []int) {
+	return file_order_order_v1_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateOrderRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CreateOrderRequest) GetMarketId() string {
+	if x != nil {
+		return x.MarketId
+	}
+	return ""
+}
+
+func (x *CreateOrderRequest) GetOrderType() OrderType {
+	if x != nil {
+		return x.OrderType
+	}
+	return OrderType_ORDER_TYPE_UNSPECIFIED
+}
+
+func (x *CreateOrderRequest) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *CreateOrderRequest) GetQuantity() string {
+	if x != nil {
+		return x.Quantity
+	}
+	return ""
+}
+
+func (x *CreateOrderRequest) GetSide() OrderSide {
+	if x != nil {
+		return x.Side
+	}
+	return OrderSide_ORDER_SIDE_UNSPECIFIED
+}
+
+func (x *CreateOrderRequest) GetClientOrderId() string {
+	if x != nil {
+		return x.ClientOrderId
+	}
+	return ""
+}
+
+func (x *CreateOrderRequest) GetStopPrice() string {
+	if x != nil {
+		return x.StopPrice
+	}
+	return ""
+}
+
+func (x *CreateOrderRequest) GetTimeInForce() TimeInForce {
+	if x != nil {
+		return x.TimeInForce
+	}
+	return TimeInForce_TIME_IN_FORCE_UNSPECIFIED
+}
+
+func (x *CreateOrderRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *CreateOrderRequest) GetPostOnly() bool {
+	if x != nil {
+		return x.PostOnly
+	}
+	return false
+}
+
+func (x *CreateOrderRequest) GetReduceOnly() bool {
+	if x != nil {
+		return x.ReduceOnly
+	}
+	return false
+}
+
+type CreateOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"` // UUID
+	Status        OrderStatus            `protobuf:"varint,2,opt,name=status,proto3,enum=order.v1.OrderStatus" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrderResponse) Reset() {
+	*x = CreateOrderResponse{}
+	mi := &file_order_order_v1_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrderResponse) ProtoMessage() {}
+
+func (x *CreateOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_v1_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrderResponse.ProtoReflect.Descriptor instead.
+func (*CreateOrderResponse) Descriptor() ([]byte, []int) {
+	return file_order_order_v1_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateOrderResponse) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *CreateOrderResponse) GetStatus() OrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+type CancelOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"` // UUID
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`    // UUID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelOrderRequest) Reset() {
+	*x = CancelOrderRequest{}
+	mi := &file_order_order_v1_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderRequest) ProtoMessage() {}
+
+func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_v1_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderRequest.ProtoReflect.Descriptor instead.
+func (*CancelOrderRequest) Descriptor() ([]byte, []int) {
+	return file_order_order_v1_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CancelOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *CancelOrderRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type CancelOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"` // UUID
+	Status        OrderStatus            `protobuf:"varint,2,opt,name=status,proto3,enum=order.v1.OrderStatus" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelOrderResponse) Reset() {
+	*x = CancelOrderResponse{}
+	mi := &file_order_order_v1_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderResponse) ProtoMessage() {}
+
+func (x *CancelOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_v1_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderResponse.ProtoReflect.Descriptor instead.
+func (*CancelOrderResponse) Descriptor() ([]byte, []int) {
+	return file_order_order_v1_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CancelOrderResponse) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *CancelOrderResponse) GetStatus() OrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+// ReplaceOrderRequest изменение цены и/или объема незавершенного лимитного заказа; нужно указать хотя бы одно.
+// Рыночные и еще не активированные стоп-заказы не изменяются
+type ReplaceOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"` // UUID
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`    // UUID
+	Price         string                 `protobuf:"bytes,3,opt,name=price,proto3" json:"price,omitempty"`                    // новая лимитная цена больше нуля, пусто - без изменений
+	Quantity      string                 `protobuf:"bytes,4,opt,name=quantity,proto3" json:"quantity,omitempty"`              // новый объем, больше исполненного; пусто - без изменений, "0" отклоняется
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplaceOrderRequest) Reset() {
+	*x = ReplaceOrderRequest{}
+	mi := &file_order_order_v1_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplaceOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplaceOrderRequest) ProtoMessage() {}
+
+func (x *ReplaceOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_v1_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplaceOrderRequest.ProtoReflect.Descriptor instead.
+func (*ReplaceOrderRequest) Descriptor() ([]byte, []int) {
+	return file_order_order_v1_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ReplaceOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *ReplaceOrderRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ReplaceOrderRequest) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *ReplaceOrderRequest) GetQuantity() string {
+	if x != nil {
+		return x.Quantity
+	}
+	return ""
+}
+
+type ReplaceOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplaceOrderResponse) Reset() {
+	*x = ReplaceOrderResponse{}
+	mi := &file_order_order_v1_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplaceOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplaceOrderResponse) ProtoMessage() {}
+
+func (x *ReplaceOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_v1_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplaceOrderResponse.ProtoReflect.Descriptor instead.
+func (*ReplaceOrderResponse) Descriptor() ([]byte, []int) {
+	return file_order_order_v1_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ReplaceOrderResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type ListOrdersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                                             // UUID
+	MarketId      string                 `protobuf:"bytes,2,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`                                       // UUID торговой пары, пусто - все пары
+	Statuses      []OrderStatus          `protobuf:"varint,3,rep,packed,name=statuses,proto3,enum=order.v1.OrderStatus" json:"statuses,omitempty"`                     // пусто - любые статусы
+	OrderTypes    []OrderType            `protobuf:"varint,4,rep,packed,name=order_types,json=orderTypes,proto3,enum=order.v1.OrderType" json:"order_types,omitempty"` // пусто - любые типы
+	CreatedFrom   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`                              // включительно
+	CreatedTo     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`                                    // не включительно
+	PageSize      uint32                 `protobuf:"varint,7,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`                                      // 0 - размер по умолчанию
+	PageToken     string                 `protobuf:"bytes,8,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`                                    // next_page_token из предыдущего ответа
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrdersRequest) Reset() {
+	*x = ListOrdersRequest{}
+	mi := &file_order_order_v1_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrdersRequest) ProtoMessage() {}
+
+func (x *ListOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_v1_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrdersRequest.ProtoReflect.Descriptor instead.
+func (*ListOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_order_order_v1_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListOrdersRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListOrdersRequest) GetMarketId() string {
+	if x != nil {
+		return x.MarketId
+	}
+	return ""
+}
+
+func (x *ListOrdersRequest) GetStatuses() []OrderStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *ListOrdersRequest) GetOrderTypes() []OrderType {
+	if x != nil {
+		return x.OrderTypes
+	}
+	return nil
+}
+
+func (x *ListOrdersRequest) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *ListOrdersRequest) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
+
+func (x *ListOrdersRequest) GetPageSize() uint32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListOrdersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// ListOrdersResponse заказы отсортированы от новых к старым
+type ListOrdersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Orders        []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // пусто, если страниц больше нет
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrdersResponse) Reset() {
+	*x = ListOrdersResponse{}
+	mi := &file_order_order_v1_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrdersResponse) ProtoMessage() {}
+
+func (x *ListOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_v1_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrdersResponse.ProtoReflect.Descriptor instead.
+func (*ListOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_order_order_v1_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListOrdersResponse) GetOrders() []*Order {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+func (x *ListOrdersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type GetOrderHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"` // UUID
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`    // UUID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderHistoryRequest) Reset() {
+	*x = GetOrderHistoryRequest{}
+	mi := &file_order_order_v1_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderHistoryRequest) ProtoMessage() {}
+
+func (x *GetOrderHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_v1_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_order_order_v1_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetOrderHistoryRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *GetOrderHistoryRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetOrderHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"` // UUID
+	Transitions   []*OrderTransition     `protobuf:"bytes,2,rep,name=transitions,proto3" json:"transitions,omitempty"`        // в порядке записи, первая - создание заказа
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderHistoryResponse) Reset() {
+	*x = GetOrderHistoryResponse{}
+	mi := &file_order_order_v1_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderHistoryResponse) ProtoMessage() {}
+
+func (x *GetOrderHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_v1_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetOrderHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_order_order_v1_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetOrderHistoryResponse) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *GetOrderHistoryResponse) GetTransitions() []*OrderTransition {
+	if x != nil {
+		return x.Transitions
+	}
+	return nil
+}
+
+// OrderTransition смена статуса заказа; from_status = to_status - изменение цены или объема
+type OrderTransition struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromStatus    OrderStatus            `protobuf:"varint,1,opt,name=from_status,json=fromStatus,proto3,enum=order.v1.OrderStatus" json:"from_status,omitempty"` // UNSPECIFIED для создания заказа
+	ToStatus      OrderStatus            `protobuf:"varint,2,opt,name=to_status,json=toStatus,proto3,enum=order.v1.OrderStatus" json:"to_status,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Actor         string                 `protobuf:"bytes,4,opt,name=actor,proto3" json:"actor,omitempty"` // "user:<id>" или "system"
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderTransition) Reset() {
+	*x = OrderTransition{}
+	mi := &file_order_order_v1_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderTransition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderTransition) ProtoMessage() {}
+
+func (x *OrderTransition) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_v1_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderTransition.ProtoReflect.Descriptor instead.
+func (*OrderTransition) Descriptor() ([]byte, []int) {
+	return file_order_order_v1_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *OrderTransition) GetFromStatus() OrderStatus {
+	if x != nil {
+		return x.FromStatus
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+func (x *OrderTransition) GetToStatus() OrderStatus {
+	if x != nil {
+		return x.ToStatus
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+func (x *OrderTransition) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *OrderTransition) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *OrderTransition) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+type SubscribeOrderUpdatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                    // UUID
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`                 // UUID, пусто - все заказы пользователя
+	FromSequence  int64                  `protobuf:"varint,3,opt,name=from_sequence,json=fromSequence,proto3" json:"from_sequence,omitempty"` // sequence последнего полученного события, 0 - только новые
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeOrderUpdatesRequest) Reset() {
+	*x = SubscribeOrderUpdatesRequest{}
+	mi := &file_order_order_v1_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeOrderUpdatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeOrderUpdatesRequest) ProtoMessage() {}
+
+func (x *SubscribeOrderUpdatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_v1_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeOrderUpdatesRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeOrderUpdatesRequest) Descriptor() ([]byte, []int) {
+	return file_order_order_v1_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SubscribeOrderUpdatesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SubscribeOrderUpdatesRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *SubscribeOrderUpdatesRequest) GetFromSequence() int64 {
+	if x != nil {
+		return x.FromSequence
+	}
+	return 0
+}
+
+// OrderUpdate событие заказа. Доставка at-least-once: повторы отбрасываются по event_id.
+// При переполнении буфера поток завершается с RESOURCE_EXHAUSTED, и клиент переподключается с from_sequence
+type OrderUpdate struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Sequence       int64                  `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	EventId        string                 `protobuf:"bytes,2,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Type           OrderEventType         `protobuf:"varint,3,opt,name=type,proto3,enum=order.v1.OrderEventType" json:"type,omitempty"`
+	OrderId        string                 `protobuf:"bytes,4,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`                      // UUID
+	MarketId       string                 `protobuf:"bytes,5,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`                   // UUID торговой пары
+	Status         OrderStatus            `protobuf:"varint,6,opt,name=status,proto3,enum=order.v1.OrderStatus" json:"status,omitempty"`            // статус после события
+	FilledQuantity string                 `protobuf:"bytes,7,opt,name=filled_quantity,json=filledQuantity,proto3" json:"filled_quantity,omitempty"` // исполненный объем после события
+	Reason         string                 `protobuf:"bytes,8,opt,name=reason,proto3" json:"reason,omitempty"`
+	Fill           *OrderFill             `protobuf:"bytes,9,opt,name=fill,proto3" json:"fill,omitempty"` // только для ORDER_EVENT_TYPE_FILLED
+	OccurredAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *OrderUpdate) Reset() {
+	*x = OrderUpdate{}
+	mi := &file_order_order_v1_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderUpdate) ProtoMessage() {}
+
+func (x *OrderUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_v1_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderUpdate.ProtoReflect.Descriptor instead.
+func (*OrderUpdate) Descriptor() ([]byte, []int) {
+	return file_order_order_v1_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *OrderUpdate) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *OrderUpdate) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *OrderUpdate) GetType() OrderEventType {
+	if x != nil {
+		return x.Type
+	}
+	return OrderEventType_ORDER_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *OrderUpdate) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *OrderUpdate) GetMarketId() string {
+	if x != nil {
+		return x.MarketId
+	}
+	return ""
+}
+
+func (x *OrderUpdate) GetStatus() OrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+func (x *OrderUpdate) GetFilledQuantity() string {
+	if x != nil {
+		return x.FilledQuantity
+	}
+	return ""
+}
+
+func (x *OrderUpdate) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *OrderUpdate) GetFill() *OrderFill {
+	if x != nil {
+		return x.Fill
+	}
+	return nil
+}
+
+func (x *OrderUpdate) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+type OrderFill struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TradeId       string                 `protobuf:"bytes,1,opt,name=trade_id,json=tradeId,proto3" json:"trade_id,omitempty"`
+	Price         string                 `protobuf:"bytes,2,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity      string                 `protobuf:"bytes,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderFill) Reset() {
+	*x = OrderFill{}
+	mi := &file_order_order_v1_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderFill) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderFill) ProtoMessage() {}
+
+func (x *OrderFill) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_v1_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderFill.ProtoReflect.Descriptor instead.
+func (*OrderFill) Descriptor() ([]byte, []int) {
+	return file_order_order_v1_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *OrderFill) GetTradeId() string {
+	if x != nil {
+		return x.TradeId
+	}
+	return ""
+}
+
+func (x *OrderFill) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *OrderFill) GetQuantity() string {
+	if x != nil {
+		return x.Quantity
+	}
+	return ""
+}
+
+type Order struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	OrderId           string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`    // UUID
+	UserId            string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`       // UUID
+	MarketId          string                 `protobuf:"bytes,3,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"` // UUID торговой пары
+	OrderType         OrderType              `protobuf:"varint,4,opt,name=order_type,json=orderType,proto3,enum=order.v1.OrderType" json:"order_type,omitempty"`
+	Side              OrderSide              `protobuf:"varint,5,opt,name=side,proto3,enum=order.v1.OrderSide" json:"side,omitempty"`
+	Status            OrderStatus            `protobuf:"varint,6,opt,name=status,proto3,enum=order.v1.OrderStatus" json:"status,omitempty"`
+	Price             string                 `protobuf:"bytes,7,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity          string                 `protobuf:"bytes,8,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	FilledQuantity    string                 `protobuf:"bytes,10,opt,name=filled_quantity,json=filledQuantity,proto3" json:"filled_quantity,omitempty"`          // исполненный объем
+	RemainingQuantity string                 `protobuf:"bytes,11,opt,name=remaining_quantity,json=remainingQuantity,proto3" json:"remaining_quantity,omitempty"` // неисполненный остаток
+	UpdatedAt         *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	AvgFillPrice      string                 `protobuf:"bytes,13,opt,name=avg_fill_price,json=avgFillPrice,proto3" json:"avg_fill_price,omitempty"` // средняя цена исполнения, "0" если исполнений не было
+	ClientOrderId     string                 `protobuf:"bytes,14,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"`
+	StopPrice         string                 `protobuf:"bytes,15,opt,name=stop_price,json=stopPrice,proto3" json:"stop_price,omitempty"`       // цена активации стоп-заказа, "0" для остальных типов
+	TriggeredAt       *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=triggered_at,json=triggeredAt,proto3" json:"triggered_at,omitempty"` // момент активации стоп-заказа, пусто до активации
+	TimeInForce       TimeInForce            `protobuf:"varint,17,opt,name=time_in_force,json=timeInForce,proto3,enum=order.v1.TimeInForce" json:"time_in_force,omitempty"`
+	ExpiresAt         *timestamppb.Timestamp `protobuf:"bytes,18,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // только для GTD
+	PostOnly          bool                   `protobuf:"varint,19,opt,name=post_only,json=postOnly,proto3" json:"post_only,omitempty"`
+	ReduceOnly        bool                   `protobuf:"varint,20,opt,name=reduce_only,json=reduceOnly,proto3" json:"reduce_only,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Order) Reset() {
+	*x = Order{}
+	mi := &file_order_order_v1_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Order) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Order) ProtoMessage() {}
+
+func (x *Order) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_v1_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Order.ProtoReflect.Descriptor instead.
+func (*Order) Descriptor() ([]byte, []int) {
+	return file_order_order_v1_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Order) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *Order) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Order) GetMarketId() string {
+	if x != nil {
+		return x.MarketId
+	}
+	return ""
+}
+
+func (x *Order) GetOrderType() OrderType {
+	if x != nil {
+		return x.OrderType
+	}
+	return OrderType_ORDER_TYPE_UNSPECIFIED
+}
+
+func (x *Order) GetSide() OrderSide {
+	if x != nil {
+		return x.Side
+	}
+	return OrderSide_ORDER_SIDE_UNSPECIFIED
+}
+
+func (x *Order) GetStatus() OrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+func (x *Order) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *Order) GetQuantity() string {
+	if x != nil {
+		return x.Quantity
+	}
+	return ""
+}
+
+func (x *Order) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Order) GetFilledQuantity() string {
+	if x != nil {
+		return x.FilledQuantity
+	}
+	return ""
+}
+
+func (x *Order) GetRemainingQuantity() string {
+	if x != nil {
+		return x.RemainingQuantity
+	}
+	return ""
+}
+
+func (x *Order) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Order) GetAvgFillPrice() string {
+	if x != nil {
+		return x.AvgFillPrice
+	}
+	return ""
+}
+
+func (x *Order) GetClientOrderId() string {
+	if x != nil {
+		return x.ClientOrderId
+	}
+	return ""
+}
+
+func (x *Order) GetStopPrice() string {
+	if x != nil {
+		return x.StopPrice
+	}
+	return ""
+}
+
+func (x *Order) GetTriggeredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TriggeredAt
+	}
+	return nil
+}
+
+func (x *Order) GetTimeInForce() TimeInForce {
+	if x != nil {
+		return x.TimeInForce
+	}
+	return TimeInForce_TIME_IN_FORCE_UNSPECIFIED
+}
+
+func (x *Order) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *Order) GetPostOnly() bool {
+	if x != nil {
+		return x.PostOnly
+	}
+	return false
+}
+
+func (x *Order) GetReduceOnly() bool {
+	if x != nil {
+		return x.ReduceOnly
+	}
+	return false
+}
+
+var File_order_order_v1_proto protoreflect.FileDescriptor
+
+const file_order_order_v1_proto_rawDesc = "" +
+	"\n" +
+	"\x14order/order_v1.proto\x12\border.v1\x1a\x1bbuf/validate/validate.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"K\n" +
+	"\x15GetOrderStatusRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"b\n" +
+	"\x16GetOrderStatusResponse\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12-\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x15.order.v1.OrderStatusR\x06status\"_\n" +
+	"\x0fGetOrderRequest\x12&\n" +
+	"\border_id\x18\x01 \x01(\tB\v\xbaH\b\xc8\x01\x01r\x03\xb0\x01\x01R\aorderId\x12$\n" +
+	"\auser_id\x18\x02 \x01(\tB\v\xbaH\b\xc8\x01\x01r\x03\xb0\x01\x01R\x06userId\"9\n" +
+	"\x10GetOrderResponse\x12%\n" +
+	"\x05order\x18\x01 \x01(\v2\x0f.order.v1.OrderR\x05order\"\xa1\x05\n" +
+	"\x12CreateOrderRequest\x12$\n" +
+	"\auser_id\x18\x01 \x01(\tB\v\xbaH\b\xc8\x01\x01r\x03\xb0\x01\x01R\x06userId\x12(\n" +
+	"\tmarket_id\x18\x02 \x01(\tB\v\xbaH\b\xc8\x01\x01r\x03\xb0\x01\x01R\bmarketId\x12?\n" +
+	"\n" +
+	"order_type\x18\x03 \x01(\x0e2\x13.order.v1.OrderTypeB\v\xbaH\b\xc8\x01\x01\x82\x01\x02\x10\x01R\torderType\x127\n" +
+	"\x05price\x18\x04 \x01(\tB!\xbaH\x1e\xd8\x01\x01r\x192\x17^[0-9]+(\\.[0-9]{1,8})?$R\x05price\x12?\n" +
+	"\bquantity\x18\x05 \x01(\tB#\xbaH \xc8\x01\x01r\x1b\x10\x012\x17^[0-9]+(\\.[0-9]{1,8})?$R\bquantity\x124\n" +
+	"\x04side\x18\x06 \x01(\x0e2\x13.order.v1.OrderSideB\v\xbaH\b\xc8\x01\x01\x82\x01\x02\x10\x01R\x04side\x12G\n" +
+	"\x0fclient_order_id\x18\a \x01(\tB\x1f\xbaH\x1c\xd8\x01\x01r\x172\x15^[A-Za-z0-9_-]{1,64}$R\rclientOrderId\x12@\n" +
+	"\n" +
+	"stop_price\x18\b \x01(\tB!\xbaH\x1e\xd8\x01\x01r\x192\x17^[0-9]+(\\.[0-9]{1,8})?$R\tstopPrice\x12F\n" +
+	"\rtime_in_force\x18\t \x01(\x0e2\x15.order.v1.TimeInForceB\v\xbaH\b\xd8\x01\x01\x82\x01\x02\x10\x01R\vtimeInForce\x129\n" +
+	"\n" +
+	"expires_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x1b\n" +
+	"\tpost_only\x18\v \x01(\bR\bpostOnly\x12\x1f\n" +
+	"\vreduce_only\x18\f \x01(\bR\n" +
+	"reduceOnly\"_\n" +
+	"\x13CreateOrderResponse\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12-\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x15.order.v1.OrderStatusR\x06status\"b\n" +
+	"\x12CancelOrderRequest\x12&\n" +
+	"\border_id\x18\x01 \x01(\tB\v\xbaH\b\xc8\x01\x01r\x03\xb0\x01\x01R\aorderId\x12$\n" +
+	"\auser_id\x18\x02 \x01(\tB\v\xbaH\b\xc8\x01\x01r\x03\xb0\x01\x01R\x06userId\"_\n" +
+	"\x13CancelOrderResponse\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12-\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x15.order.v1.OrderStatusR\x06status\"\xdb\x01\n" +
+	"\x13ReplaceOrderRequest\x12&\n" +
+	"\border_id\x18\x01 \x01(\tB\v\xbaH\b\xc8\x01\x01r\x03\xb0\x01\x01R\aorderId\x12$\n" +
+	"\auser_id\x18\x02 \x01(\tB\v\xbaH\b\xc8\x01\x01r\x03\xb0\x01\x01R\x06userId\x127\n" +
+	"\x05price\x18\x03 \x01(\tB!\xbaH\x1e\xd8\x01\x01r\x192\x17^[0-9]+(\\.[0-9]{1,8})?$R\x05price\x12=\n" +
+	"\bquantity\x18\x04 \x01(\tB!\xbaH\x1e\xd8\x01\x01r\x192\x17^[0-9]+(\\.[0-9]{1,8})?$R\bquantity\"=\n" +
+	"\x14ReplaceOrderResponse\x12%\n" +
+	"\x05order\x18\x01 \x01(\v2\x0f.order.v1.OrderR\x05order\"\xae\x03\n" +
+	"\x11ListOrdersRequest\x12$\n" +
+	"\auser_id\x18\x01 \x01(\tB\v\xbaH\b\xc8\x01\x01r\x03\xb0\x01\x01R\x06userId\x12(\n" +
+	"\tmarket_id\x18\x02 \x01(\tB\v\xbaH\b\xd8\x01\x01r\x03\xb0\x01\x01R\bmarketId\x12B\n" +
+	"\bstatuses\x18\x03 \x03(\x0e2\x15.order.v1.OrderStatusB\x0f\xbaH\f\x92\x01\t\"\a\x82\x01\x04\x10\x01 \x00R\bstatuses\x12E\n" +
+	"\vorder_types\x18\x04 \x03(\x0e2\x13.order.v1.OrderTypeB\x0f\xbaH\f\x92\x01\t\"\a\x82\x01\x04\x10\x01 \x00R\n" +
+	"orderTypes\x12=\n" +
+	"\fcreated_from\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\vcreatedFrom\x129\n" +
+	"\n" +
+	"created_to\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedTo\x12%\n" +
+	"\tpage_size\x18\a \x01(\rB\b\xbaH\x05*\x03\x18\xf4\x03R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\b \x01(\tR\tpageToken\"e\n" +
+	"\x12ListOrdersResponse\x12'\n" +
+	"\x06orders\x18\x01 \x03(\v2\x0f.order.v1.OrderR\x06orders\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"f\n" +
+	"\x16GetOrderHistoryRequest\x12&\n" +
+	"\border_id\x18\x01 \x01(\tB\v\xbaH\b\xc8\x01\x01r\x03\xb0\x01\x01R\aorderId\x12$\n" +
+	"\auser_id\x18\x02 \x01(\tB\v\xbaH\b\xc8\x01\x01r\x03\xb0\x01\x01R\x06userId\"q\n" +
+	"\x17GetOrderHistoryResponse\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12;\n" +
+	"\vtransitions\x18\x02 \x03(\v2\x19.order.v1.OrderTransitionR\vtransitions\"\xe8\x01\n" +
+	"\x0fOrderTransition\x126\n" +
+	"\vfrom_status\x18\x01 \x01(\x0e2\x15.order.v1.OrderStatusR\n" +
+	"fromStatus\x122\n" +
+	"\tto_status\x18\x02 \x01(\x0e2\x15.order.v1.OrderStatusR\btoStatus\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x14\n" +
+	"\x05actor\x18\x04 \x01(\tR\x05actor\x12;\n" +
+	"\voccurred_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\"\x9a\x01\n" +
+	"\x1cSubscribeOrderUpdatesRequest\x12$\n" +
+	"\auser_id\x18\x01 \x01(\tB\v\xbaH\b\xc8\x01\x01r\x03\xb0\x01\x01R\x06userId\x12&\n" +
+	"\border_id\x18\x02 \x01(\tB\v\xbaH\b\xd8\x01\x01r\x03\xb0\x01\x01R\aorderId\x12,\n" +
+	"\rfrom_sequence\x18\x03 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\ffromSequence\"\x80\x03\n" +
+	"\vOrderUpdate\x12\x1a\n" +
+	"\bsequence\x18\x01 \x01(\x03R\bsequence\x12\x19\n" +
+	"\bevent_id\x18\x02 \x01(\tR\aeventId\x12,\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x18.order.v1.OrderEventTypeR\x04type\x12\x19\n" +
+	"\border_id\x18\x04 \x01(\tR\aorderId\x12\x1b\n" +
+	"\tmarket_id\x18\x05 \x01(\tR\bmarketId\x12-\n" +
+	"\x06status\x18\x06 \x01(\x0e2\x15.order.v1.OrderStatusR\x06status\x12'\n" +
+	"\x0ffilled_quantity\x18\a \x01(\tR\x0efilledQuantity\x12\x16\n" +
+	"\x06reason\x18\b \x01(\tR\x06reason\x12'\n" +
+	"\x04fill\x18\t \x01(\v2\x13.order.v1.OrderFillR\x04fill\x12;\n" +
+	"\voccurred_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\"X\n" +
+	"\tOrderFill\x12\x19\n" +
+	"\btrade_id\x18\x01 \x01(\tR\atradeId\x12\x14\n" +
+	"\x05price\x18\x02 \x01(\tR\x05price\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\tR\bquantity\"\xc4\x06\n" +
+	"\x05Order\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tmarket_id\x18\x03 \x01(\tR\bmarketId\x122\n" +
+	"\n" +
+	"order_type\x18\x04 \x01(\x0e2\x13.order.v1.OrderTypeR\torderType\x12'\n" +
+	"\x04side\x18\x05 \x01(\x0e2\x13.order.v1.OrderSideR\x04side\x12-\n" +
+	"\x06status\x18\x06 \x01(\x0e2\x15.order.v1.OrderStatusR\x06status\x12\x14\n" +
+	"\x05price\x18\a \x01(\tR\x05price\x12\x1a\n" +
+	"\bquantity\x18\b \x01(\tR\bquantity\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12'\n" +
+	"\x0ffilled_quantity\x18\n" +
+	" \x01(\tR\x0efilledQuantity\x12-\n" +
+	"\x12remaining_quantity\x18\v \x01(\tR\x11remainingQuantity\x129\n" +
+	"\n" +
+	"updated_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12$\n" +
+	"\x0eavg_fill_price\x18\r \x01(\tR\favgFillPrice\x12&\n" +
+	"\x0fclient_order_id\x18\x0e \x01(\tR\rclientOrderId\x12\x1d\n" +
+	"\n" +
+	"stop_price\x18\x0f \x01(\tR\tstopPrice\x12=\n" +
+	"\ftriggered_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\vtriggeredAt\x129\n" +
+	"\rtime_in_force\x18\x11 \x01(\x0e2\x15.order.v1.TimeInForceR\vtimeInForce\x129\n" +
+	"\n" +
+	"expires_at\x18\x12 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x1b\n" +
+	"\tpost_only\x18\x13 \x01(\bR\bpostOnly\x12\x1f\n" +
+	"\vreduce_only\x18\x14 \x01(\bR\n" +
+	"reduceOnly*\xef\x01\n" +
+	"\x0eOrderEventType\x12 \n" +
+	"\x1cORDER_EVENT_TYPE_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18ORDER_EVENT_TYPE_CREATED\x10\x01\x12\x1e\n" +
+	"\x1aORDER_EVENT_TYPE_CANCELLED\x10\x02\x12\x1b\n" +
+	"\x17ORDER_EVENT_TYPE_FILLED\x10\x03\x12\x1d\n" +
+	"\x19ORDER_EVENT_TYPE_REJECTED\x10\x04\x12#\n" +
+	"\x1fORDER_EVENT_TYPE_STATUS_CHANGED\x10\x05\x12\x1c\n" +
+	"\x18ORDER_EVENT_TYPE_AMENDED\x10\x06*\x8b\x01\n" +
+	"\tOrderType\x12\x1a\n" +
+	"\x16ORDER_TYPE_UNSPECIFIED\x10\x00\x12\x14\n" +
+	"\x10ORDER_TYPE_LIMIT\x10\x01\x12\x15\n" +
+	"\x11ORDER_TYPE_MARKET\x10\x02\x12\x19\n" +
+	"\x15ORDER_TYPE_STOP_LIMIT\x10\x03\x12\x1a\n" +
+	"\x16ORDER_TYPE_STOP_MARKET\x10\x04*\x88\x01\n" +
+	"\vTimeInForce\x12\x1d\n" +
+	"\x19TIME_IN_FORCE_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11TIME_IN_FORCE_GTC\x10\x01\x12\x15\n" +
+	"\x11TIME_IN_FORCE_IOC\x10\x02\x12\x15\n" +
+	"\x11TIME_IN_FORCE_FOK\x10\x03\x12\x15\n" +
+	"\x11TIME_IN_FORCE_GTD\x10\x04*P\n" +
+	"\tOrderSide\x12\x1a\n" +
+	"\x16ORDER_SIDE_UNSPECIFIED\x10\x00\x12\x12\n" +
+	"\x0eORDER_SIDE_BUY\x10\x01\x12\x13\n" +
+	"\x0fORDER_SIDE_SELL\x10\x02*\xe9\x01\n" +
+	"\vOrderStatus\x12\x1c\n" +
+	"\x18ORDER_STATUS_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14ORDER_STATUS_CREATED\x10\x01\x12\x15\n" +
+	"\x11ORDER_STATUS_OPEN\x10\x02\x12\x17\n" +
+	"\x13ORDER_STATUS_FILLED\x10\x03\x12\x1a\n" +
+	"\x16ORDER_STATUS_CANCELLED\x10\x04\x12\x19\n" +
+	"\x15ORDER_STATUS_REJECTED\x10\x05\x12!\n" +
+	"\x1dORDER_STATUS_PARTIALLY_FILLED\x10\x06\x12\x18\n" +
+	"\x14ORDER_STATUS_EXPIRED\x10\a2\x88\x05\n" +
+	"\fOrderService\x12S\n" +
+	"\x0eGetOrderStatus\x12\x1f.order.v1.GetOrderStatusRequest\x1a .order.v1.GetOrderStatusResponse\x12A\n" +
+	"\bGetOrder\x12\x19.order.v1.GetOrderRequest\x1a\x1a.order.v1.GetOrderResponse\x12J\n" +
+	"\vCreateOrder\x12\x1c.order.v1.CreateOrderRequest\x1a\x1d.order.v1.CreateOrderResponse\x12J\n" +
+	"\vCancelOrder\x12\x1c.order.v1.CancelOrderRequest\x1a\x1d.order.v1.CancelOrderResponse\x12M\n" +
+	"\fReplaceOrder\x12\x1d.order.v1.ReplaceOrderRequest\x1a\x1e.order.v1.ReplaceOrderResponse\x12G\n" +
+	"\n" +
+	"ListOrders\x12\x1b.order.v1.ListOrdersRequest\x1a\x1c.order.v1.ListOrdersResponse\x12V\n" +
+	"\x0fGetOrderHistory\x12 .order.v1.GetOrderHistoryRequest\x1a!.order.v1.GetOrderHistoryResponse\x12X\n" +
+	"\x15SubscribeOrderUpdates\x12&.order.v1.SubscribeOrderUpdatesRequest\x1a\x15.order.v1.OrderUpdate0\x01B=Z;github.com/chilly266futon/orderService/gen/pb/order;orderv1b\x06proto3"
+
+var (
+	file_order_order_v1_proto_rawDescOnce sync.Once
+	file_order_order_v1_proto_rawDescData []byte
+)
+
+func file_order_order_v1_proto_rawDescGZIP() []byte {
+	file_order_order_v1_proto_rawDescOnce.Do(func() {
+		file_order_order_v1_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_order_order_v1_proto_rawDesc), len(file_order_order_v1_proto_rawDesc)))
+	})
+	return file_order_order_v1_proto_rawDescData
+}
+
+var file_order_order_v1_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_order_order_v1_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_order_order_v1_proto_goTypes = []any{
+	(OrderEventType)(0),                  // 0: order.v1.OrderEventType
+	(OrderType)(0),                       // 1: order.v1.OrderType
+	(TimeInForce)(0),                     // 2: order.v1.TimeInForce
+	(OrderSide)(0),                       // 3: order.v1.OrderSide
+	(OrderStatus)(0),                     // 4: order.v1.OrderStatus
+	(*GetOrderStatusRequest)(nil),        // 5: order.v1.GetOrderStatusRequest
+	(*GetOrderStatusResponse)(nil),       // 6: order.v1.GetOrderStatusResponse
+	(*GetOrderRequest)(nil),              // 7: order.v1.GetOrderRequest
+	(*GetOrderResponse)(nil),             // 8: order.v1.GetOrderResponse
+	(*CreateOrderRequest)(nil),           // 9: order.v1.CreateOrderRequest
+	(*CreateOrderResponse)(nil),          // 10: order.v1.CreateOrderResponse
+	(*CancelOrderRequest)(nil),           // 11: order.v1.CancelOrderRequest
+	(*CancelOrderResponse)(nil),          // 12: order.v1.CancelOrderResponse
+	(*ReplaceOrderRequest)(nil),          // 13: order.v1.ReplaceOrderRequest
+	(*ReplaceOrderResponse)(nil),         // 14: order.v1.ReplaceOrderResponse
+	(*ListOrdersRequest)(nil),            // 15: order.v1.ListOrdersRequest
+	(*ListOrdersResponse)(nil),           // 16: order.v1.ListOrdersResponse
+	(*GetOrderHistoryRequest)(nil),       // 17: order.v1.GetOrderHistoryRequest
+	(*GetOrderHistoryResponse)(nil),      // 18: order.v1.GetOrderHistoryResponse
+	(*OrderTransition)(nil),              // 19: order.v1.OrderTransition
+	(*SubscribeOrderUpdatesRequest)(nil), // 20: order.v1.SubscribeOrderUpdatesRequest
+	(*OrderUpdate)(nil),                  // 21: order.v1.OrderUpdate
+	(*OrderFill)(nil),                    // 22: order.v1.OrderFill
+	(*Order)(nil),                        // 23: order.v1.Order
+	(*timestamppb.Timestamp)(nil),        // 24: google.protobuf.Timestamp
+}
+var file_order_order_v1_proto_depIdxs = []int32{
+	4,  // 0: order.v1.GetOrderStatusResponse.status:type_name -> order.v1.OrderStatus
+	23, // 1: order.v1.GetOrderResponse.order:type_name -> order.v1.Order
+	1,  // 2: order.v1.CreateOrderRequest.order_type:type_name -> order.v1.OrderType
+	3,  // 3: order.v1.CreateOrderRequest.side:type_name -> order.v1.OrderSide
+	2,  // 4: order.v1.CreateOrderRequest.time_in_force:type_name -> order.v1.TimeInForce
+	24, // 5: order.v1.CreateOrderRequest.expires_at:type_name -> google.protobuf.Timestamp
+	4,  // 6: order.v1.CreateOrderResponse.status:type_name -> order.v1.OrderStatus
+	4,  // 7: order.v1.CancelOrderResponse.status:type_name -> order.v1.OrderStatus
+	23, // 8: order.v1.ReplaceOrderResponse.order:type_name -> order.v1.Order
+	4,  // 9: order.v1.ListOrdersRequest.statuses:type_name -> order.v1.OrderStatus
+	1,  // 10: order.v1.ListOrdersRequest.order_types:type_name -> order.v1.OrderType
+	24, // 11: order.v1.ListOrdersRequest.created_from:type_name -> google.protobuf.Timestamp
+	24, // 12: order.v1.ListOrdersRequest.created_to:type_name -> google.protobuf.Timestamp
+	23, // 13: order.v1.ListOrdersResponse.orders:type_name -> order.v1.Order
+	19, // 14: order.v1.GetOrderHistoryResponse.transitions:type_name -> order.v1.OrderTransition
+	4,  // 15: order.v1.OrderTransition.from_status:type_name -> order.v1.OrderStatus
+	4,  // 16: order.v1.OrderTransition.to_status:type_name -> order.v1.OrderStatus
+	24, // 17: order.v1.OrderTransition.occurred_at:type_name -> google.protobuf.Timestamp
+	0,  // 18: order.v1.OrderUpdate.type:type_name -> order.v1.OrderEventType
+	4,  // 19: order.v1.OrderUpdate.status:type_name -> order.v1.OrderStatus
+	22, // 20: order.v1.OrderUpdate.fill:type_name -> order.v1.OrderFill
+	24, // 21: order.v1.OrderUpdate.occurred_at:type_name -> google.protobuf.Timestamp
+	1,  // 22: order.v1.Order.order_type:type_name -> order.v1.OrderType
+	3,  // 23: order.v1.Order.side:type_name -> order.v1.OrderSide
+	4,  // 24: order.v1.Order.status:type_name -> order.v1.OrderStatus
+	24, // 25: order.v1.Order.created_at:type_name -> google.protobuf.Timestamp
+	24, // 26: order.v1.Order.updated_at:type_name -> google.protobuf.Timestamp
+	24, // 27: order.v1.Order.triggered_at:type_name -> google.protobuf.Timestamp
+	2,  // 28: order.v1.Order.time_in_force:type_name -> order.v1.TimeInForce
+	24, // 29: order.v1.Order.expires_at:type_name -> google.protobuf.Timestamp
+	5,  // 30: order.v1.OrderService.GetOrderStatus:input_type -> order.v1.GetOrderStatusRequest
+	7,  // 31: order.v1.OrderService.GetOrder:input_type -> order.v1.GetOrderRequest
+	9,  // 32: order.v1.OrderService.CreateOrder:input_type -> order.v1.CreateOrderRequest
+	11, // 33: order.v1.OrderService.CancelOrder:input_type -> order.v1.CancelOrderRequest
+	13, // 34: order.v1.OrderService.ReplaceOrder:input_type -> order.v1.ReplaceOrderRequest
+	15, // 35: order.v1.OrderService.ListOrders:input_type -> order.v1.ListOrdersRequest
+	17, // 36: order.v1.OrderService.GetOrderHistory:input_type -> order.v1.GetOrderHistoryRequest
+	20, // 37: order.v1.OrderService.SubscribeOrderUpdates:input_type -> order.v1.SubscribeOrderUpdatesRequest
+	6,  // 38: order.v1.OrderService.GetOrderStatus:output_type -> order.v1.GetOrderStatusResponse
+	8,  // 39: order.v1.OrderService.GetOrder:output_type -> order.v1.GetOrderResponse
+	10, // 40: order.v1.OrderService.CreateOrder:output_type -> order.v1.CreateOrderResponse
+	12, // 41: order.v1.OrderService.CancelOrder:output_type -> order.v1.CancelOrderResponse
+	14, // 42: order.v1.OrderService.ReplaceOrder:output_type -> order.v1.ReplaceOrderResponse
+	16, // 43: order.v1.OrderService.ListOrders:output_type -> order.v1.ListOrdersResponse
+	18, // 44: order.v1.OrderService.GetOrderHistory:output_type -> order.v1.GetOrderHistoryResponse
+	21, // 45: order.v1.OrderService.SubscribeOrderUpdates:output_type -> order.v1.OrderUpdate
+	38, // [38:46] is the sub-list for method output_type
+	30, // [30:38] is the sub-list for method input_type
+	30, // [30:30] is the sub-list for extension type_name
+	30, // [30:30] is the sub-list for extension extendee
+	0,  // [0:30] is the sub-list for field type_name
+}
+
+func init() { file_order_order_v1_proto_init() }
+func file_order_order_v1_proto_init() {
+	if File_order_order_v1_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_order_order_v1_proto_rawDesc), len(file_order_order_v1_proto_rawDesc)),
+			NumEnums:      5,
+			NumMessages:   19,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_order_order_v1_proto_goTypes,
+		DependencyIndexes: file_order_order_v1_proto_depIdxs,
+		EnumInfos:         file_order_order_v1_proto_enumTypes,
+		MessageInfos:      file_order_order_v1_proto_msgTypes,
+	}.Build()
+	File_order_order_v1_proto = out.File
+	file_order_order_v1_proto_goTypes = nil
+	file_order_order_v1_proto_depIdxs = nil
+}
@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"context"
+	"errors"
+)
+
+const (
+	MethodJWT      = "jwt"
+	MethodMTLS     = "mtls"
+	MethodMetadata = "metadata"
+)
+
+// ErrNoCredentials в вызове нет учетных данных, которые проверяет аутентификатор
+var ErrNoCredentials = errors.New("no credentials")
+
+// Identity проверенная личность вызывающего
+type Identity struct {
+	UserID string
+	Roles  []string
+	// Method способ проверки: jwt, mtls или metadata
+	Method string
+}
+
+// HasRole проверяет наличие роли
+func (i Identity) HasRole(role string) bool {
+	for _, r := range i.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+type identityKey struct{}
+
+func WithIdentity(ctx context.Context, identity Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// IdentityFromContext возвращает личность, установленную перехватчиком аутентификации
+func IdentityFromContext(ctx context.Context) (Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(Identity)
+	return identity, ok
+}
+
+// Authenticator проверяет учетные данные вызова.
+// Возвращает ErrNoCredentials, если нужных ему учетных данных в вызове нет
+type Authenticator interface {
+	Authenticate(ctx context.Context) (Identity, error)
+}
+
+type chain []Authenticator
+
+// Chain пробует аутентификаторы по порядку до первого, нашедшего свои учетные данные.
+// Ошибка проверки найденных учетных данных не передает ход следующему
+func Chain(authenticators ...Authenticator) Authenticator {
+	return chain(authenticators)
+}
+
+func (c chain) Authenticate(ctx context.Context) (Identity, error) {
+	for _, a := range c {
+		identity, err := a.Authenticate(ctx)
+		if errors.Is(err, ErrNoCredentials) {
+			continue
+		}
+		return identity, err
+	}
+	return Identity{}, ErrNoCredentials
+}
@@ -0,0 +1,73 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/chilly266futon/orderService/internal/auth"
+)
+
+// stubAuthenticator возвращает заданный результат и считает вызовы
+type stubAuthenticator struct {
+	identity auth.Identity
+	err      error
+	calls    int
+}
+
+func (a *stubAuthenticator) Authenticate(context.Context) (auth.Identity, error) {
+	a.calls++
+	return a.identity, a.err
+}
+
+func TestChain(t *testing.T) {
+	errInvalid := errors.New("invalid token")
+
+	tests := []struct {
+		name      string
+		results   []error
+		wantUser  string
+		wantErr   error
+		wantCalls []int
+	}{
+		{"first authenticator wins", []error{nil, nil}, "user-0", nil, []int{1, 0}},
+		{"falls through missing credentials", []error{auth.ErrNoCredentials, nil}, "user-1", nil, []int{1, 1}},
+		{"invalid credentials stop the chain", []error{errInvalid, nil}, "", errInvalid, []int{1, 0}},
+		{"nobody found credentials", []error{auth.ErrNoCredentials, auth.ErrNoCredentials}, "", auth.ErrNoCredentials, []int{1, 1}},
+		{"empty chain", nil, "", auth.ErrNoCredentials, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stubs := make([]*stubAuthenticator, len(tt.results))
+			authenticators := make([]auth.Authenticator, len(tt.results))
+			for i, err := range tt.results {
+				stubs[i] = &stubAuthenticator{err: err}
+				if err == nil {
+					stubs[i].identity = auth.Identity{UserID: "user-" + string(rune('0'+i))}
+				}
+				authenticators[i] = stubs[i]
+			}
+
+			identity, err := auth.Chain(authenticators...).Authenticate(context.Background())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Authenticate() error = %v, want %v", err, tt.wantErr)
+			}
+			if identity.UserID != tt.wantUser {
+				t.Fatalf("Authenticate() user = %q, want %q", identity.UserID, tt.wantUser)
+			}
+			for i, s := range stubs {
+				if s.calls != tt.wantCalls[i] {
+					t.Fatalf("authenticator %d called %d times, want %d", i, s.calls, tt.wantCalls[i])
+				}
+			}
+		})
+	}
+}
+
+func TestIdentityHasRole(t *testing.T) {
+	identity := auth.Identity{Roles: []string{"trader", "admin"}}
+	if !identity.HasRole("admin") || identity.HasRole("viewer") {
+		t.Fatalf("HasRole() on %v is wrong", identity.Roles)
+	}
+}
@@ -0,0 +1,84 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/chilly266futon/exchange-shared/pkg/common"
+	"github.com/chilly266futon/exchange-shared/pkg/interceptors"
+
+	"github.com/chilly266futon/orderService/internal/middleware"
+)
+
+// Interceptor аутентифицирует вызовы и кладет Identity в контекст.
+// Проверенный пользователь также записывается в метаданные user_id, чтобы common.GetUserID
+// (например, в per-user rate limiting) видел его, а не значение клиента
+type Interceptor struct {
+	authenticator Authenticator
+	// public префиксы методов, не требующих аутентификации
+	public []string
+	logger *zap.Logger
+}
+
+func NewInterceptor(authenticator Authenticator, logger *zap.Logger, publicMethods ...string) *Interceptor {
+	return &Interceptor{
+		authenticator: authenticator,
+		public:        publicMethods,
+		logger:        logger,
+	}
+}
+
+func (i *Interceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := i.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (i *Interceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := i.authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, middleware.WithContext(ss, ctx))
+	}
+}
+
+func (i *Interceptor) authenticate(ctx context.Context, method string) (context.Context, error) {
+	for _, prefix := range i.public {
+		if strings.HasPrefix(method, prefix) {
+			return ctx, nil
+		}
+	}
+
+	identity, err := i.authenticator.Authenticate(ctx)
+	if err != nil {
+		i.logger.Warn("authentication failed",
+			zap.String("trace_id", interceptors.GetTraceID(ctx)),
+			zap.String("method", method),
+			zap.Error(err),
+		)
+		if errors.Is(err, ErrNoCredentials) {
+			return nil, status.Error(codes.Unauthenticated, "credentials required")
+		}
+		return nil, status.Error(codes.Unauthenticated, "invalid credentials")
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	md = md.Copy()
+	md.Set(common.UserIDKey, identity.UserID)
+	ctx = metadata.NewIncomingContext(ctx, md)
+
+	return WithIdentity(ctx, identity), nil
+}
@@ -0,0 +1,114 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/chilly266futon/exchange-shared/pkg/common"
+
+	"github.com/chilly266futon/orderService/internal/auth"
+)
+
+const (
+	publicPrefix  = "/grpc.health.v1.Health/"
+	privateMethod = "/order.v1.OrderService/CreateOrder"
+)
+
+// testStream поток с подменяемым контекстом
+type testStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestInterceptorUnary(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		result   error
+		wantCode codes.Code
+		wantUser string
+	}{
+		{"public method without credentials", publicPrefix + "Check", auth.ErrNoCredentials, codes.OK, ""},
+		{"missing credentials", privateMethod, auth.ErrNoCredentials, codes.Unauthenticated, ""},
+		{"invalid credentials", privateMethod, errors.New("invalid token"), codes.Unauthenticated, ""},
+		{"authenticated", privateMethod, nil, codes.OK, "user-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := &stubAuthenticator{err: tt.result}
+			if tt.result == nil {
+				stub.identity = auth.Identity{UserID: "user-1", Roles: []string{"trader"}, Method: auth.MethodJWT}
+			}
+			interceptor := auth.NewInterceptor(stub, zap.NewNop(), publicPrefix)
+
+			// клиент подставляет чужой user_id: после аутентификации он заменяется проверенным
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.UserIDKey, "spoofed"))
+
+			called := false
+			_, err := interceptor.Unary()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, func(ctx context.Context, _ any) (any, error) {
+				called = true
+				identity, ok := auth.IdentityFromContext(ctx)
+				if tt.wantUser == "" {
+					if ok {
+						t.Fatalf("public call got identity %+v", identity)
+					}
+					return nil, nil
+				}
+				if !ok || identity.UserID != tt.wantUser {
+					t.Fatalf("identity = %+v, %v, want %s", identity, ok, tt.wantUser)
+				}
+				if got := common.GetUserID(ctx); got != tt.wantUser {
+					t.Fatalf("user_id metadata = %q, want %q", got, tt.wantUser)
+				}
+				return nil, nil
+			})
+
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("interceptor code = %v, want %v", code, tt.wantCode)
+			}
+			if called != (tt.wantCode == codes.OK) {
+				t.Fatalf("handler called = %v, want %v", called, tt.wantCode == codes.OK)
+			}
+			if tt.method == publicPrefix+"Check" && stub.calls != 0 {
+				t.Fatal("public method was authenticated")
+			}
+		})
+	}
+}
+
+func TestInterceptorStream(t *testing.T) {
+	stub := &stubAuthenticator{identity: auth.Identity{UserID: "user-1", Method: auth.MethodMTLS}}
+	interceptor := auth.NewInterceptor(stub, zap.NewNop(), publicPrefix)
+	stream := &testStream{ctx: context.Background()}
+
+	err := interceptor.Stream()(nil, stream, &grpc.StreamServerInfo{FullMethod: "/order.v1.OrderService/SubscribeOrderUpdates"}, func(_ any, ss grpc.ServerStream) error {
+		if identity, ok := auth.IdentityFromContext(ss.Context()); !ok || identity.UserID != "user-1" {
+			t.Fatalf("stream identity = %+v, %v, want user-1", identity, ok)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("stream interceptor error = %v", err)
+	}
+
+	stub.err = auth.ErrNoCredentials
+	err = interceptor.Stream()(nil, stream, &grpc.StreamServerInfo{FullMethod: "/order.v1.OrderService/SubscribeOrderUpdates"}, func(any, grpc.ServerStream) error {
+		t.Fatal("handler called without credentials")
+		return nil
+	})
+	if code := status.Code(err); code != codes.Unauthenticated {
+		t.Fatalf("stream interceptor code = %v, want %v", code, codes.Unauthenticated)
+	}
+}
@@ -0,0 +1,226 @@
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc/metadata"
+)
+
+const defaultRolesClaim = "roles"
+
+type JWTConfig struct {
+	// HSSecret общий ключ для HS256/384/512
+	HSSecret string
+	// RSPublicKeyFile PEM-файл с открытым ключом для RS256/384/512
+	RSPublicKeyFile string
+	// JWKSFile локальный JWKS с ключами RSA и oct, ключ выбирается по kid
+	JWKSFile string
+	Issuer   string
+	Audience string
+	// RolesClaim claim с ролями: массив строк или строка через пробел
+	RolesClaim string
+}
+
+// JWTAuthenticator проверяет bearer-токен из метаданных authorization.
+// Пользователь берется из claim sub
+type JWTAuthenticator struct {
+	hsSecret   []byte
+	rsKey      *rsa.PublicKey
+	jwks       map[string]any
+	parser     *jwt.Parser
+	rolesClaim string
+}
+
+func NewJWTAuthenticator(cfg JWTConfig) (*JWTAuthenticator, error) {
+	a := &JWTAuthenticator{
+		rolesClaim: cfg.RolesClaim,
+	}
+	if a.rolesClaim == "" {
+		a.rolesClaim = defaultRolesClaim
+	}
+
+	if cfg.HSSecret != "" {
+		a.hsSecret = []byte(cfg.HSSecret)
+	}
+	if cfg.RSPublicKeyFile != "" {
+		data, err := os.ReadFile(cfg.RSPublicKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read RS public key: %w", err)
+		}
+		if a.rsKey, err = jwt.ParseRSAPublicKeyFromPEM(data); err != nil {
+			return nil, fmt.Errorf("failed to parse RS public key: %w", err)
+		}
+	}
+	if cfg.JWKSFile != "" {
+		keys, err := loadJWKS(cfg.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+		a.jwks = keys
+	}
+	if a.hsSecret == nil && a.rsKey == nil && len(a.jwks) == 0 {
+		return nil, errors.New("no JWT verification keys configured")
+	}
+
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{"HS256", "HS384", "HS512", "RS256", "RS384", "RS512"}),
+		jwt.WithExpirationRequired(),
+	}
+	if cfg.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(cfg.Audience))
+	}
+	a.parser = jwt.NewParser(opts...)
+
+	return a, nil
+}
+
+func (a *JWTAuthenticator) Authenticate(ctx context.Context) (Identity, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return Identity{}, ErrNoCredentials
+	}
+
+	// схема регистронезависима; заголовок без bearer-токена - неверные учетные данные, а не их отсутствие
+	scheme, raw, _ := strings.Cut(strings.TrimSpace(values[0]), " ")
+	raw = strings.TrimSpace(raw)
+	if !strings.EqualFold(scheme, "Bearer") || raw == "" {
+		return Identity{}, errors.New("invalid authorization header: bearer token expected")
+	}
+
+	claims := jwt.MapClaims{}
+	if _, err := a.parser.ParseWithClaims(raw, claims, a.key); err != nil {
+		return Identity{}, fmt.Errorf("invalid token: %w", err)
+	}
+
+	sub, err := claims.GetSubject()
+	if err != nil || sub == "" {
+		return Identity{}, errors.New("invalid token: missing sub")
+	}
+
+	return Identity{
+		UserID: sub,
+		Roles:  rolesOf(claims[a.rolesClaim]),
+		Method: MethodJWT,
+	}, nil
+}
+
+// key выбирает ключ проверки по алгоритму и kid токена
+func (a *JWTAuthenticator) key(token *jwt.Token) (any, error) {
+	if kid, ok := token.Header["kid"].(string); ok && kid != "" {
+		key, ok := a.jwks[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown key id %q", kid)
+		}
+		return matchKey(token, key)
+	}
+
+	switch token.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		if a.hsSecret != nil {
+			return a.hsSecret, nil
+		}
+	case *jwt.SigningMethodRSA:
+		if a.rsKey != nil {
+			return a.rsKey, nil
+		}
+	}
+	return nil, fmt.Errorf("no key for %s", token.Method.Alg())
+}
+
+// matchKey проверяет, что тип ключа из JWKS соответствует алгоритму токена
+func matchKey(token *jwt.Token, key any) (any, error) {
+	switch token.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		if k, ok := key.([]byte); ok {
+			return k, nil
+		}
+	case *jwt.SigningMethodRSA:
+		if k, ok := key.(*rsa.PublicKey); ok {
+			return k, nil
+		}
+	}
+	return nil, fmt.Errorf("key type does not match %s", token.Method.Alg())
+}
+
+func rolesOf(claim any) []string {
+	switch v := claim.(type) {
+	case string:
+		return strings.Fields(v)
+	case []any:
+		roles := make([]string, 0, len(v))
+		for _, r := range v {
+			if s, ok := r.(string); ok {
+				roles = append(roles, s)
+			}
+		}
+		return roles
+	default:
+		return nil
+	}
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	K   string `json:"k"`
+}
+
+// loadJWKS читает ключи RSA и oct из JWKS-файла. Ключи других типов пропускаются
+func loadJWKS(path string) (map[string]any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWKS: %w", err)
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("failed to parse JWKS: %w", err)
+	}
+
+	keys := make(map[string]any, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Kid == "" {
+			return nil, errors.New("JWKS key without kid")
+		}
+
+		switch k.Kty {
+		case "RSA":
+			n, err := base64.RawURLEncoding.DecodeString(k.N)
+			if err != nil {
+				return nil, fmt.Errorf("JWKS key %q: invalid n: %w", k.Kid, err)
+			}
+			e, err := base64.RawURLEncoding.DecodeString(k.E)
+			if err != nil {
+				return nil, fmt.Errorf("JWKS key %q: invalid e: %w", k.Kid, err)
+			}
+			keys[k.Kid] = &rsa.PublicKey{
+				N: new(big.Int).SetBytes(n),
+				E: int(new(big.Int).SetBytes(e).Int64()),
+			}
+		case "oct":
+			secret, err := base64.RawURLEncoding.DecodeString(k.K)
+			if err != nil {
+				return nil, fmt.Errorf("JWKS key %q: invalid k: %w", k.Kid, err)
+			}
+			keys[k.Kid] = secret
+		}
+	}
+	return keys, nil
+}
@@ -0,0 +1,297 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/chilly266futon/orderService/internal/auth"
+)
+
+const (
+	testSecret   = "test-secret"
+	testIssuer   = "exchange-auth"
+	testAudience = "order-service"
+)
+
+type testKeys struct {
+	rsa     *rsa.PrivateKey
+	pemFile string
+	pemData []byte
+	jwks    string
+}
+
+func newTestKeys(t *testing.T) testKeys {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("MarshalPKIXPublicKey() error = %v", err)
+	}
+	pemData := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+
+	dir := t.TempDir()
+	pemFile := filepath.Join(dir, "public.pem")
+	if err := os.WriteFile(pemFile, pemData, 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	jwks := filepath.Join(dir, "jwks.json")
+	set := fmt.Sprintf(`{"keys":[{"kty":"RSA","kid":"rsa-1","n":%q,"e":%q},{"kty":"oct","kid":"oct-1","k":%q}]}`,
+		base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		base64.RawURLEncoding.EncodeToString([]byte(testSecret)),
+	)
+	if err := os.WriteFile(jwks, []byte(set), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	return testKeys{rsa: key, pemFile: pemFile, pemData: pemData, jwks: jwks}
+}
+
+func claims(mutate func(jwt.MapClaims)) jwt.MapClaims {
+	c := jwt.MapClaims{
+		"sub":   "user-1",
+		"iss":   testIssuer,
+		"aud":   testAudience,
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"roles": []string{"trader", "admin"},
+	}
+	if mutate != nil {
+		mutate(c)
+	}
+	return c
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, kid string, c jwt.MapClaims, key any) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(method, c)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	s, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("SignedString() error = %v", err)
+	}
+	return s
+}
+
+func withAuthorization(value string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", value))
+}
+
+func TestJWTAuthenticator(t *testing.T) {
+	keys := newTestKeys(t)
+
+	hs, err := auth.NewJWTAuthenticator(auth.JWTConfig{HSSecret: testSecret, Issuer: testIssuer, Audience: testAudience})
+	if err != nil {
+		t.Fatalf("NewJWTAuthenticator() error = %v", err)
+	}
+	rs, err := auth.NewJWTAuthenticator(auth.JWTConfig{RSPublicKeyFile: keys.pemFile, Issuer: testIssuer, Audience: testAudience})
+	if err != nil {
+		t.Fatalf("NewJWTAuthenticator() error = %v", err)
+	}
+	jwks, err := auth.NewJWTAuthenticator(auth.JWTConfig{JWKSFile: keys.jwks, RolesClaim: "scope"})
+	if err != nil {
+		t.Fatalf("NewJWTAuthenticator() error = %v", err)
+	}
+
+	none := func() string {
+		s, err := jwt.NewWithClaims(jwt.SigningMethodNone, claims(nil)).SignedString(jwt.UnsafeAllowNoneSignatureType)
+		if err != nil {
+			t.Fatalf("SignedString() error = %v", err)
+		}
+		return s
+	}
+
+	tests := []struct {
+		name          string
+		authenticator *auth.JWTAuthenticator
+		header        string
+		wantNoCreds   bool
+		wantErr       bool
+		wantRoles     []string
+	}{
+		{
+			name:          "valid HS256",
+			authenticator: hs,
+			header:        "Bearer " + sign(t, jwt.SigningMethodHS256, "", claims(nil), []byte(testSecret)),
+			wantRoles:     []string{"trader", "admin"},
+		},
+		{
+			name:          "valid RS256",
+			authenticator: rs,
+			header:        "Bearer " + sign(t, jwt.SigningMethodRS256, "", claims(nil), keys.rsa),
+			wantRoles:     []string{"trader", "admin"},
+		},
+		{
+			name:          "lowercase bearer",
+			authenticator: hs,
+			header:        "bearer " + sign(t, jwt.SigningMethodHS256, "", claims(nil), []byte(testSecret)),
+			wantRoles:     []string{"trader", "admin"},
+		},
+		{
+			name:          "JWKS RSA key by kid, roles from a space separated claim",
+			authenticator: jwks,
+			header: "Bearer " + sign(t, jwt.SigningMethodRS256, "rsa-1", claims(func(c jwt.MapClaims) {
+				c["scope"] = "trader viewer"
+			}), keys.rsa),
+			wantRoles: []string{"trader", "viewer"},
+		},
+		{
+			name:          "HS token signed with the RS public key",
+			authenticator: rs,
+			header:        "Bearer " + sign(t, jwt.SigningMethodHS256, "", claims(nil), keys.pemData),
+			wantErr:       true,
+		},
+		{
+			name:          "HS token against a JWKS RSA kid",
+			authenticator: jwks,
+			header:        "Bearer " + sign(t, jwt.SigningMethodHS256, "rsa-1", claims(nil), keys.pemData),
+			wantErr:       true,
+		},
+		{
+			name:          "unknown kid",
+			authenticator: jwks,
+			header:        "Bearer " + sign(t, jwt.SigningMethodHS256, "other", claims(nil), []byte(testSecret)),
+			wantErr:       true,
+		},
+		{
+			name:          "alg none",
+			authenticator: hs,
+			header:        "Bearer " + none(),
+			wantErr:       true,
+		},
+		{
+			name:          "wrong HS secret",
+			authenticator: hs,
+			header:        "Bearer " + sign(t, jwt.SigningMethodHS256, "", claims(nil), []byte("other-secret")),
+			wantErr:       true,
+		},
+		{
+			name:          "expired",
+			authenticator: hs,
+			header: "Bearer " + sign(t, jwt.SigningMethodHS256, "", claims(func(c jwt.MapClaims) {
+				c["exp"] = time.Now().Add(-time.Minute).Unix()
+			}), []byte(testSecret)),
+			wantErr: true,
+		},
+		{
+			name:          "missing exp",
+			authenticator: hs,
+			header: "Bearer " + sign(t, jwt.SigningMethodHS256, "", claims(func(c jwt.MapClaims) {
+				delete(c, "exp")
+			}), []byte(testSecret)),
+			wantErr: true,
+		},
+		{
+			name:          "wrong issuer",
+			authenticator: hs,
+			header: "Bearer " + sign(t, jwt.SigningMethodHS256, "", claims(func(c jwt.MapClaims) {
+				c["iss"] = "someone-else"
+			}), []byte(testSecret)),
+			wantErr: true,
+		},
+		{
+			name:          "wrong audience",
+			authenticator: hs,
+			header: "Bearer " + sign(t, jwt.SigningMethodHS256, "", claims(func(c jwt.MapClaims) {
+				c["aud"] = "market-service"
+			}), []byte(testSecret)),
+			wantErr: true,
+		},
+		{
+			name:          "missing sub",
+			authenticator: hs,
+			header: "Bearer " + sign(t, jwt.SigningMethodHS256, "", claims(func(c jwt.MapClaims) {
+				delete(c, "sub")
+			}), []byte(testSecret)),
+			wantErr: true,
+		},
+		{
+			name:          "basic scheme",
+			authenticator: hs,
+			header:        "Basic dXNlcjpwYXNz",
+			wantErr:       true,
+		},
+		{
+			name:          "bearer without token",
+			authenticator: hs,
+			header:        "Bearer ",
+			wantErr:       true,
+		},
+		{
+			name:          "token without scheme",
+			authenticator: hs,
+			header:        sign(t, jwt.SigningMethodHS256, "", claims(nil), []byte(testSecret)),
+			wantErr:       true,
+		},
+		{
+			name:          "garbage token",
+			authenticator: hs,
+			header:        "Bearer not.a.jwt",
+			wantErr:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identity, err := tt.authenticator.Authenticate(withAuthorization(tt.header))
+			if errors.Is(err, auth.ErrNoCredentials) {
+				t.Fatalf("Authenticate() error = %v, present header must not count as missing credentials", err)
+			}
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Authenticate() = %+v, want error", identity)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Authenticate() error = %v", err)
+			}
+			if identity.UserID != "user-1" || identity.Method != auth.MethodJWT || !slices.Equal(identity.Roles, tt.wantRoles) {
+				t.Fatalf("Authenticate() = %+v, want user-1 via jwt with roles %v", identity, tt.wantRoles)
+			}
+		})
+	}
+}
+
+func TestJWTAuthenticatorWithoutHeader(t *testing.T) {
+	a, err := auth.NewJWTAuthenticator(auth.JWTConfig{HSSecret: testSecret})
+	if err != nil {
+		t.Fatalf("NewJWTAuthenticator() error = %v", err)
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("user_id", "user-1"))
+	if _, err := a.Authenticate(ctx); !errors.Is(err, auth.ErrNoCredentials) {
+		t.Fatalf("Authenticate() error = %v, want %v", err, auth.ErrNoCredentials)
+	}
+	if _, err := a.Authenticate(context.Background()); !errors.Is(err, auth.ErrNoCredentials) {
+		t.Fatalf("Authenticate() without metadata error = %v, want %v", err, auth.ErrNoCredentials)
+	}
+}
+
+func TestNewJWTAuthenticatorRequiresKey(t *testing.T) {
+	if _, err := auth.NewJWTAuthenticator(auth.JWTConfig{Issuer: testIssuer}); err == nil {
+		t.Fatal("NewJWTAuthenticator() without keys succeeded")
+	}
+}
@@ -0,0 +1,23 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/chilly266futon/exchange-shared/pkg/common"
+)
+
+// MetadataAuthenticator доверяет user_id из метаданных без проверки.
+// Только для локального запуска и тестов, когда аутентификация выключена
+type MetadataAuthenticator struct{}
+
+func NewMetadataAuthenticator() *MetadataAuthenticator {
+	return &MetadataAuthenticator{}
+}
+
+func (a *MetadataAuthenticator) Authenticate(ctx context.Context) (Identity, error) {
+	userID := common.GetUserID(ctx)
+	if userID == "" {
+		return Identity{}, ErrNoCredentials
+	}
+	return Identity{UserID: userID, Method: MethodMetadata}, nil
+}
@@ -0,0 +1,38 @@
+package auth
+
+import (
+	"context"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// MTLSAuthenticator берет личность из проверенного клиентского сертификата:
+// пользователь - CommonName, роли - OrganizationalUnit
+type MTLSAuthenticator struct{}
+
+func NewMTLSAuthenticator() *MTLSAuthenticator {
+	return &MTLSAuthenticator{}
+}
+
+func (a *MTLSAuthenticator) Authenticate(ctx context.Context) (Identity, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return Identity{}, ErrNoCredentials
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return Identity{}, ErrNoCredentials
+	}
+
+	cert := tlsInfo.State.VerifiedChains[0][0]
+	if cert.Subject.CommonName == "" {
+		return Identity{}, ErrNoCredentials
+	}
+
+	return Identity{
+		UserID: cert.Subject.CommonName,
+		Roles:  append([]string(nil), cert.Subject.OrganizationalUnit...),
+		Method: MethodMTLS,
+	}, nil
+}
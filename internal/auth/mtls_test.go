@@ -0,0 +1,91 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"slices"
+	"testing"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	"github.com/chilly266futon/orderService/internal/auth"
+)
+
+func withPeerCert(state tls.ConnectionState) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{State: state}})
+}
+
+func TestMTLSAuthenticator(t *testing.T) {
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: "user-1", OrganizationalUnit: []string{"trader", "admin"}}}
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		wantNo    bool
+		wantRoles []string
+	}{
+		{
+			name:      "verified chain",
+			ctx:       withPeerCert(tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}),
+			wantRoles: []string{"trader", "admin"},
+		},
+		{
+			name:   "certificate not verified",
+			ctx:    withPeerCert(tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}),
+			wantNo: true,
+		},
+		{
+			name: "certificate without common name",
+			ctx: withPeerCert(tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{
+				{Subject: pkix.Name{OrganizationalUnit: []string{"admin"}}},
+			}}}),
+			wantNo: true,
+		},
+		{
+			name:   "plaintext connection",
+			ctx:    peer.NewContext(context.Background(), &peer.Peer{}),
+			wantNo: true,
+		},
+		{
+			name:   "no peer",
+			ctx:    context.Background(),
+			wantNo: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identity, err := auth.NewMTLSAuthenticator().Authenticate(tt.ctx)
+			if tt.wantNo {
+				if !errors.Is(err, auth.ErrNoCredentials) {
+					t.Fatalf("Authenticate() = %+v, %v, want %v", identity, err, auth.ErrNoCredentials)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Authenticate() error = %v", err)
+			}
+			if identity.UserID != "user-1" || identity.Method != auth.MethodMTLS || !slices.Equal(identity.Roles, tt.wantRoles) {
+				t.Fatalf("Authenticate() = %+v, want user-1 via mtls with roles %v", identity, tt.wantRoles)
+			}
+		})
+	}
+}
+
+func TestMTLSAuthenticatorCopiesRoles(t *testing.T) {
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: "user-1", OrganizationalUnit: []string{"trader"}}}
+	ctx := withPeerCert(tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}})
+
+	identity, err := auth.NewMTLSAuthenticator().Authenticate(ctx)
+	if err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+	identity.Roles[0] = "admin"
+	if cert.Subject.OrganizationalUnit[0] != "trader" {
+		t.Fatal("identity roles share memory with the certificate")
+	}
+}
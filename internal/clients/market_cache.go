@@ -0,0 +1,194 @@
+package clients
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+
+	spotpb "github.com/chilly266futon/exchange-service-contracts/gen/pb/spot"
+	"github.com/chilly266futon/exchange-shared/pkg/interceptors"
+)
+
+// CacheConfig кэш каталога рынков spot-service
+type CacheConfig struct {
+	Enabled bool
+	// TTL сколько каталог считается свежим; после этого он перечитывается при следующем запросе
+	TTL time.Duration
+	// RefreshInterval период фонового обновления известных каталогов, 0 - без фонового обновления
+	RefreshInterval time.Duration
+	// MaxStale сколько после загрузки можно отдавать каталог, если spot-service недоступен
+	MaxStale time.Duration
+}
+
+// fetchMarkets загружает рынки, доступные пользователю с ролями roles
+type fetchMarkets func(ctx context.Context, roles []spotpb.UserRole) ([]*spotpb.Market, error)
+
+// marketCatalog рынки одного набора ролей по ID
+type marketCatalog struct {
+	roles     []spotpb.UserRole
+	markets   map[string]*spotpb.Market
+	fetchedAt time.Time
+}
+
+// marketCache каталоги рынков по набору ролей. Набор ролей влияет на то, какие рынки отдает spot-service,
+// поэтому для каждого набора хранится свой каталог. Одновременные промахи по одному набору
+// схлопываются в один запрос
+type marketCache struct {
+	fetch  fetchMarkets
+	cfg    CacheConfig
+	logger *zap.Logger
+	now    func() time.Time
+
+	mu       sync.RWMutex
+	catalogs map[string]*marketCatalog
+	group    singleflight.Group
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+func newMarketCache(fetch fetchMarkets, cfg CacheConfig, logger *zap.Logger) *marketCache {
+	return &marketCache{
+		fetch:    fetch,
+		cfg:      cfg,
+		logger:   logger,
+		now:      time.Now,
+		catalogs: make(map[string]*marketCatalog),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// market ищет рынок в каталоге набора ролей. Если каталог устарел, он перечитывается; при ошибке
+// spot-service отдается прежний каталог, пока он не старше MaxStale
+func (c *marketCache) market(ctx context.Context, marketID string, roles []spotpb.UserRole) (*spotpb.Market, error) {
+	key := roleSetKey(roles)
+
+	c.mu.RLock()
+	catalog := c.catalogs[key]
+	c.mu.RUnlock()
+
+	if catalog == nil || c.now().Sub(catalog.fetchedAt) >= c.cfg.TTL {
+		fresh, err := c.load(ctx, key, roles)
+		if err != nil {
+			if catalog == nil || c.now().Sub(catalog.fetchedAt) >= c.cfg.MaxStale {
+				return nil, err
+			}
+			c.logger.Warn("spot service unavailable, serving stale market catalog",
+				zap.String("trace_id", interceptors.GetTraceID(ctx)),
+				zap.String("roles", key),
+				zap.Duration("age", c.now().Sub(catalog.fetchedAt)),
+				zap.Error(err),
+			)
+		} else {
+			catalog = fresh
+		}
+	}
+
+	return catalog.markets[marketID], nil
+}
+
+// load загружает каталог набора ролей через singleflight. Запрос не отменяется вместе с ctx вызывающего,
+// потому что его результат ждут и другие вызовы
+func (c *marketCache) load(ctx context.Context, key string, roles []spotpb.UserRole) (*marketCatalog, error) {
+	ch := c.group.DoChan(key, func() (any, error) {
+		markets, err := c.fetch(context.WithoutCancel(ctx), roles)
+		if err != nil {
+			return nil, err
+		}
+
+		catalog := &marketCatalog{
+			roles:     slices.Clone(roles),
+			markets:   make(map[string]*spotpb.Market, len(markets)),
+			fetchedAt: c.now(),
+		}
+		for _, m := range markets {
+			catalog.markets[m.Id] = m
+		}
+
+		c.mu.Lock()
+		c.catalogs[key] = catalog
+		c.mu.Unlock()
+		return catalog, nil
+	})
+
+	select {
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return res.Val.(*marketCatalog), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Start запускает фоновое обновление каталогов, если задан RefreshInterval
+func (c *marketCache) Start() {
+	if c.cfg.RefreshInterval <= 0 {
+		close(c.done)
+		return
+	}
+	go c.run()
+}
+
+// Stop останавливает фоновое обновление и дожидается его завершения
+func (c *marketCache) Stop() {
+	close(c.stop)
+	<-c.done
+}
+
+func (c *marketCache) run() {
+	defer close(c.done)
+
+	ticker := time.NewTicker(c.cfg.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C:
+			c.refresh()
+		}
+	}
+}
+
+// refresh перечитывает все известные каталоги. Ошибки только логируются: каталог останется прежним
+// и будет перечитан при следующем запросе после TTL
+func (c *marketCache) refresh() {
+	c.mu.RLock()
+	catalogs := make(map[string][]spotpb.UserRole, len(c.catalogs))
+	for key, catalog := range c.catalogs {
+		catalogs[key] = catalog.roles
+	}
+	c.mu.RUnlock()
+
+	for key, roles := range catalogs {
+		if _, err := c.load(context.Background(), key, roles); err != nil {
+			c.logger.Warn("failed to refresh market catalog",
+				zap.String("roles", key),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+// roleSetKey ключ набора ролей, не зависящий от порядка и повторов
+func roleSetKey(roles []spotpb.UserRole) string {
+	sorted := slices.Clone(roles)
+	slices.Sort(sorted)
+	sorted = slices.Compact(sorted)
+
+	parts := make([]string, len(sorted))
+	for i, r := range sorted {
+		parts[i] = strconv.Itoa(int(r))
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,314 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	spotpb "github.com/chilly266futon/exchange-service-contracts/gen/pb/spot"
+)
+
+// fakeSpot клиент spot-service, отдающий заданные рынки любому набору ролей
+type fakeSpot struct {
+	mu      sync.Mutex
+	markets []*spotpb.Market
+	err     error
+	// release если задан, ViewMarkets ждет его закрытия
+	release chan struct{}
+	started chan struct{}
+	calls   atomic.Int64
+}
+
+func newFakeSpot(marketIDs ...string) *fakeSpot {
+	f := &fakeSpot{started: make(chan struct{}, 1)}
+	f.set(marketIDs...)
+	return f
+}
+
+func (f *fakeSpot) set(marketIDs ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.markets = nil
+	for _, id := range marketIDs {
+		f.markets = append(f.markets, &spotpb.Market{Id: id, Enabled: true})
+	}
+}
+
+func (f *fakeSpot) fail(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.err = err
+}
+
+func (f *fakeSpot) ViewMarkets(ctx context.Context, _ []spotpb.UserRole) ([]*spotpb.Market, error) {
+	f.calls.Add(1)
+	select {
+	case f.started <- struct{}{}:
+	default:
+	}
+	if f.release != nil {
+		select {
+		case <-f.release:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.markets, f.err
+}
+
+func (f *fakeSpot) Close() error {
+	return nil
+}
+
+// testClock часы, которые двигает тест
+type testClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *testClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func newTestCache(spot *fakeSpot, cfg CacheConfig) (*marketCache, *testClock) {
+	client := &spotClientImpl{client: spot, timeout: time.Second, logger: zap.NewNop()}
+	clock := &testClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+	cache := newMarketCache(client.viewMarkets, cfg, zap.NewNop())
+	cache.now = clock.Now
+	return cache, clock
+}
+
+var verified = []spotpb.UserRole{spotpb.UserRole_USER_ROLE_VERIFIED}
+
+func lookup(t *testing.T, c *marketCache, marketID string, roles []spotpb.UserRole) *spotpb.Market {
+	t.Helper()
+
+	m, err := c.market(context.Background(), marketID, roles)
+	if err != nil {
+		t.Fatalf("market() error = %v", err)
+	}
+	return m
+}
+
+func TestMarketCacheCollapsesConcurrentMisses(t *testing.T) {
+	spot := newFakeSpot("market-1")
+	spot.release = make(chan struct{})
+	c, _ := newTestCache(spot, CacheConfig{TTL: time.Minute, MaxStale: time.Hour})
+
+	const callers = 10
+	var wg sync.WaitGroup
+	results := make(chan *spotpb.Market, callers)
+	for range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m, err := c.market(context.Background(), "market-1", verified)
+			if err != nil {
+				t.Errorf("market() error = %v", err)
+			}
+			results <- m
+		}()
+	}
+
+	// первый запрос в spot-service начался; даем остальным вызовам присоединиться к нему
+	<-spot.started
+	time.Sleep(20 * time.Millisecond)
+	close(spot.release)
+	wg.Wait()
+	close(results)
+
+	for m := range results {
+		if m == nil || m.Id != "market-1" {
+			t.Fatalf("market() = %v, want market-1", m)
+		}
+	}
+	if n := spot.calls.Load(); n != 1 {
+		t.Fatalf("spot service called %d times, want 1", n)
+	}
+}
+
+func TestMarketCacheCallerCancelDoesNotCancelLoad(t *testing.T) {
+	spot := newFakeSpot("market-1")
+	spot.release = make(chan struct{})
+	c, _ := newTestCache(spot, CacheConfig{TTL: time.Minute, MaxStale: time.Hour})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		_, err := c.market(ctx, "market-1", verified)
+		done <- err
+	}()
+
+	<-spot.started
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("market() error = %v, want %v", err, context.Canceled)
+	}
+
+	// загрузка продолжается и наполняет кэш для следующих вызовов
+	close(spot.release)
+	waitForCatalog(t, c, verified)
+	if m := lookup(t, c, "market-1", verified); m == nil {
+		t.Fatal("market-1 not cached")
+	}
+	if n := spot.calls.Load(); n != 1 {
+		t.Fatalf("spot service called %d times, want 1", n)
+	}
+}
+
+func TestMarketCacheTTL(t *testing.T) {
+	spot := newFakeSpot("market-1")
+	c, clock := newTestCache(spot, CacheConfig{TTL: time.Minute, MaxStale: time.Hour})
+
+	if m := lookup(t, c, "market-1", verified); m == nil {
+		t.Fatal("market-1 not found")
+	}
+
+	spot.set("market-1", "market-2")
+	clock.Advance(time.Minute - time.Nanosecond)
+	if m := lookup(t, c, "market-2", verified); m != nil {
+		t.Fatal("market-2 found before the catalog expired")
+	}
+	if n := spot.calls.Load(); n != 1 {
+		t.Fatalf("spot service called %d times within ttl, want 1", n)
+	}
+
+	clock.Advance(time.Nanosecond)
+	if m := lookup(t, c, "market-2", verified); m == nil {
+		t.Fatal("market-2 not found after the catalog expired")
+	}
+	if n := spot.calls.Load(); n != 2 {
+		t.Fatalf("spot service called %d times, want 2", n)
+	}
+}
+
+func TestMarketCacheKeepsCatalogPerRoleSet(t *testing.T) {
+	spot := newFakeSpot("market-1")
+	c, _ := newTestCache(spot, CacheConfig{TTL: time.Minute, MaxStale: time.Hour})
+
+	lookup(t, c, "market-1", []spotpb.UserRole{spotpb.UserRole_USER_ROLE_VERIFIED, spotpb.UserRole_USER_ROLE_PREMIUM})
+	lookup(t, c, "market-1", []spotpb.UserRole{spotpb.UserRole_USER_ROLE_PREMIUM, spotpb.UserRole_USER_ROLE_VERIFIED, spotpb.UserRole_USER_ROLE_PREMIUM})
+	if n := spot.calls.Load(); n != 1 {
+		t.Fatalf("spot service called %d times for the same role set, want 1", n)
+	}
+
+	lookup(t, c, "market-1", verified)
+	if n := spot.calls.Load(); n != 2 {
+		t.Fatalf("spot service called %d times for two role sets, want 2", n)
+	}
+}
+
+func TestMarketCacheServesStaleUntilMaxStale(t *testing.T) {
+	errUnavailable := errors.New("spot service unavailable")
+	spot := newFakeSpot("market-1")
+	c, clock := newTestCache(spot, CacheConfig{TTL: time.Minute, MaxStale: 10 * time.Minute})
+
+	lookup(t, c, "market-1", verified)
+	spot.fail(errUnavailable)
+
+	clock.Advance(time.Minute)
+	if m := lookup(t, c, "market-1", verified); m == nil {
+		t.Fatal("stale market-1 not served")
+	}
+
+	clock.Advance(9*time.Minute - time.Nanosecond)
+	if m := lookup(t, c, "market-1", verified); m == nil {
+		t.Fatal("stale market-1 not served before MaxStale")
+	}
+
+	clock.Advance(time.Nanosecond)
+	if _, err := c.market(context.Background(), "market-1", verified); !errors.Is(err, errUnavailable) {
+		t.Fatalf("market() at MaxStale error = %v, want %v", err, errUnavailable)
+	}
+
+	// без каталога ошибка возвращается сразу
+	if _, err := c.market(context.Background(), "market-1", []spotpb.UserRole{spotpb.UserRole_USER_ROLE_PREMIUM}); !errors.Is(err, errUnavailable) {
+		t.Fatalf("market() without catalog error = %v, want %v", err, errUnavailable)
+	}
+
+	// spot-service вернулся: каталог снова свежий
+	spot.fail(nil)
+	if m := lookup(t, c, "market-1", verified); m == nil {
+		t.Fatal("market-1 not found after recovery")
+	}
+}
+
+func TestMarketCacheBackgroundRefresh(t *testing.T) {
+	spot := newFakeSpot("market-1")
+	c, _ := newTestCache(spot, CacheConfig{TTL: time.Hour, MaxStale: time.Hour, RefreshInterval: 10 * time.Millisecond})
+	c.Start()
+	defer c.Stop()
+
+	lookup(t, c, "market-1", verified)
+	spot.set("market-2")
+
+	// каталог обновляется в фоне, хотя TTL еще не истек
+	deadline := time.Now().Add(5 * time.Second)
+	for lookup(t, c, "market-2", verified) == nil {
+		if time.Now().After(deadline) {
+			t.Fatal("catalog was not refreshed in the background")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if m := lookup(t, c, "market-1", verified); m != nil {
+		t.Fatal("removed market-1 is still served")
+	}
+}
+
+func TestMarketCacheRefreshErrorKeepsCatalog(t *testing.T) {
+	spot := newFakeSpot("market-1")
+	c, _ := newTestCache(spot, CacheConfig{TTL: time.Hour, MaxStale: time.Hour})
+
+	lookup(t, c, "market-1", verified)
+	spot.fail(errors.New("spot service unavailable"))
+	c.refresh()
+
+	if n := spot.calls.Load(); n != 2 {
+		t.Fatalf("spot service called %d times, want refresh to reload the catalog", n)
+	}
+	if m := lookup(t, c, "market-1", verified); m == nil {
+		t.Fatal("market-1 lost after a failed refresh")
+	}
+}
+
+func TestMarketCacheStopWithoutRefresh(t *testing.T) {
+	c, _ := newTestCache(newFakeSpot(), CacheConfig{TTL: time.Minute})
+	c.Start()
+	c.Stop()
+}
+
+func waitForCatalog(t *testing.T, c *marketCache, roles []spotpb.UserRole) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		c.mu.RLock()
+		catalog := c.catalogs[roleSetKey(roles)]
+		c.mu.RUnlock()
+		if catalog != nil {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the catalog")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
@@ -0,0 +1,74 @@
+package clients
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/chilly266futon/orderService/internal/domain"
+)
+
+// RulesTable торговые правила рынков. spot-service пока не отдает правила в контракте,
+// поэтому они задаются конфигурацией: правила рынка из Markets, иначе Default
+type RulesTable struct {
+	Default domain.MarketRules
+	Markets map[string]domain.MarketRules
+}
+
+// RuleValues правила одного рынка из конфигурации, значения - десятичные строки. Пусто или "0" - без ограничения
+type RuleValues struct {
+	TickSize    string
+	StepSize    string
+	MinQuantity string
+	MaxQuantity string
+	MinNotional string
+}
+
+// NewRulesTable разбирает правила рынков: defaults для всех, markets переопределяет их целиком по ID рынка
+func NewRulesTable(defaults RuleValues, markets map[string]RuleValues) (RulesTable, error) {
+	table := RulesTable{Markets: make(map[string]domain.MarketRules, len(markets))}
+
+	var err error
+	if table.Default, err = parseMarketRules(defaults); err != nil {
+		return RulesTable{}, fmt.Errorf("default: %w", err)
+	}
+	for marketID, values := range markets {
+		if table.Markets[marketID], err = parseMarketRules(values); err != nil {
+			return RulesTable{}, fmt.Errorf("market %s: %w", marketID, err)
+		}
+	}
+	return table, nil
+}
+
+func parseMarketRules(values RuleValues) (domain.MarketRules, error) {
+	var rules domain.MarketRules
+	fields := []struct {
+		name  string
+		value string
+		dst   *decimal.Decimal
+	}{
+		{"tick_size", values.TickSize, &rules.TickSize},
+		{"step_size", values.StepSize, &rules.StepSize},
+		{"min_quantity", values.MinQuantity, &rules.MinQuantity},
+		{"max_quantity", values.MaxQuantity, &rules.MaxQuantity},
+		{"min_notional", values.MinNotional, &rules.MinNotional},
+	}
+	for _, f := range fields {
+		if f.value == "" {
+			continue
+		}
+		value, err := decimal.NewFromString(f.value)
+		if err != nil || value.IsNegative() {
+			return domain.MarketRules{}, fmt.Errorf("invalid %s %q", f.name, f.value)
+		}
+		*f.dst = value
+	}
+	return rules, nil
+}
+
+func (t RulesTable) rulesFor(marketID string) domain.MarketRules {
+	if rules, ok := t.Markets[marketID]; ok {
+		return rules
+	}
+	return t.Default
+}
@@ -0,0 +1,85 @@
+package clients
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/chilly266futon/orderService/internal/domain"
+)
+
+func TestNewRulesTable(t *testing.T) {
+	table, err := NewRulesTable(
+		RuleValues{TickSize: "0.01", StepSize: "0", MinQuantity: "0.001", MaxQuantity: "", MinNotional: "5"},
+		map[string]RuleValues{"btc-usdt": {TickSize: "0.5", MaxQuantity: "10"}},
+	)
+	if err != nil {
+		t.Fatalf("NewRulesTable() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		marketID string
+		want     domain.MarketRules
+	}{
+		{
+			name:     "market without own rules gets the default",
+			marketID: "eth-usdt",
+			want: domain.MarketRules{
+				TickSize:    decimal.RequireFromString("0.01"),
+				MinQuantity: decimal.RequireFromString("0.001"),
+				MinNotional: decimal.RequireFromString("5"),
+			},
+		},
+		{
+			// правила рынка заменяют default целиком, незаданные поля не наследуются
+			name:     "market rules replace the default",
+			marketID: "btc-usdt",
+			want: domain.MarketRules{
+				TickSize:    decimal.RequireFromString("0.5"),
+				MaxQuantity: decimal.RequireFromString("10"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := table.rulesFor(tt.marketID)
+			fields := []struct {
+				name      string
+				got, want decimal.Decimal
+			}{
+				{"tick_size", got.TickSize, tt.want.TickSize},
+				{"step_size", got.StepSize, tt.want.StepSize},
+				{"min_quantity", got.MinQuantity, tt.want.MinQuantity},
+				{"max_quantity", got.MaxQuantity, tt.want.MaxQuantity},
+				{"min_notional", got.MinNotional, tt.want.MinNotional},
+			}
+			for _, f := range fields {
+				if !f.got.Equal(f.want) {
+					t.Errorf("%s = %s, want %s", f.name, f.got, f.want)
+				}
+			}
+		})
+	}
+}
+
+func TestNewRulesTableRejectsInvalidValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		defaults RuleValues
+		markets  map[string]RuleValues
+	}{
+		{"not a number", RuleValues{TickSize: "abc"}, nil},
+		{"negative", RuleValues{MinNotional: "-1"}, nil},
+		{"invalid market rule", RuleValues{}, map[string]RuleValues{"btc-usdt": {StepSize: "1e"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewRulesTable(tt.defaults, tt.markets); err == nil {
+				t.Fatal("NewRulesTable() accepted an invalid value")
+			}
+		})
+	}
+}
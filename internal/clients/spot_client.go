@@ -11,16 +11,27 @@ import (
 	"github.com/chilly266futon/exchange-shared/pkg/breaker"
 	"github.com/chilly266futon/exchange-shared/pkg/interceptors"
 	"github.com/chilly266futon/spotService/pkg/spotclient"
+
+	"github.com/chilly266futon/orderService/internal/domain"
 )
 
 type SpotClient interface {
-	MarketExists(ctx context.Context, marketID string, userRoles []spotpb.UserRole) (bool, error)
+	// MarketRules возвращает торговые правила рынка или nil, если рынок не найден, выключен, удален или недоступен с ролями userRoles
+	MarketRules(ctx context.Context, marketID string, userRoles []spotpb.UserRole) (*domain.MarketRules, error)
+	Close() error
+}
+
+// marketViewer часть клиента spot-service, которой пользуется order-service
+type marketViewer interface {
+	ViewMarkets(ctx context.Context, userRoles []spotpb.UserRole) ([]*spotpb.Market, error)
 	Close() error
 }
 
 type spotClientImpl struct {
-	client  *spotclient.Client
+	client  marketViewer
 	breaker *breaker.Wrapper
+	cache   *marketCache
+	rules   RulesTable
 	timeout time.Duration
 	logger  *zap.Logger
 }
@@ -30,6 +41,8 @@ type Config struct {
 	Timeout       time.Duration
 	EnableBreaker bool
 	BreakerConfig breaker.Config
+	Cache         CacheConfig
+	Rules         RulesTable
 }
 
 func NewSpotClient(cfg Config, logger *zap.Logger) (SpotClient, error) {
@@ -42,6 +55,7 @@ func NewSpotClient(cfg Config, logger *zap.Logger) (SpotClient, error) {
 
 	impl := &spotClientImpl{
 		client:  client,
+		rules:   cfg.Rules,
 		timeout: cfg.Timeout,
 		logger:  logger,
 	}
@@ -50,44 +64,81 @@ func NewSpotClient(cfg Config, logger *zap.Logger) (SpotClient, error) {
 		impl.breaker = breaker.NewWrapper("spot-service", cfg.BreakerConfig)
 	}
 
+	if cfg.Cache.Enabled {
+		impl.cache = newMarketCache(impl.viewMarkets, cfg.Cache, logger)
+		impl.cache.Start()
+	}
+
 	return impl, nil
 }
 
-func (c *spotClientImpl) MarketExists(ctx context.Context, marketID string, userRoles []spotpb.UserRole) (bool, error) {
+func (c *spotClientImpl) MarketRules(ctx context.Context, marketID string, userRoles []spotpb.UserRole) (*domain.MarketRules, error) {
+	exists, err := c.marketExists(ctx, marketID, userRoles)
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	rules := c.rules.rulesFor(marketID)
+	return &rules, nil
+}
+
+func (c *spotClientImpl) marketExists(ctx context.Context, marketID string, userRoles []spotpb.UserRole) (bool, error) {
+	if c.cache != nil {
+		market, err := c.cache.market(ctx, marketID, userRoles)
+		return market != nil && tradable(market), err
+	}
+
+	markets, err := c.viewMarkets(ctx, userRoles)
+	if err != nil {
+		return false, err
+	}
+
+	for _, market := range markets {
+		if market.Id == marketID {
+			return tradable(market), nil
+		}
+	}
+	return false, nil
+}
+
+// tradable рынок включен и не удален: spot-service может отдавать такие рынки в каталоге
+func tradable(market *spotpb.Market) bool {
+	return market.Enabled && market.DeletedAt == nil
+}
+
+// viewMarkets загружает рынки, доступные пользователю с ролями userRoles, через circuit breaker, если он включен
+func (c *spotClientImpl) viewMarkets(ctx context.Context, userRoles []spotpb.UserRole) ([]*spotpb.Market, error) {
 	ctx, cancel := context.WithTimeout(ctx, c.timeout)
 	defer cancel()
 
 	traceID := interceptors.GetTraceID(ctx)
 
-	checkMarket := func() (bool, error) {
+	view := func() ([]*spotpb.Market, error) {
 		markets, err := c.client.ViewMarkets(ctx, userRoles)
 		if err != nil {
 			c.logger.Error("market unavailable",
 				zap.String("trace_id", traceID))
-			return false, err
+			return nil, err
 		}
-
-		for _, market := range markets {
-			if market.Id == marketID {
-				return true, nil
-			}
-		}
-		return false, nil
+		return markets, nil
 	}
 
 	if c.breaker != nil {
-		var exists bool
+		var markets []*spotpb.Market
 		err := c.breaker.Execute(func() error {
 			var execErr error
-			exists, execErr = checkMarket()
+			markets, execErr = view()
 			return execErr
 		})
-		return exists, err
+		return markets, err
 	}
 
-	return checkMarket()
+	return view()
 }
 
 func (c *spotClientImpl) Close() error {
+	if c.cache != nil {
+		c.cache.Stop()
+	}
 	return c.client.Close()
 }
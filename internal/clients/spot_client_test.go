@@ -0,0 +1,63 @@
+package clients
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	spotpb "github.com/chilly266futon/exchange-service-contracts/gen/pb/spot"
+
+	"github.com/chilly266futon/orderService/internal/domain"
+)
+
+func TestMarketRulesChecksMarketState(t *testing.T) {
+	spot := newFakeSpot()
+	spot.markets = []*spotpb.Market{
+		{Id: "enabled", Enabled: true},
+		{Id: "disabled"},
+		{Id: "deleted", Enabled: true, DeletedAt: timestamppb.New(time.Now())},
+		{Id: "custom", Enabled: true},
+	}
+	rules := RulesTable{
+		Default: domain.MarketRules{TickSize: decimal.RequireFromString("0.01")},
+		Markets: map[string]domain.MarketRules{"custom": {TickSize: decimal.RequireFromString("0.5")}},
+	}
+
+	tests := []struct {
+		marketID string
+		wantTick string // пусто - правил нет, рынок недоступен
+	}{
+		{"enabled", "0.01"},
+		{"custom", "0.5"},
+		{"disabled", ""},
+		{"deleted", ""},
+		{"unknown", ""},
+	}
+
+	for _, cached := range []bool{false, true} {
+		client := &spotClientImpl{client: spot, rules: rules, timeout: time.Second, logger: zap.NewNop()}
+		if cached {
+			client.cache = newMarketCache(client.viewMarkets, CacheConfig{TTL: time.Minute, MaxStale: time.Hour}, zap.NewNop())
+		}
+
+		for _, tt := range tests {
+			got, err := client.MarketRules(context.Background(), tt.marketID, verified)
+			if err != nil {
+				t.Fatalf("MarketRules(%s) cached=%v error = %v", tt.marketID, cached, err)
+			}
+			if tt.wantTick == "" {
+				if got != nil {
+					t.Fatalf("MarketRules(%s) cached=%v = %+v, want nil", tt.marketID, cached, got)
+				}
+				continue
+			}
+			if got == nil || !got.TickSize.Equal(decimal.RequireFromString(tt.wantTick)) {
+				t.Fatalf("MarketRules(%s) cached=%v = %+v, want tick size %s", tt.marketID, cached, got, tt.wantTick)
+			}
+		}
+	}
+}
@@ -13,7 +13,16 @@ type Config struct {
 	Server      ServerConfig      `yaml:"server"`
 	SpotService SpotServiceConfig `yaml:"spot_service"`
 	RateLimit   RateLimitConfig   `yaml:"rate_limit"`
+	Auth        AuthConfig        `yaml:"auth"`
+	Roles       RolesConfig       `yaml:"roles"`
+	Admin       AdminConfig       `yaml:"admin"`
+	Storage     StorageConfig     `yaml:"storage"`
+	Outbox      OutboxConfig      `yaml:"outbox"`
+	Matching    MatchingConfig    `yaml:"matching"`
+	Trigger     TriggerConfig     `yaml:"trigger"`
+	Sweeper     SweeperConfig     `yaml:"sweeper"`
 	Health      HealthConfig      `yaml:"health"`
+	Metrics     MetricsConfig     `yaml:"metrics"`
 	Logger      logger.Config     `yaml:"logger"`
 }
 
@@ -24,10 +33,36 @@ type ServerConfig struct {
 }
 
 type SpotServiceConfig struct {
-	Addr          string        `yaml:"addr"`
-	Timeout       time.Duration `yaml:"timeout"`
-	EnableBreaker bool          `yaml:"enable_breaker"`
-	Breaker       BreakerConfig `yaml:"breaker"`
+	Addr          string            `yaml:"addr"`
+	Timeout       time.Duration     `yaml:"timeout"`
+	EnableBreaker bool              `yaml:"enable_breaker"`
+	Breaker       BreakerConfig     `yaml:"breaker"`
+	Cache         CacheConfig       `yaml:"cache"`
+	MarketRules   MarketRulesConfig `yaml:"market_rules"`
+}
+
+// MarketRulesConfig торговые правила рынков: Default для всех, Markets переопределяет их целиком по ID рынка
+type MarketRulesConfig struct {
+	Default MarketRuleConfig            `yaml:"default"`
+	Markets map[string]MarketRuleConfig `yaml:"markets"`
+}
+
+// MarketRuleConfig правила одного рынка, значения - десятичные строки. Пусто или "0" - без ограничения
+type MarketRuleConfig struct {
+	TickSize    string `yaml:"tick_size"`
+	StepSize    string `yaml:"step_size"`
+	MinQuantity string `yaml:"min_quantity"`
+	MaxQuantity string `yaml:"max_quantity"`
+	MinNotional string `yaml:"min_notional"`
+}
+
+// CacheConfig кэш каталога рынков spot-service. MaxStale - сколько после загрузки
+// можно отдавать каталог, если spot-service недоступен
+type CacheConfig struct {
+	Enabled         bool          `yaml:"enabled"`
+	TTL             time.Duration `yaml:"ttl"`
+	RefreshInterval time.Duration `yaml:"refresh_interval"`
+	MaxStale        time.Duration `yaml:"max_stale"`
 }
 
 type BreakerConfig struct {
@@ -37,6 +72,116 @@ type BreakerConfig struct {
 	Attempts    uint32        `yaml:"attempts"`
 }
 
+const (
+	StorageDriverMemory   = "memory"
+	StorageDriverPostgres = "postgres"
+)
+
+// StorageConfig выбирает хранилище заказов: memory или postgres
+type StorageConfig struct {
+	Driver   string         `yaml:"driver"`
+	Postgres PostgresConfig `yaml:"postgres"`
+}
+
+type PostgresConfig struct {
+	DSN             string        `yaml:"dsn"`
+	MaxOpenConns    int           `yaml:"max_open_conns"`
+	MaxIdleConns    int           `yaml:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime"`
+	Migrate         bool          `yaml:"migrate"`
+}
+
+const (
+	OutboxSinkLog  = "log"
+	OutboxSinkFile = "file"
+)
+
+// OutboxConfig публикация событий заказов. Enabled включает внешний получатель Sink: log или file.
+// Подписки SubscribeOrderUpdates и движки читают outbox независимо от релея с периодом TailInterval
+type OutboxConfig struct {
+	Enabled          bool          `yaml:"enabled"`
+	SubscriberBuffer int           `yaml:"subscriber_buffer"`
+	PollInterval     time.Duration `yaml:"poll_interval"`
+	TailInterval     time.Duration `yaml:"tail_interval"`
+	BatchSize        int           `yaml:"batch_size"`
+	Sink             string        `yaml:"sink"`
+	FilePath         string        `yaml:"file_path"`
+}
+
+// MatchingConfig встроенный движок сопоставления LIMIT и MARKET заказов. QueueSize - очередь команд одного рынка.
+// При postgres-хранилище заказы сводит один экземпляр, удерживающий advisory lock LeaseKey
+type MatchingConfig struct {
+	Enabled   bool  `yaml:"enabled"`
+	QueueSize int   `yaml:"queue_size"`
+	LeaseKey  int64 `yaml:"lease_key"`
+}
+
+// TriggerConfig движок стоп-заказов. Feed - источник цен последних сделок: file - файл JSON Lines FilePath,
+// matching - сделки встроенного движка сопоставления.
+// PollInterval - как часто проверять дописанные строки, 0 - прочитать файл один раз
+type TriggerConfig struct {
+	Enabled      bool          `yaml:"enabled"`
+	Feed         string        `yaml:"feed"`
+	FilePath     string        `yaml:"file_path"`
+	PollInterval time.Duration `yaml:"poll_interval"`
+}
+
+// SweeperConfig фоновая очистка заказов: истечение GTD и отклонение зависших в CREATED через CreatedTimeout
+// (0 - не отклонять). При postgres-хранилище проход выполняет один экземпляр, удерживающий advisory lock LeaseKey
+type SweeperConfig struct {
+	Enabled        bool          `yaml:"enabled"`
+	Interval       time.Duration `yaml:"interval"`
+	CreatedTimeout time.Duration `yaml:"created_timeout"`
+	BatchSize      int           `yaml:"batch_size"`
+	LeaseKey       int64         `yaml:"lease_key"`
+}
+
+// AuthConfig аутентификация вызовов. Выключить ее можно только вместе с Insecure: тогда user_id
+// из метаданных принимается без проверки. Без Insecure сервис с выключенной аутентификацией не стартует
+type AuthConfig struct {
+	Enabled  bool       `yaml:"enabled"`
+	Insecure bool       `yaml:"insecure"`
+	JWT      JWTConfig  `yaml:"jwt"`
+	MTLS     MTLSConfig `yaml:"mtls"`
+}
+
+// JWTConfig ключи проверки bearer-токенов. Достаточно одного из hs_secret, rs_public_key_file, jwks_file
+type JWTConfig struct {
+	HSSecret        string `yaml:"hs_secret"`
+	RSPublicKeyFile string `yaml:"rs_public_key_file"`
+	JWKSFile        string `yaml:"jwks_file"`
+	Issuer          string `yaml:"issuer"`
+	Audience        string `yaml:"audience"`
+	RolesClaim      string `yaml:"roles_claim"`
+}
+
+// MTLSConfig TLS сервера с проверкой клиентских сертификатов, подписанных ClientCAFile
+type MTLSConfig struct {
+	Enabled      bool   `yaml:"enabled"`
+	CertFile     string `yaml:"cert_file"`
+	KeyFile      string `yaml:"key_file"`
+	ClientCAFile string `yaml:"client_ca_file"`
+}
+
+const (
+	RolesStoreFile     = "file"
+	RolesStorePostgres = "postgres"
+)
+
+// RolesConfig источники ролей пользователя для spot-service: сначала claims личности,
+// затем Store (file или postgres, пусто - не используется) с кэшем на CacheTTL, затем Default
+type RolesConfig struct {
+	Store    string        `yaml:"store"`
+	FilePath string        `yaml:"file_path"`
+	CacheTTL time.Duration `yaml:"cache_ttl"`
+	Default  []string      `yaml:"default"`
+}
+
+// AdminConfig сервис администраторов OrderAdminService. Доступ только с ролью USER_ROLE_ADMIN
+type AdminConfig struct {
+	Enabled bool `yaml:"enabled"`
+}
+
 // RateLimitConfig конфигурация rate limiting
 type RateLimitConfig struct {
 	Enabled           bool                             `yaml:"enabled"`
@@ -50,6 +195,12 @@ type HealthConfig struct {
 	Enabled bool `mapstructure:"enabled"`
 }
 
+// MetricsConfig HTTP-сервер с метриками expvar на /debug/vars
+type MetricsConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Addr    string `yaml:"addr"`
+}
+
 // MethodRateLimitConfig лимит для конкретного метода
 type MethodRateLimitConfig struct {
 	RequestsPerSecond float64 `yaml:"requests_per_second"`
@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+const (
+	AuditActionListOrders         = "list_orders"
+	AuditActionGetOrder           = "get_order"
+	AuditActionForceTransition    = "force_transition"
+	AuditActionCancelMarketOrders = "cancel_market_orders"
+	AuditActionListAuditRecords   = "list_audit_records"
+
+	AuditOutcomeSuccess = "success"
+	AuditOutcomeDenied  = "denied"
+	AuditOutcomeFailed  = "failed"
+)
+
+// AuditRecord запись журнала действий администраторов
+type AuditRecord struct {
+	ID       string
+	Actor    string
+	Action   string
+	OrderID  string // пусто для действий не над одним заказом
+	MarketID string
+	Reason   string
+	Outcome  string
+	// Details подробности: целевой статус, фильтр, текст ошибки
+	Details string
+	At      time.Time
+}
@@ -5,11 +5,45 @@ import "errors"
 var (
 	ErrInvalidOrderType       = errors.New("invalid order type")
 	ErrInvalidOrderStatus     = errors.New("invalid order status")
+	ErrInvalidOrderSide       = errors.New("invalid order side")
+	ErrInvalidOrderEventType  = errors.New("invalid order event type")
 	ErrInvalidPrice           = errors.New("price must be positive")
 	ErrInvalidQuantity        = errors.New("quantity must be positive")
+	ErrPriceNotAllowed        = errors.New("price is not allowed for this order type")
+	ErrInvalidStopPrice       = errors.New("stop price must be positive for stop orders")
+	ErrStopPriceNotAllowed    = errors.New("stop price is allowed only for stop orders")
+	ErrStopLimitPrice         = errors.New("stop-limit price must not be worse than the stop price")
+	ErrInvalidTimeInForce     = errors.New("invalid time in force")
+	ErrTimeInForceNotAllowed  = errors.New("time in force is not allowed for this order type")
+	ErrInvalidExpiry          = errors.New("expiry time must be in the future for GTD orders")
+	ErrExpiryNotAllowed       = errors.New("expiry time is allowed only for GTD orders")
+	ErrPostOnlyNotAllowed     = errors.New("post-only is allowed only for limit orders that can rest in the book")
+	ErrReduceOnlyViolation    = errors.New("reduce-only order would increase or flip the position")
 	ErrMarketNotAvailable     = errors.New("market not found or not accessible")
+	ErrPriceTickSize          = errors.New("price does not match market tick size")
+	ErrQuantityStepSize       = errors.New("quantity does not match market step size")
+	ErrQuantityTooSmall       = errors.New("quantity is below market minimum")
+	ErrQuantityTooLarge       = errors.New("quantity is above market maximum")
+	ErrNotionalTooSmall       = errors.New("order value is below market minimum notional")
 	ErrOrderNotFound          = errors.New("order not found")
+	ErrOrderAlreadyExists     = errors.New("order already exists")
+	ErrVersionConflict        = errors.New("order was modified concurrently")
+	ErrDuplicateClientOrderID = errors.New("client order ID is already used by this user")
+	ErrIdempotencyKeyReused   = errors.New("client order ID was already used with different order parameters")
 	ErrAccessDenied           = errors.New("access denied")
+	ErrUnauthenticated        = errors.New("unauthenticated")
 	ErrOrderCannotBeCancelled = errors.New("order cannot be cancelled in current status")
 	ErrOrderAlreadyCancelled  = errors.New("order is already cancelled")
+	ErrOrderCannotBeAmended   = errors.New("order cannot be amended in current status")
+	ErrQuantityBelowFilled    = errors.New("quantity must be greater than filled quantity")
+	ErrNothingToAmend         = errors.New("amendment must change price or quantity")
+	ErrInvalidTransition      = errors.New("invalid order status transition")
+	ErrOrderNotTriggerable    = errors.New("order is not an untriggered stop order")
+	ErrOrderNotExpired        = errors.New("order is not an expired GTD order")
+	ErrInvalidFill            = errors.New("fill requires trade ID and positive price and quantity")
+	ErrFillExceedsRemaining   = errors.New("fill quantity exceeds remaining quantity")
+	ErrOrderNotFillable       = errors.New("order cannot be filled in current status")
+	ErrDuplicateFill          = errors.New("fill with this trade ID is already applied")
+	ErrInvalidPageToken       = errors.New("invalid page token")
+	ErrInvalidTimeRange       = errors.New("created_from must be before created_to")
 )
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// MarketRules торговые правила рынка. Нулевое значение поля означает отсутствие ограничения
+type MarketRules struct {
+	// TickSize шаг цены: цена должна быть кратна ему
+	TickSize decimal.Decimal
+	// StepSize шаг количества: количество должно быть кратно ему
+	StepSize    decimal.Decimal
+	MinQuantity decimal.Decimal
+	MaxQuantity decimal.Decimal
+	// MinNotional минимальная стоимость заказа, цена * количество
+	MinNotional decimal.Decimal
+}
+
+// Check проверяет цену и количество заказа по правилам рынка
+func (r MarketRules) Check(price, quantity decimal.Decimal) error {
+	if r.TickSize.IsPositive() && !price.Mod(r.TickSize).IsZero() {
+		return fmt.Errorf("%w: %s is not a multiple of %s", ErrPriceTickSize, price, r.TickSize)
+	}
+	if err := r.CheckQuantity(quantity); err != nil {
+		return err
+	}
+	if r.MinNotional.IsPositive() && price.Mul(quantity).LessThan(r.MinNotional) {
+		return fmt.Errorf("%w: %s < %s", ErrNotionalTooSmall, price.Mul(quantity), r.MinNotional)
+	}
+	return nil
+}
+
+// CheckOrder проверяет заказ по правилам рынка. Стоп-цена проверяется на шаг цены.
+// Стоимость заказа без лимитной цены оценивается по стоп-цене, у MARKET она неизвестна и не проверяется
+func (r MarketRules) CheckOrder(o *Order) error {
+	if o.StopPrice.IsPositive() && r.TickSize.IsPositive() && !o.StopPrice.Mod(r.TickSize).IsZero() {
+		return fmt.Errorf("%w: stop price %s is not a multiple of %s", ErrPriceTickSize, o.StopPrice, r.TickSize)
+	}
+
+	switch {
+	case o.Price.IsPositive():
+		return r.Check(o.Price, o.Quantity)
+	case o.StopPrice.IsPositive():
+		return r.Check(o.StopPrice, o.Quantity)
+	default:
+		return r.CheckQuantity(o.Quantity)
+	}
+}
+
+// CheckQuantity проверяет только количество: шаг и границы
+func (r MarketRules) CheckQuantity(quantity decimal.Decimal) error {
+	if r.StepSize.IsPositive() && !quantity.Mod(r.StepSize).IsZero() {
+		return fmt.Errorf("%w: %s is not a multiple of %s", ErrQuantityStepSize, quantity, r.StepSize)
+	}
+	if r.MinQuantity.IsPositive() && quantity.LessThan(r.MinQuantity) {
+		return fmt.Errorf("%w: %s < %s", ErrQuantityTooSmall, quantity, r.MinQuantity)
+	}
+	if r.MaxQuantity.IsPositive() && quantity.GreaterThan(r.MaxQuantity) {
+		return fmt.Errorf("%w: %s > %s", ErrQuantityTooLarge, quantity, r.MaxQuantity)
+	}
+	return nil
+}
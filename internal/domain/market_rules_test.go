@@ -0,0 +1,65 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/chilly266futon/orderService/internal/domain"
+)
+
+func dec(s string) decimal.Decimal {
+	if s == "" {
+		return decimal.Zero
+	}
+	return decimal.RequireFromString(s)
+}
+
+func TestCheckOrder(t *testing.T) {
+	rules := domain.MarketRules{
+		TickSize:    dec("0.01"),
+		StepSize:    dec("0.001"),
+		MinQuantity: dec("0.01"),
+		MaxQuantity: dec("100"),
+		MinNotional: dec("10"),
+	}
+
+	tests := []struct {
+		name      string
+		rules     domain.MarketRules
+		typ       domain.OrderType
+		price     string
+		stopPrice string
+		quantity  string
+		want      error
+	}{
+		{"limit within rules", rules, domain.OrderTypeLimit, "100.25", "", "0.5", nil},
+		{"price off tick", rules, domain.OrderTypeLimit, "100.255", "", "0.5", domain.ErrPriceTickSize},
+		{"quantity off step", rules, domain.OrderTypeLimit, "100", "", "0.5005", domain.ErrQuantityStepSize},
+		{"quantity below minimum", rules, domain.OrderTypeLimit, "10000", "", "0.005", domain.ErrQuantityTooSmall},
+		{"quantity at minimum", rules, domain.OrderTypeLimit, "1000", "", "0.01", nil},
+		{"quantity above maximum", rules, domain.OrderTypeLimit, "100", "", "100.001", domain.ErrQuantityTooLarge},
+		{"quantity at maximum", rules, domain.OrderTypeLimit, "100", "", "100", nil},
+		{"notional below minimum", rules, domain.OrderTypeLimit, "99.99", "", "0.1", domain.ErrNotionalTooSmall},
+		{"notional at minimum", rules, domain.OrderTypeLimit, "100", "", "0.1", nil},
+		{"market checks quantity only", rules, domain.OrderTypeMarket, "", "", "0.01", nil},
+		{"market quantity off step", rules, domain.OrderTypeMarket, "", "", "0.0105", domain.ErrQuantityStepSize},
+		{"stop price off tick", rules, domain.OrderTypeStopMarket, "", "100.005", "1", domain.ErrPriceTickSize},
+		{"stop market notional by stop price", rules, domain.OrderTypeStopMarket, "", "50", "0.1", domain.ErrNotionalTooSmall},
+		{"stop market within rules", rules, domain.OrderTypeStopMarket, "", "100", "0.1", nil},
+		{"stop limit notional by limit price", rules, domain.OrderTypeStopLimit, "100", "50", "0.1", nil},
+		{"stop limit stop price off tick", rules, domain.OrderTypeStopLimit, "100", "99.999", "0.1", domain.ErrPriceTickSize},
+		{"no rules", domain.MarketRules{}, domain.OrderTypeLimit, "0.000000001", "", "123456789.123456789", nil},
+		{"zero fields are unlimited", domain.MarketRules{TickSize: dec("0"), MinNotional: dec("0"), MaxQuantity: dec("0")}, domain.OrderTypeLimit, "0.001", "", "1000000", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &domain.Order{Type: tt.typ, Price: dec(tt.price), StopPrice: dec(tt.stopPrice), Quantity: dec(tt.quantity)}
+			if err := tt.rules.CheckOrder(o); !errors.Is(err, tt.want) {
+				t.Fatalf("CheckOrder() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -7,14 +7,99 @@ import (
 )
 
 type Order struct {
-	ID        string
-	UserID    string
-	MarketID  string
-	Type      OrderType
-	Status    OrderStatus
-	Price     decimal.Decimal
-	Quantity  decimal.Decimal
-	CreatedAt time.Time
+	ID string
+	// ClientOrderID необязательный ключ идемпотентности, уникален в пределах пользователя
+	ClientOrderID string
+	UserID        string
+	MarketID      string
+	Type          OrderType
+	Side          OrderSide
+	Status        OrderStatus
+	// Price лимитная цена, ноль для MARKET и STOP_MARKET
+	Price decimal.Decimal
+	// StopPrice цена активации стоп-заказа, ноль для остальных типов. TriggeredAt - момент активации,
+	// ноль, пока стоп-заказ не активирован; тип заказа при активации не меняется
+	StopPrice   decimal.Decimal
+	TriggeredAt time.Time
+	Quantity    decimal.Decimal
+	// SubmittedPrice и SubmittedQuantity цена и объем, с которыми заказ был создан. Запоминаются
+	// при первом изменении заказа; ноль SubmittedQuantity - заказ не изменялся, условия в Price и Quantity
+	SubmittedPrice    decimal.Decimal
+	SubmittedQuantity decimal.Decimal
+	// RequeuedAt момент последнего изменения, поставившего заказ в конец очереди; ноль - не ставилось
+	RequeuedAt time.Time
+	// TimeInForce срок действия, ExpiresAt - момент истечения для GTD, ноль для остальных
+	TimeInForce TimeInForce
+	ExpiresAt   time.Time
+	// PostOnly заказ только добавляет ликвидность: если он сразу исполнился бы, он отклоняется.
+	// ReduceOnly заказ только сокращает позицию пользователя на рынке
+	PostOnly   bool
+	ReduceOnly bool
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	// Version растет на единицу при каждом сохранении, используется для оптимистической блокировки
+	Version int64
+
+	// FilledQuantity исполненный объем, AvgFillPrice - средневзвешенная цена исполнений
+	FilledQuantity decimal.Decimal
+	AvgFillPrice   decimal.Decimal
+
+	pendingTransitions []OrderTransition
+	pendingFills       []Fill
+	pendingEvents      []OrderEvent
+}
+
+// Clone возвращает независимую копию заказа
+func (o *Order) Clone() *Order {
+	c := *o
+	c.pendingTransitions = append([]OrderTransition(nil), o.pendingTransitions...)
+	c.pendingFills = append([]Fill(nil), o.pendingFills...)
+	c.pendingEvents = append([]OrderEvent(nil), o.pendingEvents...)
+	return &c
+}
+
+// RemainingQuantity неисполненный остаток заказа
+func (o *Order) RemainingQuantity() decimal.Decimal {
+	return o.Quantity.Sub(o.FilledQuantity)
+}
+
+// SubmittedTerms цена и объем, с которыми заказ был создан, без учета последующих изменений
+func (o *Order) SubmittedTerms() (price, quantity decimal.Decimal) {
+	if o.SubmittedQuantity.IsZero() {
+		return o.Price, o.Quantity
+	}
+	return o.SubmittedPrice, o.SubmittedQuantity
+}
+
+// QueuedAt момент, с которого заказ занимает место в очереди книги: создание, активация стоп-заказа
+// или изменение цены либо увеличение объема, в зависимости от того, что было позже
+func (o *Order) QueuedAt() time.Time {
+	at := o.CreatedAt
+	for _, t := range []time.Time{o.TriggeredAt, o.RequeuedAt} {
+		if t.After(at) {
+			at = t
+		}
+	}
+	return at
+}
+
+// HasSameTerms сравнивает параметры, с которыми заказ был создан. Изменения цены и объема
+// после создания не учитываются, поэтому повтор исходного запроса совпадает и с измененным заказом
+func (o *Order) HasSameTerms(other *Order) bool {
+	price, quantity := o.SubmittedTerms()
+	otherPrice, otherQuantity := other.SubmittedTerms()
+
+	return o.UserID == other.UserID &&
+		o.MarketID == other.MarketID &&
+		o.Type == other.Type &&
+		o.Side == other.Side &&
+		price.Equal(otherPrice) &&
+		o.StopPrice.Equal(other.StopPrice) &&
+		quantity.Equal(otherQuantity) &&
+		o.TimeInForce == other.TimeInForce &&
+		o.ExpiresAt.Equal(other.ExpiresAt) &&
+		o.PostOnly == other.PostOnly &&
+		o.ReduceOnly == other.ReduceOnly
 }
 
 func (o *Order) IsOwnedBy(userID string) bool {
@@ -23,9 +108,9 @@ func (o *Order) IsOwnedBy(userID string) bool {
 
 func (o *Order) CanBeCancelled() error {
 	switch o.Status {
-	case OrderStatusCreated, OrderStatusOpen:
+	case OrderStatusCreated, OrderStatusOpen, OrderStatusPartiallyFilled:
 		return nil
-	case OrderStatusFilled, OrderStatusRejected:
+	case OrderStatusFilled, OrderStatusRejected, OrderStatusExpired:
 		return ErrOrderCannotBeCancelled
 	case OrderStatusCancelled:
 		return ErrOrderAlreadyCancelled
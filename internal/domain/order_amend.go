@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Amend меняет цену и/или объем незавершенного заказа; нулевое значение оставляет параметр без изменений.
+// Рыночные заказы исполняются сразу, а неактивированные стоп-заказы еще не в книге, поэтому их не изменить.
+// Объем не может стать меньше или равен исполненному. Изменение записывается в историю как переход
+// в тот же статус и публикуется событием ORDER_AMENDED. Новая цена или больший объем ставят заказ
+// в конец очереди, момент запоминается в RequeuedAt
+func (o *Order) Amend(price, quantity decimal.Decimal, actor string, at time.Time) error {
+	if !o.IsFillable() {
+		return fmt.Errorf("%w: %s", ErrOrderCannotBeAmended, o.Status)
+	}
+	if o.IsAwaitingTrigger() {
+		return fmt.Errorf("%w: %s awaiting trigger", ErrOrderCannotBeAmended, o.Type)
+	}
+	if o.ExecutionType() == OrderTypeMarket {
+		return fmt.Errorf("%w: %s", ErrOrderCannotBeAmended, o.Type)
+	}
+
+	var changes []string
+	if !price.IsZero() && !price.Equal(o.Price) {
+		if !o.Type.HasLimitPrice() {
+			return fmt.Errorf("%w: %s", ErrPriceNotAllowed, o.Type)
+		}
+		if !price.IsPositive() {
+			return ErrInvalidPrice
+		}
+		changes = append(changes, fmt.Sprintf("price %s -> %s", o.Price, price))
+	}
+	if !quantity.IsZero() && !quantity.Equal(o.Quantity) {
+		if !quantity.IsPositive() {
+			return ErrInvalidQuantity
+		}
+		if !quantity.GreaterThan(o.FilledQuantity) {
+			return fmt.Errorf("%w: filled %s", ErrQuantityBelowFilled, o.FilledQuantity)
+		}
+		changes = append(changes, fmt.Sprintf("quantity %s -> %s", o.Quantity, quantity))
+	}
+	if len(changes) == 0 {
+		return ErrNothingToAmend
+	}
+
+	if !price.IsZero() && !price.Equal(o.Price) || quantity.GreaterThan(o.Quantity) {
+		o.RequeuedAt = at
+	}
+	if o.SubmittedQuantity.IsZero() {
+		o.SubmittedPrice, o.SubmittedQuantity = o.Price, o.Quantity
+	}
+	if !price.IsZero() {
+		o.Price = price
+	}
+	if !quantity.IsZero() {
+		o.Quantity = quantity
+	}
+
+	reason := "amended: " + strings.Join(changes, ", ")
+	o.pendingTransitions = append(o.pendingTransitions, OrderTransition{
+		OrderID: o.ID,
+		From:    o.Status,
+		To:      o.Status,
+		Reason:  reason,
+		Actor:   actor,
+		At:      at,
+	})
+	o.UpdatedAt = at
+	o.recordEvent(OrderEventAmended, reason, nil, at)
+	return nil
+}
@@ -0,0 +1,174 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/chilly266futon/orderService/internal/domain"
+)
+
+func TestAmendRejectsOrdersOutsideBook(t *testing.T) {
+	triggered := time.Now()
+
+	tests := []struct {
+		name  string
+		order *domain.Order
+	}{
+		{
+			name:  "created market order",
+			order: &domain.Order{Type: domain.OrderTypeMarket, Status: domain.OrderStatusCreated},
+		},
+		{
+			name: "stop limit awaiting trigger",
+			order: &domain.Order{Type: domain.OrderTypeStopLimit, Status: domain.OrderStatusCreated,
+				Price: decimal.RequireFromString("100"), StopPrice: decimal.RequireFromString("99")},
+		},
+		{
+			name: "stop market awaiting trigger",
+			order: &domain.Order{Type: domain.OrderTypeStopMarket, Status: domain.OrderStatusCreated,
+				StopPrice: decimal.RequireFromString("99")},
+		},
+		{
+			name: "triggered stop market",
+			order: &domain.Order{Type: domain.OrderTypeStopMarket, Status: domain.OrderStatusOpen,
+				StopPrice: decimal.RequireFromString("99"), TriggeredAt: triggered},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.order.Side = domain.OrderSideBuy
+			tt.order.Quantity = decimal.RequireFromString("1")
+
+			err := tt.order.Amend(decimal.Zero, decimal.RequireFromString("2"), domain.ActorSystem, time.Now())
+			if !errors.Is(err, domain.ErrOrderCannotBeAmended) {
+				t.Fatalf("Amend() error = %v, want %v", err, domain.ErrOrderCannotBeAmended)
+			}
+			if !tt.order.Quantity.Equal(decimal.RequireFromString("1")) {
+				t.Fatalf("quantity = %s after rejected amend", tt.order.Quantity)
+			}
+		})
+	}
+}
+
+func TestAmendTriggeredStopLimit(t *testing.T) {
+	o := &domain.Order{
+		Type:        domain.OrderTypeStopLimit,
+		Side:        domain.OrderSideBuy,
+		Status:      domain.OrderStatusOpen,
+		Price:       decimal.RequireFromString("100"),
+		StopPrice:   decimal.RequireFromString("99"),
+		Quantity:    decimal.RequireFromString("1"),
+		TriggeredAt: time.Now(),
+	}
+
+	if err := o.Amend(decimal.RequireFromString("101"), decimal.Zero, domain.ActorSystem, time.Now()); err != nil {
+		t.Fatalf("Amend() error = %v", err)
+	}
+}
+
+func TestAmendKeepsSubmittedTerms(t *testing.T) {
+	submitted := &domain.Order{
+		UserID:      "user-1",
+		MarketID:    "market-1",
+		Type:        domain.OrderTypeLimit,
+		Side:        domain.OrderSideBuy,
+		Status:      domain.OrderStatusOpen,
+		Price:       decimal.RequireFromString("100"),
+		Quantity:    decimal.RequireFromString("1"),
+		TimeInForce: domain.TimeInForceGTC,
+	}
+	o := submitted.Clone()
+
+	if err := o.Amend(decimal.RequireFromString("101"), decimal.Zero, domain.ActorSystem, time.Now()); err != nil {
+		t.Fatalf("Amend(price) error = %v", err)
+	}
+	if err := o.Amend(decimal.Zero, decimal.RequireFromString("3"), domain.ActorSystem, time.Now()); err != nil {
+		t.Fatalf("Amend(quantity) error = %v", err)
+	}
+
+	price, quantity := o.SubmittedTerms()
+	if !price.Equal(submitted.Price) || !quantity.Equal(submitted.Quantity) {
+		t.Fatalf("SubmittedTerms() = %s, %s, want %s, %s", price, quantity, submitted.Price, submitted.Quantity)
+	}
+	if !o.HasSameTerms(submitted) {
+		t.Fatal("HasSameTerms() = false for the original request after amend")
+	}
+
+	amended := submitted.Clone()
+	amended.Price = o.Price
+	amended.Quantity = o.Quantity
+	if o.HasSameTerms(amended) {
+		t.Fatal("HasSameTerms() = true for a request with the amended terms")
+	}
+}
+
+func TestAmendRequeuesOnPriceChangeOrQuantityIncrease(t *testing.T) {
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	amendedAt := created.Add(time.Minute)
+
+	tests := []struct {
+		name     string
+		price    string
+		quantity string
+		requeued bool
+	}{
+		{name: "new price", price: "101", quantity: "0", requeued: true},
+		{name: "larger quantity", price: "0", quantity: "3", requeued: true},
+		{name: "smaller quantity", price: "0", quantity: "1", requeued: false},
+		{name: "same price smaller quantity", price: "100", quantity: "1", requeued: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &domain.Order{
+				Type:      domain.OrderTypeLimit,
+				Side:      domain.OrderSideBuy,
+				Status:    domain.OrderStatusOpen,
+				Price:     decimal.RequireFromString("100"),
+				Quantity:  decimal.RequireFromString("2"),
+				CreatedAt: created,
+			}
+
+			err := o.Amend(decimal.RequireFromString(tt.price), decimal.RequireFromString(tt.quantity), domain.ActorSystem, amendedAt)
+			if err != nil {
+				t.Fatalf("Amend() error = %v", err)
+			}
+
+			want := created
+			if tt.requeued {
+				want = amendedAt
+			}
+			if !o.QueuedAt().Equal(want) {
+				t.Fatalf("QueuedAt() = %v, want %v", o.QueuedAt(), want)
+			}
+		})
+	}
+}
+
+func TestQueuedAt(t *testing.T) {
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	triggered := created.Add(time.Minute)
+	requeued := created.Add(2 * time.Minute)
+
+	tests := []struct {
+		name  string
+		order domain.Order
+		want  time.Time
+	}{
+		{name: "created", order: domain.Order{CreatedAt: created}, want: created},
+		{name: "triggered", order: domain.Order{CreatedAt: created, TriggeredAt: triggered}, want: triggered},
+		{name: "requeued after trigger", order: domain.Order{CreatedAt: created, TriggeredAt: triggered, RequeuedAt: requeued}, want: requeued},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.order.QueuedAt(); !got.Equal(tt.want) {
+				t.Fatalf("QueuedAt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type OrderEventType uint8
+
+const (
+	OrderEventUnspecified = iota
+	OrderEventCreated
+	OrderEventCancelled
+	OrderEventFilled
+	OrderEventRejected
+	OrderEventStatusChanged
+	OrderEventAmended
+)
+
+func (t OrderEventType) String() string {
+	switch t {
+	case OrderEventCreated:
+		return "ORDER_CREATED"
+	case OrderEventCancelled:
+		return "ORDER_CANCELLED"
+	case OrderEventFilled:
+		return "ORDER_FILLED"
+	case OrderEventRejected:
+		return "ORDER_REJECTED"
+	case OrderEventStatusChanged:
+		return "ORDER_STATUS_CHANGED"
+	case OrderEventAmended:
+		return "ORDER_AMENDED"
+	default:
+		return "UNSPECIFIED"
+	}
+}
+
+func ParseOrderEventType(s string) (OrderEventType, error) {
+	switch s {
+	case "ORDER_CREATED":
+		return OrderEventCreated, nil
+	case "ORDER_CANCELLED":
+		return OrderEventCancelled, nil
+	case "ORDER_FILLED":
+		return OrderEventFilled, nil
+	case "ORDER_REJECTED":
+		return OrderEventRejected, nil
+	case "ORDER_STATUS_CHANGED":
+		return OrderEventStatusChanged, nil
+	case "ORDER_AMENDED":
+		return OrderEventAmended, nil
+	default:
+		return OrderEventUnspecified, ErrInvalidOrderEventType
+	}
+}
+
+// OrderEvent доменное событие заказа. Содержит снимок состояния заказа после события.
+// Sequence назначает хранилище при записи: он растет монотонно для всех событий
+type OrderEvent struct {
+	Sequence int64
+	ID       string
+	Type     OrderEventType
+
+	OrderID        string
+	UserID         string
+	MarketID       string
+	Status         OrderStatus
+	FilledQuantity decimal.Decimal
+	Reason         string
+	// Fill заполнен только для OrderEventFilled
+	Fill *Fill
+
+	OccurredAt time.Time
+}
+
+// eventTypeForStatus тип события для перехода в статус
+func eventTypeForStatus(s OrderStatus) OrderEventType {
+	switch s {
+	case OrderStatusCreated:
+		return OrderEventCreated
+	case OrderStatusCancelled:
+		return OrderEventCancelled
+	case OrderStatusRejected:
+		return OrderEventRejected
+	default:
+		return OrderEventStatusChanged
+	}
+}
+
+// recordEvent запоминает событие со снимком текущего состояния заказа
+func (o *Order) recordEvent(t OrderEventType, reason string, fill *Fill, at time.Time) {
+	o.pendingEvents = append(o.pendingEvents, OrderEvent{
+		ID:             uuid.NewString(),
+		Type:           t,
+		OrderID:        o.ID,
+		UserID:         o.UserID,
+		MarketID:       o.MarketID,
+		Status:         o.Status,
+		FilledQuantity: o.FilledQuantity,
+		Reason:         reason,
+		Fill:           fill,
+		OccurredAt:     at,
+	})
+}
+
+// PendingEvents события, ещё не записанные в outbox
+func (o *Order) PendingEvents() []OrderEvent {
+	return o.pendingEvents
+}
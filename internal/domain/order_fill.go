@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Fill исполнение части заказа в сделке. TradeID уникален в пределах заказа
+type Fill struct {
+	OrderID  string
+	TradeID  string
+	Price    decimal.Decimal
+	Quantity decimal.Decimal
+	At       time.Time
+}
+
+// ApplyFill учитывает исполнение: увеличивает исполненный объем, пересчитывает среднюю цену
+// и переводит заказ в PARTIALLY_FILLED или FILLED.
+// Повтор сделки внутри ещё не сохраненных изменений возвращает ErrDuplicateFill,
+// повтор уже сохраненной сделки отклоняет хранилище
+func (o *Order) ApplyFill(fill Fill, actor string) error {
+	if fill.TradeID == "" || !fill.Price.IsPositive() || !fill.Quantity.IsPositive() {
+		return ErrInvalidFill
+	}
+	for _, pending := range o.pendingFills {
+		if pending.TradeID == fill.TradeID {
+			return ErrDuplicateFill
+		}
+	}
+
+	if !o.IsFillable() {
+		return ErrOrderNotFillable
+	}
+
+	if fill.Quantity.GreaterThan(o.RemainingQuantity()) {
+		return ErrFillExceedsRemaining
+	}
+
+	filled := o.FilledQuantity.Add(fill.Quantity)
+	o.AvgFillPrice = o.AvgFillPrice.Mul(o.FilledQuantity).
+		Add(fill.Price.Mul(fill.Quantity)).
+		Div(filled)
+	o.FilledQuantity = filled
+
+	fill.OrderID = o.ID
+	o.pendingFills = append(o.pendingFills, fill)
+	o.UpdatedAt = fill.At
+
+	next := OrderStatus(OrderStatusPartiallyFilled)
+	if o.RemainingQuantity().IsZero() {
+		next = OrderStatusFilled
+	}
+	if next != o.Status {
+		if err := o.TransitionTo(next, "trade "+fill.TradeID, actor, fill.At); err != nil {
+			return err
+		}
+	}
+
+	o.recordEvent(OrderEventFilled, "trade "+fill.TradeID, &fill, fill.At)
+	return nil
+}
+
+// IsFillable заказ может участвовать в сделках
+func (o *Order) IsFillable() bool {
+	switch o.Status {
+	case OrderStatusCreated, OrderStatusOpen, OrderStatusPartiallyFilled:
+		return true
+	default:
+		return false
+	}
+}
+
+// PendingFills исполнения, ещё не сохраненные в хранилище
+func (o *Order) PendingFills() []Fill {
+	return o.pendingFills
+}
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// ValidateExecutionFlags проверяет флаги исполнения для типа и срока действия заказа.
+// Post-only заказ должен встать в книгу, поэтому допустим только с лимитной ценой и не с IOC или FOK
+func (t OrderType) ValidateExecutionFlags(postOnly, reduceOnly bool, tif TimeInForce) error {
+	if !postOnly {
+		return nil
+	}
+	if !t.HasLimitPrice() {
+		return fmt.Errorf("%w: %s", ErrPostOnlyNotAllowed, t)
+	}
+	if tif.IsImmediate() {
+		return fmt.Errorf("%w: %s", ErrPostOnlyNotAllowed, tif)
+	}
+	return nil
+}
+
+// Position позиция пользователя на рынке, собранная по его заказам
+type Position struct {
+	// Net чистый исполненный объем, положительный для покупок
+	Net decimal.Decimal
+	// reserved неисполненный остаток незавершенных reduce-only заказов по сторонам
+	reserved map[OrderSide]decimal.Decimal
+}
+
+// Add учитывает заказ: его исполнения и, для незавершенного reduce-only заказа, неисполненный остаток
+func (p *Position) Add(o *Order) {
+	p.Net = p.Net.Add(o.SignedFilledQuantity())
+	if !o.ReduceOnly || o.Status.IsFinal() {
+		return
+	}
+	if p.reserved == nil {
+		p.reserved = make(map[OrderSide]decimal.Decimal)
+	}
+	p.reserved[o.Side] = p.reserved[o.Side].Add(o.RemainingQuantity())
+}
+
+// Reserved неисполненный остаток незавершенных reduce-only заказов стороны side
+func (p Position) Reserved(side OrderSide) decimal.Decimal {
+	return p.reserved[side]
+}
+
+// CheckReduceOnly проверяет, что reduce-only заказ только сокращает позицию пользователя на рынке.
+// position собрана без самого заказа, кроме его исполнений. Заказ должен быть встречным позиции,
+// а его остаток вместе с остатками других reduce-only заказов той же стороны - не больше нее
+func (o *Order) CheckReduceOnly(position Position) error {
+	if !o.ReduceOnly {
+		return nil
+	}
+
+	reserved := position.Reserved(o.Side)
+	if !o.reduces(position.Net) || o.RemainingQuantity().Add(reserved).GreaterThan(position.Net.Abs()) {
+		return fmt.Errorf("%w: position %s, reserved %s, %s %s",
+			ErrReduceOnlyViolation, position.Net, reserved, o.Side, o.RemainingQuantity())
+	}
+	return nil
+}
+
+// ReducibleQuantity сколько из остатка заказа можно исполнить при чистой позиции net.
+// Reduce-only заказ не исполняется сверх позиции и не исполняется вовсе, если он ее не сокращает
+func (o *Order) ReducibleQuantity(net decimal.Decimal) decimal.Decimal {
+	remaining := o.RemainingQuantity()
+	if !o.ReduceOnly {
+		return remaining
+	}
+	if !o.reduces(net) {
+		return decimal.Zero
+	}
+	return decimal.Min(remaining, net.Abs())
+}
+
+func (o *Order) reduces(net decimal.Decimal) bool {
+	return (o.Side == OrderSideSell && net.IsPositive()) ||
+		(o.Side == OrderSideBuy && net.IsNegative())
+}
+
+// SignedFilledQuantity исполненный объем со знаком стороны: покупки положительны, продажи отрицательны
+func (o *Order) SignedFilledQuantity() decimal.Decimal {
+	if o.Side == OrderSideSell {
+		return o.FilledQuantity.Neg()
+	}
+	return o.FilledQuantity
+}
@@ -0,0 +1,114 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/chilly266futon/orderService/internal/domain"
+)
+
+func reduceOnlyOrder(side domain.OrderSide, status domain.OrderStatus, quantity, filled string) *domain.Order {
+	return &domain.Order{
+		Side:           side,
+		Status:         status,
+		Quantity:       decimal.RequireFromString(quantity),
+		FilledQuantity: decimal.RequireFromString(filled),
+		ReduceOnly:     true,
+	}
+}
+
+func TestCheckReduceOnly(t *testing.T) {
+	long := func(others ...*domain.Order) domain.Position {
+		var p domain.Position
+		p.Add(&domain.Order{Side: domain.OrderSideBuy, Status: domain.OrderStatusFilled,
+			Quantity: decimal.RequireFromString("10"), FilledQuantity: decimal.RequireFromString("10")})
+		for _, o := range others {
+			p.Add(o)
+		}
+		return p
+	}
+
+	tests := []struct {
+		name     string
+		order    *domain.Order
+		position domain.Position
+		wantErr  bool
+	}{
+		{
+			name:     "within position",
+			order:    reduceOnlyOrder(domain.OrderSideSell, domain.OrderStatusUnspecified, "10", "0"),
+			position: long(),
+		},
+		{
+			name:     "same side as position",
+			order:    reduceOnlyOrder(domain.OrderSideBuy, domain.OrderStatusUnspecified, "1", "0"),
+			position: long(),
+			wantErr:  true,
+		},
+		{
+			name:     "exceeds position",
+			order:    reduceOnlyOrder(domain.OrderSideSell, domain.OrderStatusUnspecified, "11", "0"),
+			position: long(),
+			wantErr:  true,
+		},
+		{
+			name:  "other open reduce-only orders reserve the position",
+			order: reduceOnlyOrder(domain.OrderSideSell, domain.OrderStatusUnspecified, "4", "0"),
+			position: long(
+				reduceOnlyOrder(domain.OrderSideSell, domain.OrderStatusOpen, "5", "0"),
+				reduceOnlyOrder(domain.OrderSideSell, domain.OrderStatusPartiallyFilled, "4", "2"),
+			),
+			wantErr: true,
+		},
+		{
+			name:  "fits next to other reduce-only orders",
+			order: reduceOnlyOrder(domain.OrderSideSell, domain.OrderStatusUnspecified, "1", "0"),
+			position: long(
+				reduceOnlyOrder(domain.OrderSideSell, domain.OrderStatusOpen, "5", "0"),
+				reduceOnlyOrder(domain.OrderSideSell, domain.OrderStatusPartiallyFilled, "4", "2"),
+			),
+		},
+		{
+			name:  "finished reduce-only orders reserve nothing",
+			order: reduceOnlyOrder(domain.OrderSideSell, domain.OrderStatusUnspecified, "10", "0"),
+			position: long(
+				reduceOnlyOrder(domain.OrderSideSell, domain.OrderStatusCancelled, "5", "0"),
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.order.CheckReduceOnly(tt.position)
+			if tt.wantErr != errors.Is(err, domain.ErrReduceOnlyViolation) {
+				t.Fatalf("CheckReduceOnly() error = %v, want violation %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReducibleQuantity(t *testing.T) {
+	tests := []struct {
+		name  string
+		order *domain.Order
+		net   string
+		want  string
+	}{
+		{"regular order", &domain.Order{Side: domain.OrderSideBuy, Quantity: decimal.RequireFromString("5")}, "3", "5"},
+		{"within position", reduceOnlyOrder(domain.OrderSideSell, domain.OrderStatusOpen, "5", "1"), "10", "4"},
+		{"capped by position", reduceOnlyOrder(domain.OrderSideSell, domain.OrderStatusOpen, "5", "0"), "2", "2"},
+		{"position closed", reduceOnlyOrder(domain.OrderSideSell, domain.OrderStatusOpen, "5", "0"), "0", "0"},
+		{"position flipped", reduceOnlyOrder(domain.OrderSideSell, domain.OrderStatusOpen, "5", "0"), "-1", "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.order.ReducibleQuantity(decimal.RequireFromString(tt.net))
+			if !got.Equal(decimal.RequireFromString(tt.want)) {
+				t.Fatalf("ReducibleQuantity(%s) = %s, want %s", tt.net, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// ActorSystem инициатор переходов, выполняемых самим сервисом
+	ActorSystem = "system"
+
+	actorUserPrefix  = "user:"
+	actorAdminPrefix = "admin:"
+)
+
+// UserActor инициатор перехода - пользователь, владелец заказа
+func UserActor(userID string) string {
+	return actorUserPrefix + userID
+}
+
+// AdminActor инициатор перехода - администратор
+func AdminActor(userID string) string {
+	return actorAdminPrefix + userID
+}
+
+// OrderTransition запись истории заказа: смена статуса или, при From == To, изменение параметров заказа
+type OrderTransition struct {
+	OrderID string
+	From    OrderStatus
+	To      OrderStatus
+	Reason  string
+	Actor   string
+	At      time.Time
+}
+
+// orderTransitions допустимые переходы между статусами.
+// Из UNSPECIFIED можно перейти только в CREATED - это создание заказа
+var orderTransitions = map[OrderStatus][]OrderStatus{
+	OrderStatusUnspecified: {OrderStatusCreated},
+	OrderStatusCreated: {
+		OrderStatusOpen,
+		OrderStatusPartiallyFilled,
+		OrderStatusFilled,
+		OrderStatusCancelled,
+		OrderStatusRejected,
+		OrderStatusExpired,
+	},
+	OrderStatusOpen:            {OrderStatusPartiallyFilled, OrderStatusFilled, OrderStatusCancelled, OrderStatusExpired},
+	OrderStatusPartiallyFilled: {OrderStatusFilled, OrderStatusCancelled, OrderStatusExpired},
+}
+
+// forcedTargets статусы, в которые администратор может перевести заказ в обход orderTransitions.
+// Только завершающие: статусы исполнения выставляются через ApplyFill, чтобы не нарушить FilledQuantity,
+// а OPEN оставил бы заказ вне книги сопоставления и стоп-заказов
+var forcedTargets = []OrderStatus{OrderStatusCancelled, OrderStatusRejected}
+
+// CanTransition проверяет, разрешен ли переход from -> to
+func CanTransition(from, to OrderStatus) bool {
+	for _, allowed := range orderTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// IsFinal сообщает, что из статуса нет переходов
+func (s OrderStatus) IsFinal() bool {
+	return len(orderTransitions[s]) == 0
+}
+
+// TransitionTo переводит заказ в статус to и запоминает переход для записи в историю
+func (o *Order) TransitionTo(to OrderStatus, reason, actor string, at time.Time) error {
+	if !CanTransition(o.Status, to) {
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, o.Status, to)
+	}
+
+	o.transition(to, reason, actor, at)
+	return nil
+}
+
+// ForceTransitionTo переводит незавершенный заказ в CANCELLED или REJECTED в обход таблицы переходов.
+// Используется администраторами для зависших заказов; переход записывается в историю как обычный
+func (o *Order) ForceTransitionTo(to OrderStatus, reason, actor string, at time.Time) error {
+	forced := false
+	for _, target := range forcedTargets {
+		if target == to {
+			forced = true
+		}
+	}
+	if !forced || o.Status == to || o.Status == OrderStatusUnspecified || o.Status.IsFinal() {
+		return fmt.Errorf("%w: forced %s -> %s", ErrInvalidTransition, o.Status, to)
+	}
+
+	o.transition(to, reason, actor, at)
+	return nil
+}
+
+func (o *Order) transition(to OrderStatus, reason, actor string, at time.Time) {
+	o.pendingTransitions = append(o.pendingTransitions, OrderTransition{
+		OrderID: o.ID,
+		From:    o.Status,
+		To:      to,
+		Reason:  reason,
+		Actor:   actor,
+		At:      at,
+	})
+	o.Status = to
+	o.UpdatedAt = at
+	o.recordEvent(eventTypeForStatus(to), reason, nil, at)
+}
+
+// PendingTransitions переходы, ещё не сохраненные в хранилище
+func (o *Order) PendingTransitions() []OrderTransition {
+	return o.pendingTransitions
+}
+
+// MarkPersisted сбрасывает несохраненные изменения. Вызывается хранилищем после успешной записи
+func (o *Order) MarkPersisted() {
+	o.pendingTransitions = nil
+	o.pendingFills = nil
+	o.pendingEvents = nil
+}
@@ -0,0 +1,51 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/chilly266futon/orderService/internal/domain"
+)
+
+func TestForceTransitionTo(t *testing.T) {
+	tests := []struct {
+		name string
+		from domain.OrderStatus
+		to   domain.OrderStatus
+		ok   bool
+	}{
+		{"created to cancelled", domain.OrderStatusCreated, domain.OrderStatusCancelled, true},
+		{"open to rejected", domain.OrderStatusOpen, domain.OrderStatusRejected, true},
+		{"partially filled to cancelled", domain.OrderStatusPartiallyFilled, domain.OrderStatusCancelled, true},
+		{"created to open", domain.OrderStatusCreated, domain.OrderStatusOpen, false},
+		{"partially filled to open", domain.OrderStatusPartiallyFilled, domain.OrderStatusOpen, false},
+		{"open to filled", domain.OrderStatusOpen, domain.OrderStatusFilled, false},
+		{"final status", domain.OrderStatusFilled, domain.OrderStatusCancelled, false},
+		{"same status", domain.OrderStatusCancelled, domain.OrderStatusCancelled, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &domain.Order{ID: "order-1", Status: tt.from}
+
+			err := o.ForceTransitionTo(tt.to, "stuck", domain.AdminActor("admin-1"), time.Now())
+			if !tt.ok {
+				if !errors.Is(err, domain.ErrInvalidTransition) {
+					t.Fatalf("ForceTransitionTo() error = %v, want %v", err, domain.ErrInvalidTransition)
+				}
+				if o.Status != tt.from || len(o.PendingTransitions()) != 0 {
+					t.Fatalf("rejected transition changed order: %s, %d pending", o.Status, len(o.PendingTransitions()))
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ForceTransitionTo() error = %v", err)
+			}
+			if o.Status != tt.to || len(o.PendingTransitions()) != 1 {
+				t.Fatalf("order = %s with %d pending transitions, want %s with 1", o.Status, len(o.PendingTransitions()), tt.to)
+			}
+		})
+	}
+}
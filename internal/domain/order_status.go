@@ -9,6 +9,8 @@ const (
 	OrderStatusFilled
 	OrderStatusCancelled
 	OrderStatusRejected
+	OrderStatusPartiallyFilled
+	OrderStatusExpired
 )
 
 func (s OrderStatus) String() string {
@@ -23,6 +25,10 @@ func (s OrderStatus) String() string {
 		return "CANCELLED"
 	case OrderStatusRejected:
 		return "REJECTED"
+	case OrderStatusPartiallyFilled:
+		return "PARTIALLY_FILLED"
+	case OrderStatusExpired:
+		return "EXPIRED"
 	default:
 		return "UNSPECIFIED"
 	}
@@ -40,6 +46,10 @@ func ParseOrderStatus(s string) (OrderStatus, error) {
 		return OrderStatusCancelled, nil
 	case "REJECTED":
 		return OrderStatusRejected, nil
+	case "PARTIALLY_FILLED":
+		return OrderStatusPartiallyFilled, nil
+	case "EXPIRED":
+		return OrderStatusExpired, nil
 	default:
 		return OrderStatusUnspecified, ErrInvalidOrderStatus
 	}
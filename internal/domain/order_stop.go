@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// IsAwaitingTrigger стоп-заказ, еще не активированный рыночной ценой
+func (o *Order) IsAwaitingTrigger() bool {
+	return o.Type.IsStop() && o.TriggeredAt.IsZero() && o.Status == OrderStatusCreated
+}
+
+// IsTriggered стоп-заказ, активированный рыночной ценой
+func (o *Order) IsTriggered() bool {
+	return o.Type.IsStop() && !o.TriggeredAt.IsZero()
+}
+
+// ExecutionType тип, по которому заказ исполняется: активированный STOP_LIMIT - как LIMIT,
+// STOP_MARKET - как MARKET. Неактивированный стоп-заказ возвращает свой тип и не исполняется
+func (o *Order) ExecutionType() OrderType {
+	if !o.IsTriggered() {
+		return o.Type
+	}
+	if o.Type == OrderTypeStopLimit {
+		return OrderTypeLimit
+	}
+	return OrderTypeMarket
+}
+
+// ShouldTrigger проверяет, активирует ли цена последней сделки стоп-заказ:
+// покупка срабатывает при росте цены до StopPrice, продажа - при падении до нее
+func (o *Order) ShouldTrigger(lastPrice decimal.Decimal) bool {
+	if !o.IsAwaitingTrigger() {
+		return false
+	}
+	switch o.Side {
+	case OrderSideBuy:
+		return lastPrice.GreaterThanOrEqual(o.StopPrice)
+	case OrderSideSell:
+		return lastPrice.LessThanOrEqual(o.StopPrice)
+	default:
+		return false
+	}
+}
+
+// Trigger активирует стоп-заказ: запоминает момент активации и переводит заказ в OPEN.
+// Тип остается исходным, исполняется заказ по ExecutionType
+func (o *Order) Trigger(lastPrice decimal.Decimal, at time.Time) error {
+	if !o.IsAwaitingTrigger() {
+		return fmt.Errorf("%w: %s in %s", ErrOrderNotTriggerable, o.Type, o.Status)
+	}
+
+	reason := fmt.Sprintf("stop price %s reached at %s", o.StopPrice, lastPrice)
+	if err := o.TransitionTo(OrderStatusOpen, reason, ActorSystem, at); err != nil {
+		return err
+	}
+	o.TriggeredAt = at
+	return nil
+}
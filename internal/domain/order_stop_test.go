@@ -0,0 +1,66 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/chilly266futon/orderService/internal/domain"
+)
+
+func TestTriggerKeepsSubmittedType(t *testing.T) {
+	tests := []struct {
+		typ  domain.OrderType
+		exec domain.OrderType
+	}{
+		{domain.OrderTypeStopLimit, domain.OrderTypeLimit},
+		{domain.OrderTypeStopMarket, domain.OrderTypeMarket},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.typ.String(), func(t *testing.T) {
+			o := &domain.Order{
+				ID:        "order-1",
+				Type:      tt.typ,
+				Side:      domain.OrderSideBuy,
+				Status:    domain.OrderStatusCreated,
+				StopPrice: decimal.RequireFromString("100"),
+				Quantity:  decimal.RequireFromString("1"),
+			}
+			if tt.typ.HasLimitPrice() {
+				o.Price = decimal.RequireFromString("101")
+			}
+			submitted := o.Clone()
+
+			if o.ExecutionType() != tt.typ {
+				t.Fatalf("ExecutionType() before trigger = %s, want %s", o.ExecutionType(), tt.typ)
+			}
+
+			lastPrice := decimal.RequireFromString("100.5")
+			if !o.ShouldTrigger(lastPrice) {
+				t.Fatal("ShouldTrigger() = false, want true")
+			}
+			at := time.Now()
+			if err := o.Trigger(lastPrice, at); err != nil {
+				t.Fatalf("Trigger() error = %v", err)
+			}
+
+			if o.Type != tt.typ || o.Status != domain.OrderStatusOpen || !o.TriggeredAt.Equal(at) {
+				t.Fatalf("triggered order = %s %s at %v, want %s OPEN at %v", o.Type, o.Status, o.TriggeredAt, tt.typ, at)
+			}
+			if o.ExecutionType() != tt.exec {
+				t.Fatalf("ExecutionType() = %s, want %s", o.ExecutionType(), tt.exec)
+			}
+			// повтор CreateOrder с тем же ClientOrderID сравнивает исходные параметры
+			if !o.HasSameTerms(submitted) {
+				t.Fatal("HasSameTerms() = false after trigger")
+			}
+
+			if err := o.Trigger(lastPrice, at); !errors.Is(err, domain.ErrOrderNotTriggerable) {
+				t.Fatalf("second Trigger() error = %v, want %v", err, domain.ErrOrderNotTriggerable)
+			}
+		})
+	}
+}
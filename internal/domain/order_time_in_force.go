@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// TimeInForce срок действия заказа
+type TimeInForce uint8
+
+const (
+	TimeInForceUnspecified = iota
+	// TimeInForceGTC действует до отмены
+	TimeInForceGTC
+	// TimeInForceIOC исполняется сразу в доступном объеме, остаток отменяется
+	TimeInForceIOC
+	// TimeInForceFOK исполняется сразу целиком или отменяется без исполнения
+	TimeInForceFOK
+	// TimeInForceGTD действует до ExpiresAt
+	TimeInForceGTD
+)
+
+func (t TimeInForce) String() string {
+	switch t {
+	case TimeInForceGTC:
+		return "GTC"
+	case TimeInForceIOC:
+		return "IOC"
+	case TimeInForceFOK:
+		return "FOK"
+	case TimeInForceGTD:
+		return "GTD"
+	default:
+		return "UNSPECIFIED"
+	}
+}
+
+func ParseTimeInForce(s string) (TimeInForce, error) {
+	switch s {
+	case "GTC":
+		return TimeInForceGTC, nil
+	case "IOC":
+		return TimeInForceIOC, nil
+	case "FOK":
+		return TimeInForceFOK, nil
+	case "GTD":
+		return TimeInForceGTD, nil
+	default:
+		return TimeInForceUnspecified, ErrInvalidTimeInForce
+	}
+}
+
+// IsImmediate заказ не остается в книге: неисполненный сразу остаток отменяется
+func (t TimeInForce) IsImmediate() bool {
+	return t == TimeInForceIOC || t == TimeInForceFOK
+}
+
+// DefaultTimeInForce срок действия, если он не указан: GTC для лимитных заказов, IOC для рыночных
+func (t OrderType) DefaultTimeInForce() TimeInForce {
+	if t.HasLimitPrice() {
+		return TimeInForceGTC
+	}
+	return TimeInForceIOC
+}
+
+// ValidateTimeInForce проверяет срок действия для типа заказа: рыночные заказы только IOC или FOK,
+// GTD требует ExpiresAt позже now, для остальных ExpiresAt не указывается
+func (t OrderType) ValidateTimeInForce(tif TimeInForce, expiresAt, now time.Time) error {
+	if tif == TimeInForceUnspecified || tif > TimeInForceGTD {
+		return ErrInvalidTimeInForce
+	}
+	if !t.HasLimitPrice() && !tif.IsImmediate() {
+		return fmt.Errorf("%w: %s for %s", ErrTimeInForceNotAllowed, tif, t)
+	}
+
+	if tif == TimeInForceGTD {
+		if !expiresAt.After(now) {
+			return ErrInvalidExpiry
+		}
+	} else if !expiresAt.IsZero() {
+		return fmt.Errorf("%w: %s", ErrExpiryNotAllowed, tif)
+	}
+	return nil
+}
+
+// IsExpired незавершенный GTD заказ, срок действия которого истек к моменту now
+func (o *Order) IsExpired(now time.Time) bool {
+	return o.TimeInForce == TimeInForceGTD && !o.Status.IsFinal() && !o.ExpiresAt.After(now)
+}
+
+// Expire переводит заказ с истекшим сроком действия в EXPIRED
+func (o *Order) Expire(at time.Time) error {
+	if !o.IsExpired(at) {
+		return fmt.Errorf("%w: %s in %s", ErrOrderNotExpired, o.TimeInForce, o.Status)
+	}
+
+	reason := fmt.Sprintf("good till %s expired", o.ExpiresAt.UTC().Format(time.RFC3339))
+	return o.TransitionTo(OrderStatusExpired, reason, ActorSystem, at)
+}
@@ -0,0 +1,114 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/chilly266futon/orderService/internal/domain"
+)
+
+func TestValidateTimeInForce(t *testing.T) {
+	now := time.Now()
+	future := now.Add(time.Hour)
+
+	tests := []struct {
+		name      string
+		typ       domain.OrderType
+		tif       domain.TimeInForce
+		expiresAt time.Time
+		want      error
+	}{
+		{"limit GTC", domain.OrderTypeLimit, domain.TimeInForceGTC, time.Time{}, nil},
+		{"limit IOC", domain.OrderTypeLimit, domain.TimeInForceIOC, time.Time{}, nil},
+		{"stop limit FOK", domain.OrderTypeStopLimit, domain.TimeInForceFOK, time.Time{}, nil},
+		{"limit GTD", domain.OrderTypeLimit, domain.TimeInForceGTD, future, nil},
+		{"unspecified", domain.OrderTypeLimit, domain.TimeInForceUnspecified, time.Time{}, domain.ErrInvalidTimeInForce},
+		{"unknown", domain.OrderTypeLimit, domain.TimeInForce(9), time.Time{}, domain.ErrInvalidTimeInForce},
+		{"GTD without expiry", domain.OrderTypeLimit, domain.TimeInForceGTD, time.Time{}, domain.ErrInvalidExpiry},
+		{"GTD expiry in the past", domain.OrderTypeLimit, domain.TimeInForceGTD, now.Add(-time.Second), domain.ErrInvalidExpiry},
+		{"GTD expiry now", domain.OrderTypeLimit, domain.TimeInForceGTD, now, domain.ErrInvalidExpiry},
+		{"GTC with expiry", domain.OrderTypeLimit, domain.TimeInForceGTC, future, domain.ErrExpiryNotAllowed},
+		{"IOC with expiry", domain.OrderTypeLimit, domain.TimeInForceIOC, future, domain.ErrExpiryNotAllowed},
+		{"market IOC", domain.OrderTypeMarket, domain.TimeInForceIOC, time.Time{}, nil},
+		{"stop market FOK", domain.OrderTypeStopMarket, domain.TimeInForceFOK, time.Time{}, nil},
+		{"market GTC", domain.OrderTypeMarket, domain.TimeInForceGTC, time.Time{}, domain.ErrTimeInForceNotAllowed},
+		{"stop market GTD", domain.OrderTypeStopMarket, domain.TimeInForceGTD, future, domain.ErrTimeInForceNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.typ.ValidateTimeInForce(tt.tif, tt.expiresAt, now)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("ValidateTimeInForce(%s, %s) error = %v, want %v", tt.typ, tt.tif, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultTimeInForce(t *testing.T) {
+	tests := []struct {
+		typ  domain.OrderType
+		want domain.TimeInForce
+	}{
+		{domain.OrderTypeLimit, domain.TimeInForceGTC},
+		{domain.OrderTypeStopLimit, domain.TimeInForceGTC},
+		{domain.OrderTypeMarket, domain.TimeInForceIOC},
+		{domain.OrderTypeStopMarket, domain.TimeInForceIOC},
+	}
+
+	for _, tt := range tests {
+		if got := tt.typ.DefaultTimeInForce(); got != tt.want {
+			t.Errorf("DefaultTimeInForce(%s) = %s, want %s", tt.typ, got, tt.want)
+		}
+	}
+}
+
+func TestValidateExecutionFlags(t *testing.T) {
+	tests := []struct {
+		name     string
+		typ      domain.OrderType
+		postOnly bool
+		tif      domain.TimeInForce
+		want     error
+	}{
+		{"post-only limit GTC", domain.OrderTypeLimit, true, domain.TimeInForceGTC, nil},
+		{"post-only stop limit GTD", domain.OrderTypeStopLimit, true, domain.TimeInForceGTD, nil},
+		{"post-only limit IOC", domain.OrderTypeLimit, true, domain.TimeInForceIOC, domain.ErrPostOnlyNotAllowed},
+		{"post-only limit FOK", domain.OrderTypeLimit, true, domain.TimeInForceFOK, domain.ErrPostOnlyNotAllowed},
+		{"post-only market", domain.OrderTypeMarket, true, domain.TimeInForceIOC, domain.ErrPostOnlyNotAllowed},
+		{"post-only stop market", domain.OrderTypeStopMarket, true, domain.TimeInForceIOC, domain.ErrPostOnlyNotAllowed},
+		{"market FOK without post-only", domain.OrderTypeMarket, false, domain.TimeInForceFOK, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.typ.ValidateExecutionFlags(tt.postOnly, false, tt.tif)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("ValidateExecutionFlags() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpire(t *testing.T) {
+	now := time.Now()
+	o := &domain.Order{
+		Status:      domain.OrderStatusOpen,
+		TimeInForce: domain.TimeInForceGTD,
+		ExpiresAt:   now.Add(time.Minute),
+	}
+
+	if err := o.Expire(now); !errors.Is(err, domain.ErrOrderNotExpired) {
+		t.Fatalf("Expire() before expiry error = %v, want %v", err, domain.ErrOrderNotExpired)
+	}
+	if err := o.Expire(o.ExpiresAt); err != nil {
+		t.Fatalf("Expire() error = %v", err)
+	}
+	if o.Status != domain.OrderStatusExpired {
+		t.Fatalf("status = %s, want EXPIRED", o.Status)
+	}
+	if err := o.Expire(o.ExpiresAt); !errors.Is(err, domain.ErrOrderNotExpired) {
+		t.Fatalf("Expire() after expiry error = %v, want %v", err, domain.ErrOrderNotExpired)
+	}
+}
@@ -1,5 +1,11 @@
 package domain
 
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
 type OrderType uint8
 
 const (
@@ -39,3 +45,45 @@ func ParseOrderType(s string) (OrderType, error) {
 		return OrderTypeUnspecified, ErrInvalidOrderType
 	}
 }
+
+// IsStop стоп-заказ: становится активным, когда рыночная цена достигает StopPrice
+func (t OrderType) IsStop() bool {
+	return t == OrderTypeStopLimit || t == OrderTypeStopMarket
+}
+
+// HasLimitPrice заказ исполняется не хуже указанной цены
+func (t OrderType) HasLimitPrice() bool {
+	return t == OrderTypeLimit || t == OrderTypeStopLimit
+}
+
+// ValidatePrices проверяет цены заказа для типа: LIMIT - только цена, MARKET - без цен,
+// STOP_MARKET - только стоп-цена, STOP_LIMIT - обе. Отсутствующая цена передается нулем.
+// Лимит STOP_LIMIT не хуже стоп-цены: для покупки не ниже, для продажи не выше
+func (t OrderType) ValidatePrices(side OrderSide, price, stopPrice decimal.Decimal) error {
+	if t == OrderTypeUnspecified || t > OrderTypeStopMarket {
+		return ErrInvalidOrderType
+	}
+
+	if t.HasLimitPrice() {
+		if !price.IsPositive() {
+			return ErrInvalidPrice
+		}
+	} else if !price.IsZero() {
+		return fmt.Errorf("%w: %s", ErrPriceNotAllowed, t)
+	}
+
+	if t.IsStop() {
+		if !stopPrice.IsPositive() {
+			return ErrInvalidStopPrice
+		}
+	} else if !stopPrice.IsZero() {
+		return fmt.Errorf("%w: %s", ErrStopPriceNotAllowed, t)
+	}
+
+	if t == OrderTypeStopLimit {
+		if side == OrderSideBuy && price.LessThan(stopPrice) || side == OrderSideSell && price.GreaterThan(stopPrice) {
+			return fmt.Errorf("%w: %s %s limit %s, stop %s", ErrStopLimitPrice, side, t, price, stopPrice)
+		}
+	}
+	return nil
+}
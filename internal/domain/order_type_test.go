@@ -0,0 +1,50 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/chilly266futon/orderService/internal/domain"
+)
+
+func TestValidatePrices(t *testing.T) {
+	tests := []struct {
+		name      string
+		typ       domain.OrderType
+		side      domain.OrderSide
+		price     string
+		stopPrice string
+		want      error
+	}{
+		{"limit", domain.OrderTypeLimit, domain.OrderSideBuy, "100", "0", nil},
+		{"limit without price", domain.OrderTypeLimit, domain.OrderSideBuy, "0", "0", domain.ErrInvalidPrice},
+		{"limit negative price", domain.OrderTypeLimit, domain.OrderSideSell, "-1", "0", domain.ErrInvalidPrice},
+		{"limit with stop price", domain.OrderTypeLimit, domain.OrderSideBuy, "100", "90", domain.ErrStopPriceNotAllowed},
+		{"market", domain.OrderTypeMarket, domain.OrderSideSell, "0", "0", nil},
+		{"market with price", domain.OrderTypeMarket, domain.OrderSideBuy, "100", "0", domain.ErrPriceNotAllowed},
+		{"market with stop price", domain.OrderTypeMarket, domain.OrderSideBuy, "0", "90", domain.ErrStopPriceNotAllowed},
+		{"stop market", domain.OrderTypeStopMarket, domain.OrderSideBuy, "0", "90", nil},
+		{"stop market without stop price", domain.OrderTypeStopMarket, domain.OrderSideSell, "0", "0", domain.ErrInvalidStopPrice},
+		{"stop market with price", domain.OrderTypeStopMarket, domain.OrderSideBuy, "100", "90", domain.ErrPriceNotAllowed},
+		{"stop limit without stop price", domain.OrderTypeStopLimit, domain.OrderSideBuy, "100", "0", domain.ErrInvalidStopPrice},
+		{"stop limit without price", domain.OrderTypeStopLimit, domain.OrderSideBuy, "0", "90", domain.ErrInvalidPrice},
+		{"stop limit buy above stop", domain.OrderTypeStopLimit, domain.OrderSideBuy, "101", "100", nil},
+		{"stop limit buy at stop", domain.OrderTypeStopLimit, domain.OrderSideBuy, "100", "100", nil},
+		{"stop limit buy below stop", domain.OrderTypeStopLimit, domain.OrderSideBuy, "99", "100", domain.ErrStopLimitPrice},
+		{"stop limit sell below stop", domain.OrderTypeStopLimit, domain.OrderSideSell, "99", "100", nil},
+		{"stop limit sell at stop", domain.OrderTypeStopLimit, domain.OrderSideSell, "100", "100", nil},
+		{"stop limit sell above stop", domain.OrderTypeStopLimit, domain.OrderSideSell, "101", "100", domain.ErrStopLimitPrice},
+		{"unspecified type", domain.OrderTypeUnspecified, domain.OrderSideBuy, "100", "0", domain.ErrInvalidOrderType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.typ.ValidatePrices(tt.side, decimal.RequireFromString(tt.price), decimal.RequireFromString(tt.stopPrice))
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("ValidatePrices(%s, %s, %s) error = %v, want %v", tt.side, tt.price, tt.stopPrice, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Trade сделка между двумя заказами одного рынка. Цена сделки - цена заказа, стоявшего в книге.
+// У обоих заказов сделка записана как Fill с тем же TradeID
+type Trade struct {
+	ID       string
+	MarketID string
+	Price    decimal.Decimal
+	Quantity decimal.Decimal
+	// TakerOrderID заказ, снявший ликвидность, MakerOrderID - стоявший в книге
+	TakerOrderID string
+	MakerOrderID string
+	TakerSide    OrderSide
+	At           time.Time
+}
@@ -0,0 +1,36 @@
+package order
+
+import "github.com/chilly266futon/orderService/internal/domain"
+
+type AdminGetOrderRequest struct {
+	OrderID string
+}
+
+type ForceTransitionRequest struct {
+	OrderID  string
+	ToStatus string
+	Reason   string
+}
+
+type ForceTransitionResponse struct {
+	Order *domain.Order
+}
+
+type CancelMarketOrdersRequest struct {
+	MarketID string
+	Reason   string
+}
+
+type CancelMarketOrdersResponse struct {
+	CancelledOrderIDs []string
+	FailedOrderIDs    []string
+}
+
+type ListAuditRecordsRequest struct {
+	OrderID string
+	Limit   int
+}
+
+type ListAuditRecordsResponse struct {
+	Records []domain.AuditRecord
+}
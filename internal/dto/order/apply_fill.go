@@ -0,0 +1,20 @@
+package order
+
+import (
+	"github.com/shopspring/decimal"
+
+	"github.com/chilly266futon/orderService/internal/domain"
+)
+
+type ApplyFillRequest struct {
+	OrderID  string
+	TradeID  string
+	Price    decimal.Decimal
+	Quantity decimal.Decimal
+}
+
+type ApplyFillResponse struct {
+	Order *domain.Order
+	// Duplicate сделка уже была учтена ранее, заказ не изменился
+	Duplicate bool
+}
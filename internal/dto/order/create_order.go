@@ -1,6 +1,8 @@
 package order
 
 import (
+	"time"
+
 	"github.com/shopspring/decimal"
 )
 
@@ -12,12 +14,35 @@ const (
 	OrderTypeStopMarket  = "STOP_MARKET"
 )
 
+const (
+	TimeInForceGTC = "GTC"
+	TimeInForceIOC = "IOC"
+	TimeInForceFOK = "FOK"
+	TimeInForceGTD = "GTD"
+)
+
+const (
+	OrderSideUnspecified = "UNSPECIFIED"
+	OrderSideBuy         = "BUY"
+	OrderSideSell        = "SELL"
+)
+
 type CreateOrderRequest struct {
 	UserID    string
 	MarketID  string
 	OrderType string
+	Side      string
+	// ClientOrderID необязательный ключ идемпотентности
+	ClientOrderID string
+	// Price лимитная цена, StopPrice цена активации стоп-заказа; отсутствующая цена - ноль
 	Price     decimal.Decimal
+	StopPrice decimal.Decimal
 	Quantity  decimal.Decimal
+	// TimeInForce пусто - срок действия по умолчанию для типа; ExpiresAt только для GTD
+	TimeInForce string
+	ExpiresAt   time.Time
+	PostOnly    bool
+	ReduceOnly  bool
 }
 
 type CreateOrderResponse struct {
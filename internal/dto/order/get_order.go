@@ -0,0 +1,12 @@
+package order
+
+import "github.com/chilly266futon/orderService/internal/domain"
+
+type GetOrderRequest struct {
+	OrderID string
+	UserID  string
+}
+
+type GetOrderResponse struct {
+	Order *domain.Order
+}
@@ -0,0 +1,13 @@
+package order
+
+import "github.com/chilly266futon/orderService/internal/domain"
+
+type GetOrderHistoryRequest struct {
+	OrderID string
+	UserID  string
+}
+
+type GetOrderHistoryResponse struct {
+	OrderID     string
+	Transitions []domain.OrderTransition
+}
@@ -7,6 +7,9 @@ const (
 	OrderStatusFilled      = "FILLED"
 	OrderStatusCancelled   = "CANCELLED"
 	OrderStatusRejected    = "REJECTED"
+
+	OrderStatusPartiallyFilled = "PARTIALLY_FILLED"
+	OrderStatusExpired         = "EXPIRED"
 )
 
 type GetOrderStatusRequest struct {
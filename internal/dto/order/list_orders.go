@@ -0,0 +1,23 @@
+package order
+
+import (
+	"time"
+
+	"github.com/chilly266futon/orderService/internal/domain"
+)
+
+type ListOrdersRequest struct {
+	UserID      string
+	MarketID    string
+	Statuses    []string
+	OrderTypes  []string
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	PageSize    int
+	PageToken   string
+}
+
+type ListOrdersResponse struct {
+	Orders        []*domain.Order
+	NextPageToken string
+}
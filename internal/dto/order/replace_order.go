@@ -0,0 +1,19 @@
+package order
+
+import (
+	"github.com/shopspring/decimal"
+
+	"github.com/chilly266futon/orderService/internal/domain"
+)
+
+type ReplaceOrderRequest struct {
+	OrderID string
+	UserID  string
+	// Price новая лимитная цена, Quantity новый объем; ноль - без изменений
+	Price    decimal.Decimal
+	Quantity decimal.Decimal
+}
+
+type ReplaceOrderResponse struct {
+	Order *domain.Order
+}
@@ -0,0 +1,7 @@
+package order
+
+type SubscribeOrderUpdatesRequest struct {
+	UserID       string
+	OrderID      string // пусто - все заказы пользователя
+	FromSequence int64  // 0 - только новые события
+}
@@ -0,0 +1,17 @@
+package order
+
+import (
+	"github.com/shopspring/decimal"
+
+	"github.com/chilly266futon/orderService/internal/domain"
+)
+
+type TriggerStopOrderRequest struct {
+	OrderID string
+	// LastPrice цена последней сделки, активировавшая заказ
+	LastPrice decimal.Decimal
+}
+
+type TriggerStopOrderResponse struct {
+	Order *domain.Order
+}
@@ -0,0 +1,30 @@
+package mappers
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/chilly266futon/orderService/gen/pb/order"
+	"github.com/chilly266futon/orderService/internal/domain"
+)
+
+func AuditRecordToProto(r domain.AuditRecord) *pb.AuditRecord {
+	return &pb.AuditRecord{
+		Id:         r.ID,
+		Actor:      r.Actor,
+		Action:     r.Action,
+		OrderId:    r.OrderID,
+		MarketId:   r.MarketID,
+		Reason:     r.Reason,
+		Outcome:    r.Outcome,
+		Details:    r.Details,
+		OccurredAt: timestamppb.New(r.At),
+	}
+}
+
+func AuditRecordsToProto(records []domain.AuditRecord) []*pb.AuditRecord {
+	result := make([]*pb.AuditRecord, 0, len(records))
+	for _, r := range records {
+		result = append(result, AuditRecordToProto(r))
+	}
+	return result
+}
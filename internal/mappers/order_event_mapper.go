@@ -0,0 +1,49 @@
+package mappers
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/chilly266futon/orderService/gen/pb/order"
+	"github.com/chilly266futon/orderService/internal/domain"
+)
+
+func OrderEventTypeToProto(t domain.OrderEventType) pb.OrderEventType {
+	switch t {
+	case domain.OrderEventCreated:
+		return pb.OrderEventType_ORDER_EVENT_TYPE_CREATED
+	case domain.OrderEventCancelled:
+		return pb.OrderEventType_ORDER_EVENT_TYPE_CANCELLED
+	case domain.OrderEventFilled:
+		return pb.OrderEventType_ORDER_EVENT_TYPE_FILLED
+	case domain.OrderEventRejected:
+		return pb.OrderEventType_ORDER_EVENT_TYPE_REJECTED
+	case domain.OrderEventStatusChanged:
+		return pb.OrderEventType_ORDER_EVENT_TYPE_STATUS_CHANGED
+	case domain.OrderEventAmended:
+		return pb.OrderEventType_ORDER_EVENT_TYPE_AMENDED
+	default:
+		return pb.OrderEventType_ORDER_EVENT_TYPE_UNSPECIFIED
+	}
+}
+
+func OrderEventToProto(e domain.OrderEvent) *pb.OrderUpdate {
+	update := &pb.OrderUpdate{
+		Sequence:       e.Sequence,
+		EventId:        e.ID,
+		Type:           OrderEventTypeToProto(e.Type),
+		OrderId:        e.OrderID,
+		MarketId:       e.MarketID,
+		Status:         OrderStatusToProto(e.Status),
+		FilledQuantity: e.FilledQuantity.String(),
+		Reason:         e.Reason,
+		OccurredAt:     timestamppb.New(e.OccurredAt),
+	}
+	if e.Fill != nil {
+		update.Fill = &pb.OrderFill{
+			TradeId:  e.Fill.TradeID,
+			Price:    e.Fill.Price.String(),
+			Quantity: e.Fill.Quantity.String(),
+		}
+	}
+	return update
+}
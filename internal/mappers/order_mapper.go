@@ -0,0 +1,47 @@
+package mappers
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/chilly266futon/orderService/gen/pb/order"
+	"github.com/chilly266futon/orderService/internal/domain"
+)
+
+func OrderToProto(o *domain.Order) *pb.Order {
+	pbOrder := &pb.Order{
+		OrderId:   o.ID,
+		UserId:    o.UserID,
+		MarketId:  o.MarketID,
+		OrderType: OrderTypeToProto(o.Type),
+		Side:      OrderSideToProto(o.Side),
+		Status:    OrderStatusToProto(o.Status),
+		Price:     o.Price.String(),
+		StopPrice: o.StopPrice.String(),
+		Quantity:  o.Quantity.String(),
+		CreatedAt: timestamppb.New(o.CreatedAt),
+		UpdatedAt: timestamppb.New(o.UpdatedAt),
+
+		FilledQuantity:    o.FilledQuantity.String(),
+		RemainingQuantity: o.RemainingQuantity().String(),
+		AvgFillPrice:      o.AvgFillPrice.String(),
+		ClientOrderId:     o.ClientOrderID,
+		TimeInForce:       TimeInForceToProto(o.TimeInForce),
+		PostOnly:          o.PostOnly,
+		ReduceOnly:        o.ReduceOnly,
+	}
+	if !o.ExpiresAt.IsZero() {
+		pbOrder.ExpiresAt = timestamppb.New(o.ExpiresAt)
+	}
+	if !o.TriggeredAt.IsZero() {
+		pbOrder.TriggeredAt = timestamppb.New(o.TriggeredAt)
+	}
+	return pbOrder
+}
+
+func OrdersToProto(orders []*domain.Order) []*pb.Order {
+	result := make([]*pb.Order, len(orders))
+	for i, o := range orders {
+		result[i] = OrderToProto(o)
+	}
+	return result
+}
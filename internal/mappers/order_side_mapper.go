@@ -0,0 +1,28 @@
+package mappers
+
+import (
+	pb "github.com/chilly266futon/orderService/gen/pb/order"
+	"github.com/chilly266futon/orderService/internal/domain"
+)
+
+func OrderSideFromProto(s pb.OrderSide) domain.OrderSide {
+	switch s {
+	case pb.OrderSide_ORDER_SIDE_BUY:
+		return domain.OrderSideBuy
+	case pb.OrderSide_ORDER_SIDE_SELL:
+		return domain.OrderSideSell
+	default:
+		return domain.OrderSideUnspecified
+	}
+}
+
+func OrderSideToProto(s domain.OrderSide) pb.OrderSide {
+	switch s {
+	case domain.OrderSideBuy:
+		return pb.OrderSide_ORDER_SIDE_BUY
+	case domain.OrderSideSell:
+		return pb.OrderSide_ORDER_SIDE_SELL
+	default:
+		return pb.OrderSide_ORDER_SIDE_UNSPECIFIED
+	}
+}
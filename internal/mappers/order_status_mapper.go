@@ -1,7 +1,7 @@
 package mappers
 
 import (
-	pb "github.com/chilly266futon/exchange-service-contracts/gen/pb/order"
+	pb "github.com/chilly266futon/orderService/gen/pb/order"
 	"github.com/chilly266futon/orderService/internal/domain"
 )
 
@@ -17,8 +17,33 @@ func OrderStatusToProto(s domain.OrderStatus) pb.OrderStatus {
 		return pb.OrderStatus_ORDER_STATUS_CANCELLED
 	case domain.OrderStatusRejected:
 		return pb.OrderStatus_ORDER_STATUS_REJECTED
+	case domain.OrderStatusPartiallyFilled:
+		return pb.OrderStatus_ORDER_STATUS_PARTIALLY_FILLED
+	case domain.OrderStatusExpired:
+		return pb.OrderStatus_ORDER_STATUS_EXPIRED
 
 	default:
 		return pb.OrderStatus_ORDER_STATUS_UNSPECIFIED
 	}
 }
+
+func OrderStatusFromProto(s pb.OrderStatus) domain.OrderStatus {
+	switch s {
+	case pb.OrderStatus_ORDER_STATUS_CREATED:
+		return domain.OrderStatusCreated
+	case pb.OrderStatus_ORDER_STATUS_OPEN:
+		return domain.OrderStatusOpen
+	case pb.OrderStatus_ORDER_STATUS_FILLED:
+		return domain.OrderStatusFilled
+	case pb.OrderStatus_ORDER_STATUS_CANCELLED:
+		return domain.OrderStatusCancelled
+	case pb.OrderStatus_ORDER_STATUS_REJECTED:
+		return domain.OrderStatusRejected
+	case pb.OrderStatus_ORDER_STATUS_PARTIALLY_FILLED:
+		return domain.OrderStatusPartiallyFilled
+	case pb.OrderStatus_ORDER_STATUS_EXPIRED:
+		return domain.OrderStatusExpired
+	default:
+		return domain.OrderStatusUnspecified
+	}
+}
@@ -0,0 +1,26 @@
+package mappers
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/chilly266futon/orderService/gen/pb/order"
+	"github.com/chilly266futon/orderService/internal/domain"
+)
+
+func OrderTransitionToProto(t domain.OrderTransition) *pb.OrderTransition {
+	return &pb.OrderTransition{
+		FromStatus: OrderStatusToProto(t.From),
+		ToStatus:   OrderStatusToProto(t.To),
+		Reason:     t.Reason,
+		Actor:      t.Actor,
+		OccurredAt: timestamppb.New(t.At),
+	}
+}
+
+func OrderTransitionsToProto(transitions []domain.OrderTransition) []*pb.OrderTransition {
+	result := make([]*pb.OrderTransition, len(transitions))
+	for i, t := range transitions {
+		result[i] = OrderTransitionToProto(t)
+	}
+	return result
+}
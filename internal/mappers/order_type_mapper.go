@@ -1,7 +1,7 @@
 package mappers
 
 import (
-	pb "github.com/chilly266futon/exchange-service-contracts/gen/pb/order"
+	pb "github.com/chilly266futon/orderService/gen/pb/order"
 	"github.com/chilly266futon/orderService/internal/domain"
 )
 
@@ -19,3 +19,18 @@ func OrderTypeFromProto(t pb.OrderType) domain.OrderType {
 		return domain.OrderTypeUnspecified
 	}
 }
+
+func OrderTypeToProto(t domain.OrderType) pb.OrderType {
+	switch t {
+	case domain.OrderTypeLimit:
+		return pb.OrderType_ORDER_TYPE_LIMIT
+	case domain.OrderTypeMarket:
+		return pb.OrderType_ORDER_TYPE_MARKET
+	case domain.OrderTypeStopLimit:
+		return pb.OrderType_ORDER_TYPE_STOP_LIMIT
+	case domain.OrderTypeStopMarket:
+		return pb.OrderType_ORDER_TYPE_STOP_MARKET
+	default:
+		return pb.OrderType_ORDER_TYPE_UNSPECIFIED
+	}
+}
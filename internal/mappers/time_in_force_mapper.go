@@ -0,0 +1,36 @@
+package mappers
+
+import (
+	pb "github.com/chilly266futon/orderService/gen/pb/order"
+	"github.com/chilly266futon/orderService/internal/domain"
+)
+
+func TimeInForceFromProto(t pb.TimeInForce) domain.TimeInForce {
+	switch t {
+	case pb.TimeInForce_TIME_IN_FORCE_GTC:
+		return domain.TimeInForceGTC
+	case pb.TimeInForce_TIME_IN_FORCE_IOC:
+		return domain.TimeInForceIOC
+	case pb.TimeInForce_TIME_IN_FORCE_FOK:
+		return domain.TimeInForceFOK
+	case pb.TimeInForce_TIME_IN_FORCE_GTD:
+		return domain.TimeInForceGTD
+	default:
+		return domain.TimeInForceUnspecified
+	}
+}
+
+func TimeInForceToProto(t domain.TimeInForce) pb.TimeInForce {
+	switch t {
+	case domain.TimeInForceGTC:
+		return pb.TimeInForce_TIME_IN_FORCE_GTC
+	case domain.TimeInForceIOC:
+		return pb.TimeInForce_TIME_IN_FORCE_IOC
+	case domain.TimeInForceFOK:
+		return pb.TimeInForce_TIME_IN_FORCE_FOK
+	case domain.TimeInForceGTD:
+		return pb.TimeInForce_TIME_IN_FORCE_GTD
+	default:
+		return pb.TimeInForce_TIME_IN_FORCE_UNSPECIFIED
+	}
+}
@@ -0,0 +1,141 @@
+package matching
+
+import (
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/chilly266futon/orderService/internal/domain"
+)
+
+// entry заказ, стоящий в книге
+type entry struct {
+	orderID string
+	side    domain.OrderSide
+	price   decimal.Decimal
+	// quantity объем заказа на момент постановки в книгу
+	quantity decimal.Decimal
+	// expiry таймер истечения GTD заказа
+	expiry *time.Timer
+}
+
+// level заказы одной цены в порядке попадания в книгу
+type level struct {
+	price  decimal.Decimal
+	orders []*entry
+}
+
+// orderBook книга заявок рынка с приоритетом цена-время: лучшая цена первой, при равной цене -
+// заказ, раньше попавший в книгу. Заявки на покупку упорядочены по убыванию цены, на продажу - по возрастанию.
+// Не потокобезопасна, принадлежит горутине рынка
+type orderBook struct {
+	bids    []*level
+	asks    []*level
+	entries map[string]*entry
+}
+
+func newOrderBook() *orderBook {
+	return &orderBook{entries: make(map[string]*entry)}
+}
+
+func (b *orderBook) contains(orderID string) bool {
+	_, ok := b.entries[orderID]
+	return ok
+}
+
+func (b *orderBook) get(orderID string) *entry {
+	return b.entries[orderID]
+}
+
+func (b *orderBook) add(e *entry) {
+	levels := b.side(e.side)
+	i := sort.Search(len(*levels), func(i int) bool { return !better(e.side, (*levels)[i].price, e.price) })
+	if i == len(*levels) || !(*levels)[i].price.Equal(e.price) {
+		*levels = append(*levels, nil)
+		copy((*levels)[i+1:], (*levels)[i:])
+		(*levels)[i] = &level{price: e.price}
+	}
+	(*levels)[i].orders = append((*levels)[i].orders, e)
+	b.entries[e.orderID] = e
+}
+
+// remove убирает заказ из книги и возвращает его запись, nil - заказа в книге нет
+func (b *orderBook) remove(orderID string) *entry {
+	e, ok := b.entries[orderID]
+	if !ok {
+		return nil
+	}
+	delete(b.entries, orderID)
+
+	levels := b.side(e.side)
+	i := sort.Search(len(*levels), func(i int) bool { return !better(e.side, (*levels)[i].price, e.price) })
+	if i == len(*levels) || !(*levels)[i].price.Equal(e.price) {
+		return e
+	}
+
+	lvl := (*levels)[i]
+	for j, o := range lvl.orders {
+		if o.orderID == orderID {
+			lvl.orders = append(lvl.orders[:j], lvl.orders[j+1:]...)
+			break
+		}
+	}
+	if len(lvl.orders) == 0 {
+		*levels = append((*levels)[:i], (*levels)[i+1:]...)
+	}
+	return e
+}
+
+// best первый в очереди заказ лучшей цены на стороне side
+func (b *orderBook) best(side domain.OrderSide) *entry {
+	levels := *b.side(side)
+	if len(levels) == 0 {
+		return nil
+	}
+	return levels[0].orders[0]
+}
+
+// each обходит заказы стороны side в порядке приоритета, пока fn возвращает true
+func (b *orderBook) each(side domain.OrderSide, fn func(e *entry) bool) {
+	for _, lvl := range *b.side(side) {
+		for _, e := range lvl.orders {
+			if !fn(e) {
+				return
+			}
+		}
+	}
+}
+
+func (b *orderBook) side(side domain.OrderSide) *[]*level {
+	if side == domain.OrderSideBuy {
+		return &b.bids
+	}
+	return &b.asks
+}
+
+// better цена a лучше цены b для стороны side: выше для покупки, ниже для продажи
+func better(side domain.OrderSide, a, b decimal.Decimal) bool {
+	if side == domain.OrderSideBuy {
+		return a.GreaterThan(b)
+	}
+	return a.LessThan(b)
+}
+
+// crosses заказ taker может исполниться по цене price встречного заказа
+func crosses(taker *domain.Order, price decimal.Decimal) bool {
+	if taker.ExecutionType() == domain.OrderTypeMarket {
+		return true
+	}
+	if taker.Side == domain.OrderSideBuy {
+		return taker.Price.GreaterThanOrEqual(price)
+	}
+	return taker.Price.LessThanOrEqual(price)
+}
+
+func opposite(side domain.OrderSide) domain.OrderSide {
+	if side == domain.OrderSideBuy {
+		return domain.OrderSideSell
+	}
+	return domain.OrderSideBuy
+}
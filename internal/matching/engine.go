@@ -0,0 +1,319 @@
+package matching
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/chilly266futon/orderService/internal/domain"
+	"github.com/chilly266futon/orderService/internal/outbox"
+	"github.com/chilly266futon/orderService/internal/storage"
+)
+
+const (
+	defaultQueueSize = 1024
+	loadPageSize     = 500
+	// resyncDelay пауза перед повторной загрузкой книг после ошибки хранилища
+	resyncDelay          = 5 * time.Second
+	defaultLeaseInterval = 5 * time.Second
+	releaseTimeout       = 5 * time.Second
+)
+
+// TradeSink получатель сделок движка, например источник цен для стоп-заказов
+type TradeSink interface {
+	PublishTrade(ctx context.Context, trade domain.Trade) error
+}
+
+type Config struct {
+	// QueueSize размер очереди команд одного рынка
+	QueueSize int
+	// LeaseInterval как часто проверять аренду: получать ее, пока она у другого экземпляра,
+	// и подтверждать, пока движок работает
+	LeaseInterval time.Duration
+}
+
+// Engine сводит LIMIT и MARKET заказы по приоритету цена-время. У каждого рынка своя книга
+// и своя горутина-писатель, поэтому рынки обрабатываются параллельно и без общей блокировки.
+// Заказы попадают в движок по событиям из Hub (создание, активация стоп-заказа), снимаются по событиям
+// отмены. При старте и при переполнении подписки книги строятся заново из хранилища
+// в порядке создания заказов, поэтому приоритет по времени после перезагрузки - CreatedAt.
+// Книги строит и сводит заказы только экземпляр, получивший аренду; при ее потере книги сбрасываются
+type Engine struct {
+	repo    storage.OrderRepository
+	updates *outbox.Hub
+	trades  TradeSink
+	lease   storage.Lease
+	cfg     Config
+	logger  *zap.Logger
+
+	cancel context.CancelFunc
+	done   chan struct{}
+	mu     sync.Mutex
+}
+
+// NewEngine создает движок. trades может быть nil
+func NewEngine(
+	repo storage.OrderRepository,
+	updates *outbox.Hub,
+	trades TradeSink,
+	lease storage.Lease,
+	cfg Config,
+	logger *zap.Logger,
+) *Engine {
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = defaultQueueSize
+	}
+	if cfg.LeaseInterval <= 0 {
+		cfg.LeaseInterval = defaultLeaseInterval
+	}
+
+	return &Engine{
+		repo:    repo,
+		updates: updates,
+		trades:  trades,
+		lease:   lease,
+		cfg:     cfg,
+		logger:  logger,
+	}
+}
+
+// Start запускает движок в отдельной горутине
+func (e *Engine) Start() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	e.cancel = cancel
+	e.done = make(chan struct{})
+
+	go func() {
+		defer close(e.done)
+		e.run(ctx)
+	}()
+}
+
+// Stop останавливает движок, ждет, пока рынки закончат текущие сделки, и отпускает аренду
+func (e *Engine) Stop() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.cancel == nil {
+		return
+	}
+	e.cancel()
+	<-e.done
+	e.cancel = nil
+
+	ctx, cancel := context.WithTimeout(context.Background(), releaseTimeout)
+	defer cancel()
+	if err := e.lease.Release(ctx); err != nil {
+		e.logger.Warn("failed to release matching lease", zap.Error(err))
+	}
+}
+
+func (e *Engine) run(ctx context.Context) {
+	for ctx.Err() == nil {
+		if e.holdsLease(ctx) {
+			e.session(ctx)
+			continue
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(e.cfg.LeaseInterval):
+		}
+	}
+}
+
+// holdsLease получает или подтверждает аренду. Ошибка считается потерей аренды:
+// блокировка могла уйти вместе с соединением
+func (e *Engine) holdsLease(ctx context.Context) bool {
+	acquired, err := e.lease.TryAcquire(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			e.logger.Error("failed to acquire matching lease", zap.Error(err))
+		}
+		return false
+	}
+	return acquired
+}
+
+// watchLease отменяет сессию, как только аренда перешла к другому экземпляру
+func (e *Engine) watchLease(ctx context.Context, cancel context.CancelFunc) {
+	ticker := time.NewTicker(e.cfg.LeaseInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !e.holdsLease(ctx) {
+				if ctx.Err() == nil {
+					e.logger.Warn("matching lease lost, dropping order books")
+				}
+				cancel()
+				return
+			}
+		}
+	}
+}
+
+// markets рынки одной сессии движка
+type markets struct {
+	engine *Engine
+	ctx    context.Context
+	byID   map[string]*market
+	wg     sync.WaitGroup
+}
+
+func (ms *markets) get(marketID string) *market {
+	m, ok := ms.byID[marketID]
+	if !ok {
+		m = newMarket(marketID, ms.engine)
+		ms.byID[marketID] = m
+
+		ms.wg.Add(1)
+		go func() {
+			defer ms.wg.Done()
+			m.run(ms.ctx)
+		}()
+	}
+	return m
+}
+
+// session строит книги из хранилища и обрабатывает события до остановки, потери аренды
+// или переполнения подписки. Подписка оформляется до загрузки, чтобы не потерять заказы, созданные во время нее
+func (e *Engine) session(ctx context.Context) {
+	sub := e.updates.Subscribe(storage.EventFilter{})
+	defer e.updates.Unsubscribe(sub)
+
+	sessionCtx, cancel := context.WithCancel(ctx)
+	ms := &markets{engine: e, ctx: sessionCtx, byID: make(map[string]*market)}
+	ms.wg.Add(1)
+	go func() {
+		defer ms.wg.Done()
+		e.watchLease(sessionCtx, cancel)
+	}()
+	defer func() {
+		cancel()
+		ms.wg.Wait()
+	}()
+
+	if err := e.load(sessionCtx, ms); err != nil {
+		if sessionCtx.Err() != nil {
+			return
+		}
+		e.logger.Error("failed to load orders for matching", zap.Error(err))
+		select {
+		case <-sessionCtx.Done():
+		case <-time.After(resyncDelay):
+		}
+		return
+	}
+
+	for {
+		select {
+		case <-sessionCtx.Done():
+			return
+		case event, ok := <-sub.Events():
+			if !ok {
+				e.logger.Warn("order updates subscription overflowed, rebuilding order books")
+				return
+			}
+			e.dispatch(sessionCtx, ms, event)
+		}
+	}
+}
+
+// load отправляет рынкам все незавершенные заказы в порядке их места в очереди
+func (e *Engine) load(ctx context.Context, ms *markets) error {
+	filter := storage.OrderFilter{
+		Statuses: []domain.OrderStatus{
+			domain.OrderStatusCreated,
+			domain.OrderStatusOpen,
+			domain.OrderStatusPartiallyFilled,
+		},
+		// неактивированные стоп-заказы отсеивает рынок
+		Types: []domain.OrderType{
+			domain.OrderTypeLimit,
+			domain.OrderTypeMarket,
+			domain.OrderTypeStopLimit,
+			domain.OrderTypeStopMarket,
+		},
+		Limit: loadPageSize,
+	}
+
+	var orders []*domain.Order
+	for {
+		page, err := e.repo.List(ctx, filter)
+		if err != nil {
+			return err
+		}
+		orders = append(orders, page...)
+		if len(page) < loadPageSize {
+			break
+		}
+		cursor := storage.CursorOf(page[len(page)-1])
+		filter.After = &cursor
+	}
+
+	// List отдает от новых к старым; измененные и активированные заказы встают в очередь позже создания
+	slices.Reverse(orders)
+	slices.SortStableFunc(orders, func(a, b *domain.Order) int {
+		return a.QueuedAt().Compare(b.QueuedAt())
+	})
+	for _, o := range orders {
+		ms.get(o.MarketID).send(ctx, command{kind: commandSubmit, orderID: o.ID})
+	}
+
+	e.logger.Info("order books loaded",
+		zap.Int("orders", len(orders)),
+		zap.Int("markets", len(ms.byID)),
+	)
+	return nil
+}
+
+// dispatch передает событие рынку заказа: новые и активированные заказы сводятся, измененные переставляются,
+// завершенные снимаются с книги. Исполнения, сделанные самим движком, книгу уже учли
+func (e *Engine) dispatch(ctx context.Context, ms *markets, event domain.OrderEvent) {
+	switch {
+	case event.Status.IsFinal():
+		if m, ok := ms.byID[event.MarketID]; ok {
+			m.send(ctx, command{kind: commandRemove, orderID: event.OrderID})
+		}
+	case event.Type == domain.OrderEventCreated,
+		event.Type == domain.OrderEventStatusChanged && event.Status == domain.OrderStatusOpen:
+		ms.get(event.MarketID).send(ctx, command{kind: commandSubmit, orderID: event.OrderID})
+
+	case event.Type == domain.OrderEventAmended:
+		ms.get(event.MarketID).send(ctx, command{kind: commandAmend, orderID: event.OrderID})
+	}
+}
+
+func (e *Engine) publishTrade(ctx context.Context, trade domain.Trade) {
+	e.logger.Info("trade executed",
+		zap.String("trade_id", trade.ID),
+		zap.String("market_id", trade.MarketID),
+		zap.String("price", trade.Price.String()),
+		zap.String("quantity", trade.Quantity.String()),
+		zap.String("taker_order_id", trade.TakerOrderID),
+		zap.String("maker_order_id", trade.MakerOrderID),
+	)
+
+	if e.trades == nil {
+		return
+	}
+	if err := e.trades.PublishTrade(ctx, trade); err != nil && ctx.Err() == nil {
+		e.logger.Warn("failed to publish trade",
+			zap.String("trade_id", trade.ID),
+			zap.Error(err),
+		)
+	}
+}
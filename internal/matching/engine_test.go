@@ -0,0 +1,135 @@
+package matching
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/chilly266futon/orderService/internal/domain"
+	"github.com/chilly266futon/orderService/internal/outbox"
+	"github.com/chilly266futon/orderService/internal/storage"
+	"github.com/chilly266futon/orderService/internal/storage/storagetest"
+)
+
+// switchLease аренда, которую тест выдает и отбирает
+type switchLease struct {
+	held     atomic.Bool
+	released atomic.Bool
+}
+
+func (l *switchLease) TryAcquire(context.Context) (bool, error) {
+	return l.held.Load(), nil
+}
+
+func (l *switchLease) Release(context.Context) error {
+	l.released.Store(true)
+	return nil
+}
+
+// loadCountingRepo считает загрузки книг движком
+type loadCountingRepo struct {
+	storage.OrderRepository
+	loads atomic.Int64
+}
+
+func (r *loadCountingRepo) List(ctx context.Context, filter storage.OrderFilter) ([]*domain.Order, error) {
+	r.loads.Add(1)
+	return r.OrderRepository.List(ctx, filter)
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestEngineMatchesOnlyWithLease(t *testing.T) {
+	repo := &loadCountingRepo{OrderRepository: storage.NewMemoryOrderRepository()}
+	lease := &switchLease{}
+	hub := outbox.NewHub(0)
+
+	engine := NewEngine(repo, hub, nil, lease, Config{LeaseInterval: 10 * time.Millisecond}, zap.NewNop())
+	engine.Start()
+	defer engine.Stop()
+
+	time.Sleep(50 * time.Millisecond)
+	if n := repo.loads.Load(); n != 0 {
+		t.Fatalf("engine loaded order books %d times without the lease", n)
+	}
+
+	lease.held.Store(true)
+	waitFor(t, "order books loaded", func() bool { return repo.loads.Load() == 1 })
+
+	// аренда у другого экземпляра: сессия завершается и не начинается заново, пока аренда не вернется
+	lease.held.Store(false)
+	time.Sleep(50 * time.Millisecond)
+	if n := repo.loads.Load(); n != 1 {
+		t.Fatalf("order books loaded %d times without the lease, want 1", n)
+	}
+
+	lease.held.Store(true)
+	waitFor(t, "order books rebuilt", func() bool { return repo.loads.Load() == 2 })
+
+	engine.Stop()
+	if !lease.released.Load() {
+		t.Fatal("Stop() did not release the lease")
+	}
+}
+
+func TestLoadSubmitsOrdersInQueueOrder(t *testing.T) {
+	ctx := context.Background()
+	repo := storage.NewMemoryOrderRepository()
+	engine := NewEngine(repo, outbox.NewHub(0), nil, storage.NewMemoryLease(), Config{QueueSize: 10}, zap.NewNop())
+
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	add := func(createdAt time.Time, change func(o *domain.Order)) *domain.Order {
+		o := storagetest.NewOrder("user-1")
+		o.MarketID = "market-1"
+		o.Status = domain.OrderStatusOpen
+		o.CreatedAt, o.UpdatedAt = createdAt, createdAt
+		if change != nil {
+			change(o)
+		}
+		if err := repo.Add(ctx, o); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+		return o
+	}
+
+	// первый заказ потерял место в очереди при изменении цены, стоп-заказ встал в очередь при активации
+	amended := add(created, func(o *domain.Order) { o.RequeuedAt = created.Add(3 * time.Minute) })
+	first := add(created.Add(time.Minute), nil)
+	triggered := add(created.Add(-time.Minute), func(o *domain.Order) {
+		o.Type = domain.OrderTypeStopLimit
+		o.StopPrice = o.Price
+		o.TriggeredAt = created.Add(2 * time.Minute)
+	})
+	second := add(created.Add(90*time.Second), nil)
+
+	m := newMarket("market-1", engine)
+	ms := &markets{engine: engine, ctx: ctx, byID: map[string]*market{m.id: m}}
+	if err := engine.load(ctx, ms); err != nil {
+		t.Fatalf("load() error = %v", err)
+	}
+
+	want := []string{first.ID, second.ID, triggered.ID, amended.ID}
+	for i, id := range want {
+		select {
+		case cmd := <-m.cmds:
+			if cmd.kind != commandSubmit || cmd.orderID != id {
+				t.Fatalf("command %d = %v %s, want submit %s", i, cmd.kind, cmd.orderID, id)
+			}
+		default:
+			t.Fatalf("got %d commands, want %d", i, len(want))
+		}
+	}
+}
@@ -0,0 +1,473 @@
+package matching
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+
+	"github.com/chilly266futon/orderService/internal/domain"
+	"github.com/chilly266futon/orderService/internal/storage"
+)
+
+const (
+	// maxTradeAttempts сколько раз перечитывать заказы сделки при конфликте версий
+	maxTradeAttempts = 3
+	// retryDelay пауза перед повторной обработкой заказа после ошибки хранилища
+	retryDelay = time.Second
+
+	reduceOnlyCancelReason = "reduce-only order would increase or flip the position"
+)
+
+type commandKind uint8
+
+const (
+	commandSubmit commandKind = iota
+	commandRemove
+	commandExpire
+	commandAmend
+)
+
+type command struct {
+	kind    commandKind
+	orderID string
+}
+
+// market единственный писатель книги одного рынка: все команды рынка выполняются по очереди
+// в его горутине, поэтому книга не требует блокировок
+type market struct {
+	id     string
+	engine *Engine
+	book   *orderBook
+	cmds   chan command
+}
+
+func newMarket(id string, engine *Engine) *market {
+	return &market{
+		id:     id,
+		engine: engine,
+		book:   newOrderBook(),
+		cmds:   make(chan command, engine.cfg.QueueSize),
+	}
+}
+
+func (m *market) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case cmd := <-m.cmds:
+			switch cmd.kind {
+			case commandSubmit:
+				m.submit(ctx, cmd.orderID)
+			case commandRemove:
+				m.unbook(cmd.orderID)
+			case commandExpire:
+				m.expire(ctx, cmd.orderID)
+			case commandAmend:
+				m.amend(ctx, cmd.orderID)
+			}
+		}
+	}
+}
+
+// send ставит команду в очередь рынка. Блокируется, пока очередь полна
+func (m *market) send(ctx context.Context, cmd command) {
+	select {
+	case m.cmds <- cmd:
+	case <-ctx.Done():
+	}
+}
+
+// retryLater повторяет обработку заказа после паузы
+func (m *market) retryLater(ctx context.Context, orderID string) {
+	go func() {
+		select {
+		case <-time.After(retryDelay):
+			m.send(ctx, command{kind: commandSubmit, orderID: orderID})
+		case <-ctx.Done():
+		}
+	}()
+}
+
+// submit сводит заказ со встречными заказами книги и ставит неисполненный остаток в книгу.
+// Остаток MARKET, IOC и FOK отменяется: такие заказы исполняются только по имеющейся ликвидности
+func (m *market) submit(ctx context.Context, orderID string) {
+	if m.book.contains(orderID) {
+		return
+	}
+
+	taker, err := m.engine.repo.GetByID(ctx, orderID)
+	if err != nil {
+		if !errors.Is(err, domain.ErrOrderNotFound) && ctx.Err() == nil {
+			m.engine.logger.Error("failed to load order for matching",
+				zap.String("order_id", orderID),
+				zap.Error(err),
+			)
+			m.retryLater(ctx, orderID)
+		}
+		return
+	}
+	if !isMatchable(taker) {
+		return
+	}
+	if taker.IsExpired(time.Now()) {
+		m.expireOrder(ctx, taker)
+		return
+	}
+
+	if taker.TimeInForce == domain.TimeInForceFOK {
+		ok, err := m.canFill(ctx, taker)
+		if err != nil {
+			m.storageFailed(ctx, taker, "failed to check liquidity for fill or kill order", err)
+			return
+		}
+		if !ok {
+			m.transition(ctx, taker, domain.OrderStatusCancelled, "fill or kill: not enough liquidity")
+			return
+		}
+	}
+
+	if taker.PostOnly {
+		if maker := m.book.best(opposite(taker.Side)); maker != nil && crosses(taker, maker.price) {
+			// активированный стоп-заказ уже OPEN, отклонить его нельзя
+			to := domain.OrderStatus(domain.OrderStatusRejected)
+			if taker.Status != domain.OrderStatusCreated {
+				to = domain.OrderStatusCancelled
+			}
+			m.transition(ctx, taker, to, "post-only order would take liquidity")
+			return
+		}
+	}
+
+	for taker.RemainingQuantity().IsPositive() {
+		maker := m.book.best(opposite(taker.Side))
+		if maker == nil || !crosses(taker, maker.price) {
+			break
+		}
+
+		var ok bool
+		if taker, ok = m.trade(ctx, taker, maker); !ok {
+			return
+		}
+	}
+
+	if !taker.IsFillable() {
+		return
+	}
+
+	switch {
+	case taker.ExecutionType() == domain.OrderTypeMarket:
+		m.transition(ctx, taker, domain.OrderStatusCancelled, "market order remainder cancelled: no liquidity")
+
+	case taker.TimeInForce.IsImmediate():
+		m.transition(ctx, taker, domain.OrderStatusCancelled, taker.TimeInForce.String()+" order remainder cancelled")
+
+	default:
+		if taker.Status == domain.OrderStatusCreated {
+			if !m.transition(ctx, taker, domain.OrderStatusOpen, "accepted by matching engine") {
+				return
+			}
+		}
+		m.rest(ctx, taker)
+	}
+}
+
+// rest ставит заказ в книгу. Для GTD заводится таймер, снимающий заказ по истечении срока
+func (m *market) rest(ctx context.Context, o *domain.Order) {
+	e := &entry{orderID: o.ID, side: o.Side, price: o.Price, quantity: o.Quantity}
+	if o.TimeInForce == domain.TimeInForceGTD {
+		e.expiry = time.AfterFunc(time.Until(o.ExpiresAt), func() {
+			m.send(ctx, command{kind: commandExpire, orderID: o.ID})
+		})
+	}
+	m.book.add(e)
+}
+
+func (m *market) unbook(orderID string) {
+	if e := m.book.remove(orderID); e != nil && e.expiry != nil {
+		e.expiry.Stop()
+	}
+}
+
+// amend переставляет измененный заказ. Уменьшение объема сохраняет место в очереди,
+// новая цена или больший объем ставят заказ в конец очереди, как новый
+func (m *market) amend(ctx context.Context, orderID string) {
+	e := m.book.get(orderID)
+	if e == nil {
+		m.submit(ctx, orderID)
+		return
+	}
+
+	o, err := m.engine.repo.GetByID(ctx, orderID)
+	if err != nil && !errors.Is(err, domain.ErrOrderNotFound) {
+		m.unbook(orderID)
+		if ctx.Err() == nil {
+			m.engine.logger.Error("failed to load amended order",
+				zap.String("order_id", orderID),
+				zap.String("market_id", m.id),
+				zap.Error(err),
+			)
+			m.retryLater(ctx, orderID)
+		}
+		return
+	}
+	if err != nil || !isMatchable(o) {
+		m.unbook(orderID)
+		return
+	}
+
+	if o.Price.Equal(e.price) && !o.Quantity.GreaterThan(e.quantity) {
+		e.quantity = o.Quantity
+		return
+	}
+	m.unbook(orderID)
+	m.submit(ctx, orderID)
+}
+
+// canFill встречных заказов по допустимым для taker ценам хватает на весь его остаток.
+// Книга не хранит объемы, поэтому остатки читаются из хранилища. Объемы reduce-only заказов
+// ограничиваются позициями владельцев так же, как при сделке: reduce-only taker, которому позиция
+// не дает исполниться целиком, сразу не проходит
+func (m *market) canFill(ctx context.Context, taker *domain.Order) (bool, error) {
+	need, err := m.reducible(ctx, taker)
+	if err != nil {
+		return false, err
+	}
+	if need.LessThan(taker.RemainingQuantity()) {
+		return false, nil
+	}
+	now := time.Now()
+
+	var available decimal.Decimal
+	m.book.each(opposite(taker.Side), func(e *entry) bool {
+		if !crosses(taker, e.price) {
+			return false
+		}
+
+		var maker *domain.Order
+		maker, err = m.engine.repo.GetByID(ctx, e.orderID)
+		if errors.Is(err, domain.ErrOrderNotFound) {
+			err = nil
+			return true
+		}
+		if err != nil {
+			return false
+		}
+
+		if maker.IsFillable() && !maker.IsExpired(now) {
+			var quantity decimal.Decimal
+			if quantity, err = m.reducible(ctx, maker); err != nil {
+				return false
+			}
+			available = available.Add(quantity)
+		}
+		return available.LessThan(need)
+	})
+	if err != nil {
+		return false, err
+	}
+	return available.GreaterThanOrEqual(need), nil
+}
+
+// expire снимает с книги GTD заказ по таймеру истечения
+func (m *market) expire(ctx context.Context, orderID string) {
+	o, err := m.engine.repo.GetByID(ctx, orderID)
+	if errors.Is(err, domain.ErrOrderNotFound) {
+		m.unbook(orderID)
+		return
+	}
+	if err != nil {
+		if ctx.Err() == nil {
+			m.engine.logger.Error("failed to load expired order",
+				zap.String("order_id", orderID),
+				zap.String("market_id", m.id),
+				zap.Error(err),
+			)
+			m.retryLater(ctx, orderID)
+		}
+		return
+	}
+
+	// завершенный заказ снимется по своему событию
+	if !o.IsExpired(time.Now()) {
+		return
+	}
+	m.unbook(orderID)
+	m.expireOrder(ctx, o)
+}
+
+// trade исполняет сделку taker с первым заказом очереди maker и сохраняет оба заказа атомарно.
+// Заказ maker с истекшим сроком переводится в EXPIRED без сделки, объем reduce-only заказов
+// ограничивается позицией владельца, а не сокращающий ее reduce-only заказ отменяется.
+// Возвращает актуальное состояние taker; false - сводить taker дальше нельзя: он снят
+// или сделку не удалось выполнить (тогда заказ будет обработан повторно)
+func (m *market) trade(ctx context.Context, taker *domain.Order, maker *entry) (*domain.Order, bool) {
+	logger := m.engine.logger
+
+	for attempt := 1; ; attempt++ {
+		makerOrder, err := m.engine.repo.GetByID(ctx, maker.orderID)
+		if errors.Is(err, domain.ErrOrderNotFound) || (err == nil && !makerOrder.IsFillable()) {
+			// заказ снят, событие об этом еще не дошло
+			m.unbook(maker.orderID)
+			return taker, true
+		}
+		if err != nil {
+			return m.storageFailed(ctx, taker, "failed to load maker order", err)
+		}
+		if makerOrder.IsExpired(time.Now()) {
+			// таймер истечения еще не сработал, с таким заказом сводить нельзя
+			m.unbook(maker.orderID)
+			m.expireOrder(ctx, makerOrder)
+			return taker, true
+		}
+
+		// reduce-only заказы проверяются по позиции на момент сделки: ее могли сократить другие исполнения
+		makerQuantity, err := m.reducible(ctx, makerOrder)
+		if err != nil {
+			return m.storageFailed(ctx, taker, "failed to load maker position", err)
+		}
+		if makerQuantity.IsZero() {
+			m.unbook(maker.orderID)
+			m.transition(ctx, makerOrder, domain.OrderStatusCancelled, reduceOnlyCancelReason)
+			return taker, true
+		}
+		takerQuantity, err := m.reducible(ctx, taker)
+		if err != nil {
+			return m.storageFailed(ctx, taker, "failed to load taker position", err)
+		}
+		if takerQuantity.IsZero() {
+			m.transition(ctx, taker, domain.OrderStatusCancelled, reduceOnlyCancelReason)
+			return taker, false
+		}
+		quantity := decimal.Min(takerQuantity, makerQuantity)
+		trade := domain.Trade{
+			ID:           uuid.NewString(),
+			MarketID:     m.id,
+			Price:        makerOrder.Price,
+			Quantity:     quantity,
+			TakerOrderID: taker.ID,
+			MakerOrderID: makerOrder.ID,
+			TakerSide:    taker.Side,
+			At:           time.Now(),
+		}
+		fill := domain.Fill{TradeID: trade.ID, Price: trade.Price, Quantity: trade.Quantity, At: trade.At}
+
+		if err := makerOrder.ApplyFill(fill, domain.ActorSystem); err != nil {
+			logger.Error("cannot fill maker order, removing it from book",
+				zap.String("order_id", makerOrder.ID),
+				zap.Error(err),
+			)
+			m.unbook(maker.orderID)
+			return taker, true
+		}
+		if err := taker.ApplyFill(fill, domain.ActorSystem); err != nil {
+			// изменения maker не сохранены; taker будет обработан заново со свежей копией
+			return m.storageFailed(ctx, taker, "cannot fill taker order", err)
+		}
+
+		err = m.engine.repo.UpdateMany(ctx, makerOrder, taker)
+		if err == nil {
+			if !makerOrder.IsFillable() {
+				m.unbook(maker.orderID)
+			}
+			m.engine.publishTrade(ctx, trade)
+			return taker, true
+		}
+
+		// изменения taker не сохранены, дальше работаем со свежей копией
+		fresh, loadErr := m.engine.repo.GetByID(ctx, taker.ID)
+		if loadErr != nil {
+			return m.storageFailed(ctx, taker, "failed to reload taker order", loadErr)
+		}
+		taker = fresh
+
+		if !errors.Is(err, domain.ErrVersionConflict) {
+			return m.storageFailed(ctx, taker, "failed to save trade", err)
+		}
+		if !isMatchable(taker) {
+			return taker, false
+		}
+		if attempt == maxTradeAttempts {
+			return m.storageFailed(ctx, taker, "trade version conflict, giving up", err)
+		}
+	}
+}
+
+// reducible сколько заказ может исполнить сейчас: для reduce-only - не больше позиции владельца на рынке
+func (m *market) reducible(ctx context.Context, o *domain.Order) (decimal.Decimal, error) {
+	if !o.ReduceOnly {
+		return o.RemainingQuantity(), nil
+	}
+	position, err := storage.LoadPosition(ctx, m.engine.repo, o.UserID, o.MarketID, o.ID)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return o.ReducibleQuantity(position.Net), nil
+}
+
+// transition меняет статус заказа вне сделки
+func (m *market) transition(ctx context.Context, o *domain.Order, to domain.OrderStatus, reason string) bool {
+	if err := o.TransitionTo(to, reason, domain.ActorSystem, time.Now()); err != nil {
+		m.engine.logger.Error("invalid matching transition",
+			zap.String("order_id", o.ID),
+			zap.Error(err),
+		)
+		return false
+	}
+	return m.save(ctx, o)
+}
+
+// expireOrder переводит GTD заказ с истекшим сроком в EXPIRED
+func (m *market) expireOrder(ctx context.Context, o *domain.Order) bool {
+	if err := o.Expire(time.Now()); err != nil {
+		m.engine.logger.Error("cannot expire order",
+			zap.String("order_id", o.ID),
+			zap.Error(err),
+		)
+		return false
+	}
+	return m.save(ctx, o)
+}
+
+// save сохраняет заказ. Конфликт версии означает, что заказ изменили параллельно;
+// тогда он обрабатывается заново
+func (m *market) save(ctx context.Context, o *domain.Order) bool {
+	if err := m.engine.repo.Update(ctx, o); err != nil {
+		if errors.Is(err, domain.ErrVersionConflict) {
+			// своя очередь может быть полна, поэтому не из горутины рынка
+			go m.send(ctx, command{kind: commandSubmit, orderID: o.ID})
+			return false
+		}
+		m.storageFailed(ctx, o, "failed to save order status", err)
+		return false
+	}
+	return true
+}
+
+func (m *market) storageFailed(ctx context.Context, o *domain.Order, msg string, err error) (*domain.Order, bool) {
+	if ctx.Err() == nil {
+		m.engine.logger.Error(msg,
+			zap.String("order_id", o.ID),
+			zap.String("market_id", m.id),
+			zap.Error(err),
+		)
+		m.retryLater(ctx, o.ID)
+	}
+	return o, false
+}
+
+// isMatchable заказ может быть сведен движком: LIMIT, MARKET или активированный стоп-заказ
+// с известной стороной, еще не завершенный
+func isMatchable(o *domain.Order) bool {
+	if t := o.ExecutionType(); t != domain.OrderTypeLimit && t != domain.OrderTypeMarket {
+		return false
+	}
+	if o.Side != domain.OrderSideBuy && o.Side != domain.OrderSideSell {
+		return false
+	}
+	return o.IsFillable()
+}
@@ -0,0 +1,294 @@
+package matching
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+
+	"github.com/chilly266futon/orderService/internal/domain"
+	"github.com/chilly266futon/orderService/internal/outbox"
+	"github.com/chilly266futon/orderService/internal/storage"
+	"github.com/chilly266futon/orderService/internal/storage/storagetest"
+)
+
+func newTestMarket(repo storage.OrderRepository, marketID string) *market {
+	engine := NewEngine(repo, outbox.NewHub(0), nil, storage.NewMemoryLease(), Config{}, zap.NewNop())
+	return newMarket(marketID, engine)
+}
+
+func addOrder(t *testing.T, repo storage.OrderRepository, marketID string, side domain.OrderSide, price string) *domain.Order {
+	t.Helper()
+
+	o := storagetest.NewOrder("user-1")
+	o.MarketID = marketID
+	o.Side = side
+	o.Price = decimal.RequireFromString(price)
+	if err := repo.Add(context.Background(), o); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	return o
+}
+
+func TestTradeExpiresRestingMaker(t *testing.T) {
+	ctx := context.Background()
+	repo := storage.NewMemoryOrderRepository()
+	m := newTestMarket(repo, "market-1")
+
+	// срок GTD истек, но таймер книги еще не сработал
+	expired := storagetest.NewOrder("maker")
+	expired.MarketID = m.id
+	expired.Side = domain.OrderSideSell
+	expired.Status = domain.OrderStatusOpen
+	expired.Price = decimal.RequireFromString("100")
+	expired.TimeInForce = domain.TimeInForceGTD
+	expired.ExpiresAt = time.Now().Add(-time.Minute)
+	if err := repo.Add(ctx, expired); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	m.book.add(&entry{orderID: expired.ID, side: expired.Side, price: expired.Price, quantity: expired.Quantity})
+
+	taker := addOrder(t, repo, m.id, domain.OrderSideBuy, "100")
+	m.submit(ctx, taker.ID)
+
+	got, err := repo.GetByID(ctx, expired.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.Status != domain.OrderStatusExpired || !got.FilledQuantity.IsZero() {
+		t.Fatalf("maker = %s filled %s, want EXPIRED without fills", got.Status, got.FilledQuantity)
+	}
+	if m.book.contains(expired.ID) {
+		t.Fatal("expired maker is still in the book")
+	}
+
+	got, err = repo.GetByID(ctx, taker.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.Status != domain.OrderStatusOpen || !got.FilledQuantity.IsZero() {
+		t.Fatalf("taker = %s filled %s, want OPEN without fills", got.Status, got.FilledQuantity)
+	}
+	if !m.book.contains(taker.ID) {
+		t.Fatal("taker remainder is not in the book")
+	}
+}
+
+func TestTradeMatchesNextMakerAfterExpired(t *testing.T) {
+	ctx := context.Background()
+	repo := storage.NewMemoryOrderRepository()
+	m := newTestMarket(repo, "market-1")
+
+	expired := storagetest.NewOrder("maker")
+	expired.MarketID = m.id
+	expired.Side = domain.OrderSideSell
+	expired.Status = domain.OrderStatusOpen
+	expired.Price = decimal.RequireFromString("99")
+	expired.TimeInForce = domain.TimeInForceGTD
+	expired.ExpiresAt = time.Now().Add(-time.Minute)
+	if err := repo.Add(ctx, expired); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	m.book.add(&entry{orderID: expired.ID, side: expired.Side, price: expired.Price, quantity: expired.Quantity})
+
+	maker := addOrder(t, repo, m.id, domain.OrderSideSell, "100")
+	m.submit(ctx, maker.ID)
+
+	taker := addOrder(t, repo, m.id, domain.OrderSideBuy, "100")
+	m.submit(ctx, taker.ID)
+
+	got, err := repo.GetByID(ctx, taker.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.Status != domain.OrderStatusFilled || !got.AvgFillPrice.Equal(maker.Price) {
+		t.Fatalf("taker = %s at %s, want FILLED at %s", got.Status, got.AvgFillPrice, maker.Price)
+	}
+
+	got, err = repo.GetByID(ctx, expired.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.Status != domain.OrderStatusExpired {
+		t.Fatalf("expired maker status = %s, want EXPIRED", got.Status)
+	}
+}
+
+// addPosition записывает пользователю исполненный заказ, образующий позицию на рынке
+func addPosition(t *testing.T, repo storage.OrderRepository, userID, marketID string, side domain.OrderSide, quantity string) {
+	t.Helper()
+
+	o := storagetest.NewOrder(userID)
+	o.MarketID = marketID
+	o.Side = side
+	o.Status = domain.OrderStatusFilled
+	o.Quantity = decimal.RequireFromString(quantity)
+	o.FilledQuantity = o.Quantity
+	if err := repo.Add(context.Background(), o); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+}
+
+func TestTradeCapsReduceOnlyMakerByPosition(t *testing.T) {
+	ctx := context.Background()
+	repo := storage.NewMemoryOrderRepository()
+	m := newTestMarket(repo, "market-1")
+
+	// заказ принят при позиции 0.25, затем позиция сократилась до 0.1 другим исполнением
+	addPosition(t, repo, "maker", m.id, domain.OrderSideBuy, "0.1")
+	maker := storagetest.NewOrder("maker")
+	maker.MarketID = m.id
+	maker.Side = domain.OrderSideSell
+	maker.Price = decimal.RequireFromString("100")
+	maker.ReduceOnly = true
+	if err := repo.Add(ctx, maker); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	m.submit(ctx, maker.ID)
+
+	taker := addOrder(t, repo, m.id, domain.OrderSideBuy, "100")
+	m.submit(ctx, taker.ID)
+
+	got, err := repo.GetByID(ctx, maker.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.Status != domain.OrderStatusCancelled || !got.FilledQuantity.Equal(decimal.RequireFromString("0.1")) {
+		t.Fatalf("maker = %s filled %s, want CANCELLED filled 0.1", got.Status, got.FilledQuantity)
+	}
+
+	got, err = repo.GetByID(ctx, taker.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.Status != domain.OrderStatusPartiallyFilled || !got.FilledQuantity.Equal(decimal.RequireFromString("0.1")) {
+		t.Fatalf("taker = %s filled %s, want PARTIALLY_FILLED filled 0.1", got.Status, got.FilledQuantity)
+	}
+	if !m.book.contains(taker.ID) || m.book.contains(maker.ID) {
+		t.Fatal("book must hold the taker remainder only")
+	}
+}
+
+func TestTradeCancelsReduceOnlyTakerWithoutPosition(t *testing.T) {
+	ctx := context.Background()
+	repo := storage.NewMemoryOrderRepository()
+	m := newTestMarket(repo, "market-1")
+
+	maker := addOrder(t, repo, m.id, domain.OrderSideSell, "100")
+	m.submit(ctx, maker.ID)
+
+	// позиции нет: покупка увеличила бы ее
+	taker := storagetest.NewOrder("taker")
+	taker.MarketID = m.id
+	taker.Price = decimal.RequireFromString("100")
+	taker.ReduceOnly = true
+	if err := repo.Add(ctx, taker); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	m.submit(ctx, taker.ID)
+
+	got, err := repo.GetByID(ctx, taker.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.Status != domain.OrderStatusCancelled || !got.FilledQuantity.IsZero() {
+		t.Fatalf("taker = %s filled %s, want CANCELLED without fills", got.Status, got.FilledQuantity)
+	}
+
+	got, err = repo.GetByID(ctx, maker.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.Status != domain.OrderStatusOpen || !m.book.contains(maker.ID) {
+		t.Fatalf("maker = %s, want OPEN and still in the book", got.Status)
+	}
+}
+
+func TestFillOrKillCountsReduceOnlyMakerByPosition(t *testing.T) {
+	tests := []struct {
+		name       string
+		extra      string // объем обычного встречного заказа, пусто - нет
+		wantStatus domain.OrderStatus
+		wantFilled string
+	}{
+		{"reduce-only maker alone is not enough", "", domain.OrderStatusCancelled, "0"},
+		{"regular maker covers the rest", "0.15", domain.OrderStatusFilled, "0.25"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := storage.NewMemoryOrderRepository()
+			m := newTestMarket(repo, "market-1")
+
+			// объем заказа 0.25, но позиция владельца только 0.1
+			addPosition(t, repo, "maker", m.id, domain.OrderSideBuy, "0.1")
+			maker := storagetest.NewOrder("maker")
+			maker.MarketID = m.id
+			maker.Side = domain.OrderSideSell
+			maker.Price = decimal.RequireFromString("100")
+			maker.ReduceOnly = true
+			if err := repo.Add(ctx, maker); err != nil {
+				t.Fatalf("Add() error = %v", err)
+			}
+			m.submit(ctx, maker.ID)
+
+			if tt.extra != "" {
+				other := addOrder(t, repo, m.id, domain.OrderSideSell, "100")
+				other.Quantity = decimal.RequireFromString(tt.extra)
+				if err := repo.Update(ctx, other); err != nil {
+					t.Fatalf("Update() error = %v", err)
+				}
+				m.submit(ctx, other.ID)
+			}
+
+			taker := storagetest.NewOrder("taker")
+			taker.MarketID = m.id
+			taker.Price = decimal.RequireFromString("100")
+			taker.TimeInForce = domain.TimeInForceFOK
+			if err := repo.Add(ctx, taker); err != nil {
+				t.Fatalf("Add() error = %v", err)
+			}
+			m.submit(ctx, taker.ID)
+
+			got, err := repo.GetByID(ctx, taker.ID)
+			if err != nil {
+				t.Fatalf("GetByID() error = %v", err)
+			}
+			if got.Status != tt.wantStatus || !got.FilledQuantity.Equal(decimal.RequireFromString(tt.wantFilled)) {
+				t.Fatalf("taker = %s filled %s, want %s filled %s", got.Status, got.FilledQuantity, tt.wantStatus, tt.wantFilled)
+			}
+		})
+	}
+}
+
+func TestFillOrKillRejectsReduceOnlyTakerBeyondPosition(t *testing.T) {
+	ctx := context.Background()
+	repo := storage.NewMemoryOrderRepository()
+	m := newTestMarket(repo, "market-1")
+
+	maker := addOrder(t, repo, m.id, domain.OrderSideSell, "100")
+	m.submit(ctx, maker.ID)
+
+	// короткая позиция 0.1, а reduce-only покупка на 0.25 целиком исполниться не может
+	addPosition(t, repo, "taker", m.id, domain.OrderSideSell, "0.1")
+	taker := storagetest.NewOrder("taker")
+	taker.MarketID = m.id
+	taker.Price = decimal.RequireFromString("100")
+	taker.TimeInForce = domain.TimeInForceFOK
+	taker.ReduceOnly = true
+	if err := repo.Add(ctx, taker); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	m.submit(ctx, taker.ID)
+
+	got, err := repo.GetByID(ctx, taker.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.Status != domain.OrderStatusCancelled || !got.FilledQuantity.IsZero() {
+		t.Fatalf("taker = %s filled %s, want CANCELLED without fills", got.Status, got.FilledQuantity)
+	}
+}
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"context"
+	"expvar"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// Metrics счетчики gRPC-вызовов в формате expvar.
+// Публикуется через expvar.Publish и отдается обработчиком expvar.Handler
+type Metrics struct {
+	// requests число завершенных вызовов по ключу "метод код"
+	requests expvar.Map
+	// durations суммарная длительность вызовов по методу, в секундах
+	durations expvar.Map
+	inFlight  expvar.Int
+}
+
+func NewMetrics() *Metrics {
+	m := &Metrics{}
+	m.requests.Init()
+	m.durations.Init()
+	return m
+}
+
+func (m *Metrics) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		done := m.begin(info.FullMethod)
+		resp, err := handler(ctx, req)
+		done(err)
+		return resp, err
+	}
+}
+
+func (m *Metrics) Stream() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		done := m.begin(info.FullMethod)
+		err := handler(srv, ss)
+		done(err)
+		return err
+	}
+}
+
+func (m *Metrics) begin(method string) func(err error) {
+	start := time.Now()
+	m.inFlight.Add(1)
+
+	return func(err error) {
+		m.inFlight.Add(-1)
+		m.requests.Add(method+" "+status.Code(err).String(), 1)
+		m.durations.AddFloat(method, time.Since(start).Seconds())
+	}
+}
+
+// String реализует expvar.Var
+func (m *Metrics) String() string {
+	return fmt.Sprintf(`{"requests": %s, "duration_seconds": %s, "in_flight": %s}`,
+		m.requests.String(), m.durations.String(), m.inFlight.String())
+}
@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"sort"
+
+	"google.golang.org/grpc"
+)
+
+// Stage слой конвейера перехватчиков. Значение задает место слоя: меньшее - ближе к сети.
+//
+// Порядок выбран так:
+//   - recovery снаружи всех, поэтому паника в любом слое или обработчике превращается в codes.Internal;
+//   - trace до остальных, поэтому trace ID есть в логах всех слоев, включая валидацию;
+//   - auth до rate limit, чтобы лимиты по пользователю считались по проверенной личности;
+//   - rate limit до validation, чтобы отбрасывать лишние запросы до разбора правил;
+//   - logging и metrics ближе всего к обработчику и учитывают только прошедшие проверки запросы
+type Stage int
+
+const (
+	StageRecovery Stage = iota
+	StageTrace
+	StageAuth
+	StageRateLimit
+	StageValidation
+	StageLogging
+	StageMetrics
+)
+
+func (s Stage) String() string {
+	switch s {
+	case StageRecovery:
+		return "recovery"
+	case StageTrace:
+		return "trace"
+	case StageAuth:
+		return "auth"
+	case StageRateLimit:
+		return "rate_limit"
+	case StageValidation:
+		return "validation"
+	case StageLogging:
+		return "logging"
+	case StageMetrics:
+		return "metrics"
+	default:
+		return "unknown"
+	}
+}
+
+// Pipeline собирает unary- и потоковые перехватчики в порядке Stage независимо от порядка Use
+type Pipeline struct {
+	unary  map[Stage]grpc.UnaryServerInterceptor
+	stream map[Stage]grpc.StreamServerInterceptor
+}
+
+func NewPipeline() *Pipeline {
+	return &Pipeline{
+		unary:  make(map[Stage]grpc.UnaryServerInterceptor),
+		stream: make(map[Stage]grpc.StreamServerInterceptor),
+	}
+}
+
+// Use задает перехватчики слоя, заменяя ранее заданные. Если stream nil, используется UnaryToStream(unary)
+func (p *Pipeline) Use(stage Stage, unary grpc.UnaryServerInterceptor, stream grpc.StreamServerInterceptor) *Pipeline {
+	if stream == nil {
+		stream = UnaryToStream(unary)
+	}
+	p.unary[stage] = unary
+	p.stream[stage] = stream
+	return p
+}
+
+// Stages возвращает заданные слои в порядке выполнения
+func (p *Pipeline) Stages() []Stage {
+	stages := make([]Stage, 0, len(p.unary))
+	for stage := range p.unary {
+		stages = append(stages, stage)
+	}
+	sort.Slice(stages, func(i, j int) bool { return stages[i] < stages[j] })
+	return stages
+}
+
+func (p *Pipeline) UnaryInterceptors() []grpc.UnaryServerInterceptor {
+	stages := p.Stages()
+	result := make([]grpc.UnaryServerInterceptor, len(stages))
+	for i, stage := range stages {
+		result[i] = p.unary[stage]
+	}
+	return result
+}
+
+func (p *Pipeline) StreamInterceptors() []grpc.StreamServerInterceptor {
+	stages := p.Stages()
+	result := make([]grpc.StreamServerInterceptor, len(stages))
+	for i, stage := range stages {
+		result[i] = p.stream[stage]
+	}
+	return result
+}
+
+// ServerOptions возвращает опции сервера с цепочками перехватчиков.
+// Других перехватчиков в опциях сервера быть не должно, иначе порядок нарушится
+func (p *Pipeline) ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(p.UnaryInterceptors()...),
+		grpc.ChainStreamInterceptor(p.StreamInterceptors()...),
+	}
+}
@@ -0,0 +1,171 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/chilly266futon/exchange-shared/pkg/interceptors"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/chilly266futon/orderService/gen/pb/order"
+	"github.com/chilly266futon/orderService/internal/middleware"
+)
+
+// stages все слои кроме recovery в обратном порядке: Use вызывается не в порядке выполнения
+var stages = []middleware.Stage{
+	middleware.StageMetrics,
+	middleware.StageLogging,
+	middleware.StageValidation,
+	middleware.StageRateLimit,
+	middleware.StageAuth,
+	middleware.StageTrace,
+}
+
+// recorder записывает порядок вызова слоев и паникует в слое panicAt
+type recorder struct {
+	panicAt string
+	calls   []string
+	mu      sync.Mutex
+}
+
+func (r *recorder) enter(name string) {
+	r.mu.Lock()
+	r.calls = append(r.calls, name)
+	r.mu.Unlock()
+
+	if name == r.panicAt {
+		panic(name + " failed")
+	}
+}
+
+// take возвращает записанные вызовы и очищает запись
+func (r *recorder) take() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	calls := r.calls
+	r.calls = nil
+	return calls
+}
+
+func (r *recorder) unary(name string, next grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if next != nil {
+			return next(ctx, req, info, func(ctx context.Context, req any) (any, error) {
+				r.enter(name)
+				return handler(ctx, req)
+			})
+		}
+		r.enter(name)
+		return handler(ctx, req)
+	}
+}
+
+func (r *recorder) stream(name string) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		r.enter(name)
+		return handler(srv, ss)
+	}
+}
+
+// recordingOrderServer отмечает вызов обработчика
+type recordingOrderServer struct {
+	pb.UnimplementedOrderServiceServer
+	rec *recorder
+}
+
+func (s recordingOrderServer) GetOrder(context.Context, *pb.GetOrderRequest) (*pb.GetOrderResponse, error) {
+	s.rec.enter("handler")
+	return &pb.GetOrderResponse{}, nil
+}
+
+func (s recordingOrderServer) SubscribeOrderUpdates(*pb.SubscribeOrderUpdatesRequest, grpc.ServerStreamingServer[pb.OrderUpdate]) error {
+	s.rec.enter("handler")
+	return nil
+}
+
+// newRecordedPipeline собирает конвейер из записывающих слоев. Recovery настоящий,
+// stream-перехватчик для него строится из unary, как в main
+func newRecordedPipeline(rec *recorder) *middleware.Pipeline {
+	pipeline := middleware.NewPipeline()
+	for _, stage := range stages {
+		pipeline.Use(stage, rec.unary(stage.String(), nil), rec.stream(stage.String()))
+	}
+	recovery := rec.unary(middleware.StageRecovery.String(), interceptors.UnaryPanicRecoveryInterceptor(zap.NewNop()))
+	return pipeline.Use(middleware.StageRecovery, recovery, nil)
+}
+
+func wantOrder() []string {
+	want := []string{middleware.StageRecovery.String()}
+	for _, stage := range slices.Backward(stages) {
+		want = append(want, stage.String())
+	}
+	return append(want, "handler")
+}
+
+func TestPipelineStageOrder(t *testing.T) {
+	rec := &recorder{}
+	pipeline := newRecordedPipeline(rec)
+
+	wantStages := []middleware.Stage{middleware.StageRecovery}
+	for _, stage := range slices.Backward(stages) {
+		wantStages = append(wantStages, stage)
+	}
+	if got := pipeline.Stages(); !slices.Equal(got, wantStages) {
+		t.Fatalf("Stages() = %v, want %v", got, wantStages)
+	}
+
+	client := newOrderClient(t, pipeline, recordingOrderServer{rec: rec})
+
+	if _, err := client.GetOrder(context.Background(), &pb.GetOrderRequest{}); err != nil {
+		t.Fatalf("GetOrder() error = %v", err)
+	}
+	if got := rec.take(); !slices.Equal(got, wantOrder()) {
+		t.Fatalf("unary call order = %v, want %v", got, wantOrder())
+	}
+
+	stream, err := client.SubscribeOrderUpdates(context.Background(), &pb.SubscribeOrderUpdatesRequest{})
+	if err != nil {
+		t.Fatalf("SubscribeOrderUpdates() error = %v", err)
+	}
+	if _, err := stream.Recv(); !errors.Is(err, io.EOF) {
+		t.Fatalf("Recv() error = %v, want %v", err, io.EOF)
+	}
+	if got := rec.take(); !slices.Equal(got, wantOrder()) {
+		t.Fatalf("stream call order = %v, want %v", got, wantOrder())
+	}
+}
+
+func TestPipelinePanicRecovery(t *testing.T) {
+	targets := []string{"handler"}
+	for _, stage := range stages {
+		targets = append(targets, stage.String())
+	}
+
+	for _, target := range targets {
+		t.Run(target, func(t *testing.T) {
+			rec := &recorder{panicAt: target}
+			client := newOrderClient(t, newRecordedPipeline(rec), recordingOrderServer{rec: rec})
+
+			_, err := client.GetOrder(context.Background(), &pb.GetOrderRequest{})
+			if status.Code(err) != codes.Internal {
+				t.Fatalf("GetOrder() error = %v, want code %v", err, codes.Internal)
+			}
+
+			stream, err := client.SubscribeOrderUpdates(context.Background(), &pb.SubscribeOrderUpdatesRequest{})
+			if err != nil {
+				t.Fatalf("SubscribeOrderUpdates() error = %v", err)
+			}
+			if _, err := stream.Recv(); status.Code(err) != codes.Internal {
+				t.Fatalf("Recv() error = %v, want code %v", err, codes.Internal)
+			}
+		})
+	}
+}
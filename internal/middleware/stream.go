@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// wrappedStream серверный поток с подмененным контекстом
+type wrappedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *wrappedStream) Context() context.Context {
+	return s.ctx
+}
+
+// WithContext возвращает поток, у которого Context() возвращает ctx
+func WithContext(ss grpc.ServerStream, ctx context.Context) grpc.ServerStream {
+	if ws, ok := ss.(*wrappedStream); ok {
+		return &wrappedStream{ServerStream: ws.ServerStream, ctx: ctx}
+	}
+	return &wrappedStream{ServerStream: ss, ctx: ctx}
+}
+
+// UnaryToStream превращает unary-перехватчик в потоковый. Перехватчик вызывается один раз на поток
+// с req == nil, а контекст, переданный им в handler, становится контекстом потока.
+// Подходит для перехватчиков, работающих только с контекстом и методом: trace ID, восстановление
+// после паники, rate limiting, логирование. Сообщения потока проверяет StreamValidator
+func UnaryToStream(interceptor grpc.UnaryServerInterceptor) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		unaryInfo := &grpc.UnaryServerInfo{
+			Server:     srv,
+			FullMethod: info.FullMethod,
+		}
+
+		_, err := interceptor(ss.Context(), nil, unaryInfo, func(ctx context.Context, _ any) (any, error) {
+			return nil, handler(srv, WithContext(ss, ctx))
+		})
+		return err
+	}
+}
@@ -0,0 +1,69 @@
+package middleware_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/chilly266futon/exchange-shared/pkg/interceptors"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	pb "github.com/chilly266futon/orderService/gen/pb/order"
+	"github.com/chilly266futon/orderService/internal/middleware"
+)
+
+// panickingOrderServer паникует в потоковом обработчике
+type panickingOrderServer struct {
+	pb.UnimplementedOrderServiceServer
+}
+
+func (panickingOrderServer) SubscribeOrderUpdates(*pb.SubscribeOrderUpdatesRequest, grpc.ServerStreamingServer[pb.OrderUpdate]) error {
+	panic("subscription failed")
+}
+
+// newOrderClient поднимает сервер с перехватчиками pipeline в памяти процесса
+func newOrderClient(t *testing.T, pipeline *middleware.Pipeline, srv pb.OrderServiceServer) pb.OrderServiceClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(pipeline.ServerOptions()...)
+	pb.RegisterOrderServiceServer(server, srv)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("grpc.NewClient() error = %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return pb.NewOrderServiceClient(conn)
+}
+
+func TestStreamPanicRecovery(t *testing.T) {
+	// слои регистрируются так же, как в main: восстановление потоков получается из unary-перехватчика
+	pipeline := middleware.NewPipeline().
+		Use(middleware.StageTrace, interceptors.TraceIDInterceptor(), nil).
+		Use(middleware.StageRecovery, interceptors.UnaryPanicRecoveryInterceptor(zap.NewNop()), nil)
+
+	client := newOrderClient(t, pipeline, panickingOrderServer{})
+
+	stream, err := client.SubscribeOrderUpdates(context.Background(), &pb.SubscribeOrderUpdatesRequest{UserId: "user-1"})
+	if err != nil {
+		t.Fatalf("SubscribeOrderUpdates() error = %v", err)
+	}
+	_, err = stream.Recv()
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("Recv() error = %v, want code %v", err, codes.Internal)
+	}
+}
@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+
+	"buf.build/go/protovalidate"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/chilly266futon/exchange-shared/pkg/interceptors"
+)
+
+// UnaryValidator проверяет запрос правилами protovalidate
+func UnaryValidator(validator protovalidate.Validator, logger *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := validate(ctx, validator, logger, info.FullMethod, req); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamValidator проверяет правилами protovalidate каждое сообщение, полученное из потока
+func StreamValidator(validator protovalidate.Validator, logger *zap.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &validatingStream{
+			ServerStream: ss,
+			validator:    validator,
+			logger:       logger,
+			method:       info.FullMethod,
+		})
+	}
+}
+
+type validatingStream struct {
+	grpc.ServerStream
+	validator protovalidate.Validator
+	logger    *zap.Logger
+	method    string
+}
+
+func (s *validatingStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return validate(s.Context(), s.validator, s.logger, s.method, m)
+}
+
+func validate(ctx context.Context, validator protovalidate.Validator, logger *zap.Logger, method string, req any) error {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		// на всякий случай, хотя в gRPC все сообщения всегда proto.Message
+		logger.Warn("request is not a proto message", zap.String("type", fmt.Sprintf("%T", req)))
+		return nil
+	}
+
+	if err := validator.Validate(msg); err != nil {
+		logger.Warn("request validation failed",
+			zap.String("trace_id", interceptors.GetTraceID(ctx)),
+			zap.String("method", method),
+			zap.Error(err),
+		)
+		return status.Errorf(codes.InvalidArgument, "invalid request: %v", err)
+	}
+	return nil
+}
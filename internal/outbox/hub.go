@@ -0,0 +1,89 @@
+package outbox
+
+import (
+	"context"
+	"sync"
+
+	"github.com/chilly266futon/orderService/internal/domain"
+	"github.com/chilly266futon/orderService/internal/storage"
+)
+
+const defaultSubscriberBuffer = 256
+
+// Hub раздает события подписчикам процесса. Заполняется Tailer в порядке Sequence.
+// Publish не блокируется: подписчик, не успевший забрать buffer событий, отключается
+type Hub struct {
+	subs   map[*Subscription]struct{}
+	buffer int
+	mu     sync.Mutex
+}
+
+// Subscription подписка на события. Канал Events закрывается при отписке или переполнении
+type Subscription struct {
+	filter   storage.EventFilter
+	events   chan domain.OrderEvent
+	overflow bool
+}
+
+func NewHub(buffer int) *Hub {
+	if buffer <= 0 {
+		buffer = defaultSubscriberBuffer
+	}
+	return &Hub{
+		subs:   make(map[*Subscription]struct{}),
+		buffer: buffer,
+	}
+}
+
+// Subscribe подписывает на события, подходящие под фильтр. AfterSequence и Limit не учитываются
+func (h *Hub) Subscribe(filter storage.EventFilter) *Subscription {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	sub := &Subscription{
+		filter: filter,
+		events: make(chan domain.OrderEvent, h.buffer),
+	}
+	h.subs[sub] = struct{}{}
+	return sub
+}
+
+// Unsubscribe отменяет подписку. Повторный вызов безопасен
+func (h *Hub) Unsubscribe(sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.subs[sub]; ok {
+		delete(h.subs, sub)
+		close(sub.events)
+	}
+}
+
+func (h *Hub) Publish(_ context.Context, event domain.OrderEvent) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.subs {
+		if !sub.filter.Match(event) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			sub.overflow = true
+			delete(h.subs, sub)
+			close(sub.events)
+		}
+	}
+	return nil
+}
+
+func (s *Subscription) Events() <-chan domain.OrderEvent {
+	return s.events
+}
+
+// Overflowed сообщает, что подписка закрыта из-за переполнения буфера.
+// Значение достоверно после закрытия канала Events
+func (s *Subscription) Overflowed() bool {
+	return s.overflow
+}
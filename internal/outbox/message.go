@@ -0,0 +1,51 @@
+package outbox
+
+import (
+	"time"
+
+	"github.com/chilly266futon/orderService/internal/domain"
+)
+
+// Message представление события для внешних получателей
+type Message struct {
+	Sequence       int64     `json:"sequence"`
+	EventID        string    `json:"event_id"`
+	Type           string    `json:"type"`
+	OrderID        string    `json:"order_id"`
+	UserID         string    `json:"user_id"`
+	MarketID       string    `json:"market_id"`
+	Status         string    `json:"status"`
+	FilledQuantity string    `json:"filled_quantity"`
+	Reason         string    `json:"reason,omitempty"`
+	Fill           *Fill     `json:"fill,omitempty"`
+	OccurredAt     time.Time `json:"occurred_at"`
+}
+
+type Fill struct {
+	TradeID  string `json:"trade_id"`
+	Price    string `json:"price"`
+	Quantity string `json:"quantity"`
+}
+
+func NewMessage(event domain.OrderEvent) Message {
+	msg := Message{
+		Sequence:       event.Sequence,
+		EventID:        event.ID,
+		Type:           event.Type.String(),
+		OrderID:        event.OrderID,
+		UserID:         event.UserID,
+		MarketID:       event.MarketID,
+		Status:         event.Status.String(),
+		FilledQuantity: event.FilledQuantity.String(),
+		Reason:         event.Reason,
+		OccurredAt:     event.OccurredAt,
+	}
+	if event.Fill != nil {
+		msg.Fill = &Fill{
+			TradeID:  event.Fill.TradeID,
+			Price:    event.Fill.Price.String(),
+			Quantity: event.Fill.Quantity.String(),
+		}
+	}
+	return msg
+}
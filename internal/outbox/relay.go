@@ -0,0 +1,155 @@
+package outbox
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/chilly266futon/orderService/internal/domain"
+	"github.com/chilly266futon/orderService/internal/storage"
+)
+
+const (
+	defaultPollInterval = time.Second
+	defaultBatchSize    = 100
+)
+
+// Sink получатель событий заказов. Publish может вызываться повторно для одного события,
+// поэтому получатель должен уметь отбрасывать дубли по OrderEvent.ID
+type Sink interface {
+	Publish(ctx context.Context, event domain.OrderEvent) error
+}
+
+type Config struct {
+	PollInterval time.Duration
+	BatchSize    int
+}
+
+// Relay фоновый публикатор событий из outbox.
+// Доставка at-least-once: событие помечается опубликованным только после успешного Publish.
+// Порядок внутри заказа сохраняется: после ошибки публикации остальные события этого заказа
+// в пачке пропускаются и будут отправлены в следующем проходе
+type Relay struct {
+	store  storage.Outbox
+	sink   Sink
+	cfg    Config
+	logger *zap.Logger
+
+	cancel context.CancelFunc
+	done   chan struct{}
+	mu     sync.Mutex
+}
+
+func NewRelay(store storage.Outbox, sink Sink, cfg Config, logger *zap.Logger) *Relay {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = defaultPollInterval
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultBatchSize
+	}
+
+	return &Relay{
+		store:  store,
+		sink:   sink,
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+// Start запускает публикацию в отдельной горутине
+func (r *Relay) Start() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.done = make(chan struct{})
+
+	go func() {
+		defer close(r.done)
+		r.run(ctx)
+	}()
+}
+
+// Stop останавливает публикацию и ждет завершения текущего прохода
+func (r *Relay) Stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.cancel == nil {
+		return
+	}
+	r.cancel()
+	<-r.done
+	r.cancel = nil
+}
+
+func (r *Relay) run(ctx context.Context) {
+	ticker := time.NewTicker(r.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		// полная пачка без ошибок - вероятно, есть еще события, забираем их сразу
+		for {
+			published, err := r.PublishPending(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					r.logger.Error("failed to publish order events", zap.Error(err))
+				}
+				break
+			}
+			if published < r.cfg.BatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// PublishPending публикует одну пачку неопубликованных событий и возвращает число опубликованных
+func (r *Relay) PublishPending(ctx context.Context) (int, error) {
+	events, err := r.store.UnpublishedEvents(ctx, r.cfg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	blocked := make(map[string]struct{})
+	published := make([]int64, 0, len(events))
+	for _, event := range events {
+		if _, ok := blocked[event.OrderID]; ok {
+			continue
+		}
+
+		if err := r.sink.Publish(ctx, event); err != nil {
+			r.logger.Warn("failed to publish order event",
+				zap.Int64("sequence", event.Sequence),
+				zap.String("order_id", event.OrderID),
+				zap.String("type", event.Type.String()),
+				zap.Error(err),
+			)
+			blocked[event.OrderID] = struct{}{}
+			continue
+		}
+		published = append(published, event.Sequence)
+	}
+
+	if err := r.store.MarkPublished(ctx, published); err != nil {
+		return 0, err
+	}
+	if len(blocked) > 0 {
+		// пачка не опубликована целиком, повторим по таймеру
+		return 0, nil
+	}
+	return len(published), nil
+}
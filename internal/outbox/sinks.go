@@ -0,0 +1,110 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/chilly266futon/orderService/internal/domain"
+)
+
+// FileSink дописывает события в файл в формате JSON Lines
+type FileSink struct {
+	file *os.File
+	mu   sync.Mutex
+}
+
+func NewFileSink(path string) (*FileSink, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open outbox file: %w", err)
+	}
+	return &FileSink{file: file}, nil
+}
+
+func (s *FileSink) Publish(_ context.Context, event domain.OrderEvent) error {
+	line, err := json.Marshal(NewMessage(event))
+	if err != nil {
+		return fmt.Errorf("failed to encode order event: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write order event: %w", err)
+	}
+	return s.file.Sync()
+}
+
+func (s *FileSink) Close() error {
+	return s.file.Close()
+}
+
+// MemorySink накапливает события в памяти процесса. Используется в тестах
+type MemorySink struct {
+	events []domain.OrderEvent
+	mu     sync.Mutex
+}
+
+func NewMemorySink() *MemorySink {
+	return &MemorySink{}
+}
+
+func (s *MemorySink) Publish(_ context.Context, event domain.OrderEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.events = append(s.events, event)
+	return nil
+}
+
+// Events возвращает копию полученных событий
+func (s *MemorySink) Events() []domain.OrderEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]domain.OrderEvent{}, s.events...)
+}
+
+// LogSink пишет события в лог
+type LogSink struct {
+	logger *zap.Logger
+}
+
+func NewLogSink(logger *zap.Logger) *LogSink {
+	return &LogSink{logger: logger}
+}
+
+func (s *LogSink) Publish(_ context.Context, event domain.OrderEvent) error {
+	s.logger.Info("order event",
+		zap.Int64("sequence", event.Sequence),
+		zap.String("event_id", event.ID),
+		zap.String("type", event.Type.String()),
+		zap.String("order_id", event.OrderID),
+		zap.String("status", event.Status.String()),
+	)
+	return nil
+}
+
+// fanoutSink публикует событие во все получатели по порядку
+type fanoutSink []Sink
+
+// Fanout объединяет получателей. Событие считается опубликованным, если его приняли все;
+// при ошибке оно будет повторено для всех, поэтому получатели без ошибок ставятся в конец
+func Fanout(sinks ...Sink) Sink {
+	return fanoutSink(sinks)
+}
+
+func (f fanoutSink) Publish(ctx context.Context, event domain.OrderEvent) error {
+	for _, sink := range f {
+		if err := sink.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
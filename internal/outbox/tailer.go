@@ -0,0 +1,128 @@
+package outbox
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/chilly266futon/orderService/internal/storage"
+)
+
+// Tailer читает outbox по Sequence и раздает события в Hub. В отличие от Relay он не помечает события
+// опубликованными и работает в каждом экземпляре, поэтому подписчики любого экземпляра видят события,
+// записанные всеми экземплярами. Хранилище гарантирует, что события видны в порядке Sequence,
+// поэтому курсор - номер последнего разданного события, и Hub получает события без пропусков и по порядку
+type Tailer struct {
+	store  storage.Outbox
+	hub    *Hub
+	cfg    Config
+	logger *zap.Logger
+
+	cancel context.CancelFunc
+	done   chan struct{}
+	mu     sync.Mutex
+}
+
+func NewTailer(store storage.Outbox, hub *Hub, cfg Config, logger *zap.Logger) *Tailer {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = defaultPollInterval
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultBatchSize
+	}
+
+	return &Tailer{
+		store:  store,
+		hub:    hub,
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+// Start запускает чтение в отдельной горутине. События, записанные до старта, в Hub не попадают:
+// подписчики досылают их из хранилища сами
+func (t *Tailer) Start() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.cancel = cancel
+	t.done = make(chan struct{})
+
+	go func() {
+		defer close(t.done)
+		t.run(ctx)
+	}()
+}
+
+// Stop останавливает чтение и ждет завершения текущего прохода
+func (t *Tailer) Stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.cancel == nil {
+		return
+	}
+	t.cancel()
+	<-t.done
+	t.cancel = nil
+}
+
+func (t *Tailer) run(ctx context.Context) {
+	ticker := time.NewTicker(t.cfg.PollInterval)
+	defer ticker.Stop()
+
+	cursor := int64(-1)
+	for {
+		var err error
+		if cursor < 0 {
+			cursor, err = t.store.LastSequence(ctx)
+			if err != nil {
+				cursor = -1
+			}
+		}
+
+		// полная пачка - вероятно, есть еще события, забираем их сразу
+		for err == nil {
+			var n int
+			n, err = t.next(ctx, &cursor)
+			if n < t.cfg.BatchSize {
+				break
+			}
+		}
+		if err != nil && ctx.Err() == nil {
+			t.logger.Error("failed to read order events", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// next раздает одну пачку событий после cursor и сдвигает его
+func (t *Tailer) next(ctx context.Context, cursor *int64) (int, error) {
+	events, err := t.store.EventsAfter(ctx, storage.EventFilter{
+		AfterSequence: *cursor,
+		Limit:         t.cfg.BatchSize,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, event := range events {
+		if err := t.hub.Publish(ctx, event); err != nil {
+			return 0, err
+		}
+		*cursor = event.Sequence
+	}
+	return len(events), nil
+}
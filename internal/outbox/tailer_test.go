@@ -0,0 +1,115 @@
+package outbox_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/chilly266futon/orderService/internal/domain"
+	"github.com/chilly266futon/orderService/internal/outbox"
+	"github.com/chilly266futon/orderService/internal/storage"
+	"github.com/chilly266futon/orderService/internal/storage/storagetest"
+)
+
+func cancelOrder(t *testing.T, repo storage.OrderRepository) *domain.Order {
+	t.Helper()
+
+	o := storagetest.NewOrder(uuid.NewString())
+	if err := repo.Add(context.Background(), o); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if err := o.TransitionTo(domain.OrderStatusCancelled, "cancelled", domain.ActorSystem, time.Now()); err != nil {
+		t.Fatalf("TransitionTo() error = %v", err)
+	}
+	if err := repo.Update(context.Background(), o); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	return o
+}
+
+func receive(t *testing.T, sub *outbox.Subscription) domain.OrderEvent {
+	t.Helper()
+
+	select {
+	case event, ok := <-sub.Events():
+		if !ok {
+			t.Fatal("subscription closed")
+		}
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event received")
+	}
+	return domain.OrderEvent{}
+}
+
+// TestTailerSharedStore два экземпляра с общим хранилищем: каждый видит события,
+// записанные другим, даже если релей ни одного из них события не публиковал
+func TestTailerSharedStore(t *testing.T) {
+	repo := storage.NewMemoryOrderRepository()
+	before := cancelOrder(t, repo)
+
+	hubs := []*outbox.Hub{outbox.NewHub(256), outbox.NewHub(256)}
+	subs := make([]*outbox.Subscription, len(hubs))
+	for i, hub := range hubs {
+		subs[i] = hub.Subscribe(storage.EventFilter{})
+
+		tailer := outbox.NewTailer(repo, hub, outbox.Config{PollInterval: 5 * time.Millisecond, BatchSize: 2}, zap.NewNop())
+		tailer.Start()
+		t.Cleanup(tailer.Stop)
+	}
+
+	// пробные заказы пишутся, пока оба экземпляра не начнут читать outbox
+	probes := make(map[string]struct{})
+	for _, sub := range subs {
+		for {
+			probes[cancelOrder(t, repo).ID] = struct{}{}
+			select {
+			case event := <-sub.Events():
+				if event.OrderID == before.ID {
+					t.Fatal("received event written before start")
+				}
+			case <-time.After(20 * time.Millisecond):
+				continue
+			}
+			break
+		}
+	}
+
+	isProbe := func(event domain.OrderEvent) bool {
+		_, ok := probes[event.OrderID]
+		return ok
+	}
+
+	orders := []*domain.Order{cancelOrder(t, repo), cancelOrder(t, repo), cancelOrder(t, repo)}
+	for i, sub := range subs {
+		var last int64
+		for _, o := range orders {
+			event := receive(t, sub)
+			for isProbe(event) {
+				last = event.Sequence
+				event = receive(t, sub)
+			}
+			if event.OrderID == before.ID {
+				t.Fatalf("hub %d received event written before start", i)
+			}
+			if event.OrderID != o.ID {
+				t.Fatalf("hub %d event order = %s, want %s", i, event.OrderID, o.ID)
+			}
+			if event.Sequence <= last {
+				t.Fatalf("hub %d event sequence %d after %d", i, event.Sequence, last)
+			}
+			last = event.Sequence
+		}
+	}
+
+	unpublished, err := repo.UnpublishedEvents(context.Background(), 0)
+	if err != nil {
+		t.Fatalf("UnpublishedEvents() error = %v", err)
+	}
+	if want := len(orders) + len(probes) + 1; len(unpublished) != want {
+		t.Fatalf("%d unpublished events, want %d: tailer must not mark events published", len(unpublished), want)
+	}
+}
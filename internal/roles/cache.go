@@ -0,0 +1,75 @@
+package roles
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	spotpb "github.com/chilly266futon/exchange-service-contracts/gen/pb/spot"
+)
+
+// maxCacheEntries при превышении из кэша удаляются устаревшие записи
+const maxCacheEntries = 10_000
+
+// CachedProvider кэширует ответы провайдера на ttl, включая ErrUnknownUser. Прочие ошибки не кэшируются
+type CachedProvider struct {
+	provider Provider
+	ttl      time.Duration
+	entries  map[string]cacheEntry
+	mu       sync.Mutex
+	now      func() time.Time
+}
+
+type cacheEntry struct {
+	roles   []spotpb.UserRole
+	unknown bool
+	expires time.Time
+}
+
+func NewCachedProvider(provider Provider, ttl time.Duration) *CachedProvider {
+	return &CachedProvider{
+		provider: provider,
+		ttl:      ttl,
+		entries:  make(map[string]cacheEntry),
+		now:      time.Now,
+	}
+}
+
+func (c *CachedProvider) Roles(ctx context.Context, userID string) ([]spotpb.UserRole, error) {
+	now := c.now()
+
+	c.mu.Lock()
+	entry, ok := c.entries[userID]
+	c.mu.Unlock()
+
+	if !ok || now.After(entry.expires) {
+		roles, err := c.provider.Roles(ctx, userID)
+		unknown := errors.Is(err, ErrUnknownUser)
+		if err != nil && !unknown {
+			return nil, err
+		}
+
+		entry = cacheEntry{roles: roles, unknown: unknown, expires: now.Add(c.ttl)}
+		c.store(userID, entry, now)
+	}
+
+	if entry.unknown {
+		return nil, ErrUnknownUser
+	}
+	return append([]spotpb.UserRole(nil), entry.roles...), nil
+}
+
+func (c *CachedProvider) store(userID string, entry cacheEntry, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.entries) >= maxCacheEntries {
+		for id, e := range c.entries {
+			if now.After(e.expires) {
+				delete(c.entries, id)
+			}
+		}
+	}
+	c.entries[userID] = entry
+}
@@ -0,0 +1,120 @@
+package roles
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	spotpb "github.com/chilly266futon/exchange-service-contracts/gen/pb/spot"
+)
+
+func newTestCache(provider Provider, ttl time.Duration) (*CachedProvider, *time.Time) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewCachedProvider(provider, ttl)
+	c.now = func() time.Time { return now }
+	return c, &now
+}
+
+func TestCachedProviderExpiry(t *testing.T) {
+	ctx := context.Background()
+	stub := &stubProvider{roles: []spotpb.UserRole{spotpb.UserRole_USER_ROLE_VERIFIED}}
+	c, now := newTestCache(stub, time.Minute)
+
+	for range 3 {
+		if _, err := c.Roles(ctx, "user-1"); err != nil {
+			t.Fatalf("Roles() error = %v", err)
+		}
+	}
+	if stub.calls != 1 {
+		t.Fatalf("provider called %d times within ttl, want 1", stub.calls)
+	}
+
+	// роли сменились: до истечения ttl отдаются прежние
+	stub.roles = []spotpb.UserRole{spotpb.UserRole_USER_ROLE_PREMIUM}
+	*now = now.Add(time.Minute)
+	got, err := c.Roles(ctx, "user-1")
+	if err != nil {
+		t.Fatalf("Roles() error = %v", err)
+	}
+	if got[0] != spotpb.UserRole_USER_ROLE_VERIFIED || stub.calls != 1 {
+		t.Fatalf("Roles() at ttl = %v after %d calls, want cached VERIFIED", got, stub.calls)
+	}
+
+	*now = now.Add(time.Nanosecond)
+	got, err = c.Roles(ctx, "user-1")
+	if err != nil {
+		t.Fatalf("Roles() error = %v", err)
+	}
+	if got[0] != spotpb.UserRole_USER_ROLE_PREMIUM || stub.calls != 2 {
+		t.Fatalf("Roles() after ttl = %v after %d calls, want refreshed PREMIUM", got, stub.calls)
+	}
+
+	if _, err := c.Roles(ctx, "user-2"); err != nil {
+		t.Fatalf("Roles() error = %v", err)
+	}
+	if stub.calls != 3 {
+		t.Fatalf("provider called %d times, want a separate entry per user", stub.calls)
+	}
+}
+
+func TestCachedProviderCachesUnknownUser(t *testing.T) {
+	ctx := context.Background()
+	stub := &stubProvider{err: ErrUnknownUser}
+	c, now := newTestCache(stub, time.Minute)
+
+	for range 2 {
+		if _, err := c.Roles(ctx, "user-1"); !errors.Is(err, ErrUnknownUser) {
+			t.Fatalf("Roles() error = %v, want %v", err, ErrUnknownUser)
+		}
+	}
+	if stub.calls != 1 {
+		t.Fatalf("provider called %d times, want unknown user cached", stub.calls)
+	}
+
+	stub.err = nil
+	stub.roles = []spotpb.UserRole{spotpb.UserRole_USER_ROLE_VERIFIED}
+	*now = now.Add(2 * time.Minute)
+	if _, err := c.Roles(ctx, "user-1"); err != nil {
+		t.Fatalf("Roles() after ttl error = %v", err)
+	}
+}
+
+func TestCachedProviderDoesNotCacheErrors(t *testing.T) {
+	ctx := context.Background()
+	errStore := errors.New("store unavailable")
+	stub := &stubProvider{err: errStore}
+	c, _ := newTestCache(stub, time.Minute)
+
+	if _, err := c.Roles(ctx, "user-1"); !errors.Is(err, errStore) {
+		t.Fatalf("Roles() error = %v, want %v", err, errStore)
+	}
+
+	stub.err = nil
+	stub.roles = []spotpb.UserRole{spotpb.UserRole_USER_ROLE_VERIFIED}
+	if _, err := c.Roles(ctx, "user-1"); err != nil {
+		t.Fatalf("Roles() after recovery error = %v", err)
+	}
+	if stub.calls != 2 {
+		t.Fatalf("provider called %d times, want the error not cached", stub.calls)
+	}
+}
+
+func TestCachedProviderReturnsCopy(t *testing.T) {
+	stub := &stubProvider{roles: []spotpb.UserRole{spotpb.UserRole_USER_ROLE_VERIFIED}}
+	c, _ := newTestCache(stub, time.Minute)
+
+	got, err := c.Roles(context.Background(), "user-1")
+	if err != nil {
+		t.Fatalf("Roles() error = %v", err)
+	}
+	got[0] = spotpb.UserRole_USER_ROLE_ADMIN
+
+	got, err = c.Roles(context.Background(), "user-1")
+	if err != nil {
+		t.Fatalf("Roles() error = %v", err)
+	}
+	if got[0] != spotpb.UserRole_USER_ROLE_VERIFIED {
+		t.Fatalf("cached roles changed by the caller: %v", got)
+	}
+}
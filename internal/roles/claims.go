@@ -0,0 +1,30 @@
+package roles
+
+import (
+	"context"
+
+	spotpb "github.com/chilly266futon/exchange-service-contracts/gen/pb/spot"
+
+	"github.com/chilly266futon/orderService/internal/auth"
+)
+
+// ClaimsProvider берет роли из проверенной личности вызова (claims токена или OU сертификата).
+// Если вызывает другой пользователь или ролей spot-service в личности нет, возвращает ErrUnknownUser
+type ClaimsProvider struct{}
+
+func NewClaimsProvider() *ClaimsProvider {
+	return &ClaimsProvider{}
+}
+
+func (p *ClaimsProvider) Roles(ctx context.Context, userID string) ([]spotpb.UserRole, error) {
+	identity, ok := auth.IdentityFromContext(ctx)
+	if !ok || identity.UserID != userID {
+		return nil, ErrUnknownUser
+	}
+
+	roles := ParseRoles(identity.Roles)
+	if len(roles) == 0 {
+		return nil, ErrUnknownUser
+	}
+	return roles, nil
+}
@@ -0,0 +1,71 @@
+package roles
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	spotpb "github.com/chilly266futon/exchange-service-contracts/gen/pb/spot"
+)
+
+// ErrUnknownUser у источника нет ролей пользователя, нужно спросить следующий
+var ErrUnknownUser = errors.New("unknown user")
+
+// Provider возвращает роли пользователя, с которыми проверяется доступность рынков в spot-service
+type Provider interface {
+	Roles(ctx context.Context, userID string) ([]spotpb.UserRole, error)
+}
+
+type chain []Provider
+
+// Chain спрашивает провайдеров по порядку до первого, знающего пользователя
+func Chain(providers ...Provider) Provider {
+	return chain(providers)
+}
+
+func (c chain) Roles(ctx context.Context, userID string) ([]spotpb.UserRole, error) {
+	for _, p := range c {
+		roles, err := p.Roles(ctx, userID)
+		if errors.Is(err, ErrUnknownUser) {
+			continue
+		}
+		return roles, err
+	}
+	return nil, ErrUnknownUser
+}
+
+type static []spotpb.UserRole
+
+// Static возвращает одни и те же роли для любого пользователя. Обычно последний в Chain
+func Static(roles ...spotpb.UserRole) Provider {
+	return static(roles)
+}
+
+func (s static) Roles(context.Context, string) ([]spotpb.UserRole, error) {
+	return append([]spotpb.UserRole(nil), s...), nil
+}
+
+// ParseRole разбирает роль spot-service: "USER_ROLE_PREMIUM" или "premium" без учета регистра
+func ParseRole(name string) (spotpb.UserRole, bool) {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	if !strings.HasPrefix(name, "USER_ROLE_") {
+		name = "USER_ROLE_" + name
+	}
+
+	value, ok := spotpb.UserRole_value[name]
+	if !ok || value == int32(spotpb.UserRole_USER_ROLE_UNSPECIFIED) {
+		return spotpb.UserRole_USER_ROLE_UNSPECIFIED, false
+	}
+	return spotpb.UserRole(value), true
+}
+
+// ParseRoles разбирает роли spot-service, пропуская прочие (например, роли самого order-service)
+func ParseRoles(names []string) []spotpb.UserRole {
+	result := make([]spotpb.UserRole, 0, len(names))
+	for _, name := range names {
+		if role, ok := ParseRole(name); ok {
+			result = append(result, role)
+		}
+	}
+	return result
+}
@@ -0,0 +1,203 @@
+package roles
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	spotpb "github.com/chilly266futon/exchange-service-contracts/gen/pb/spot"
+
+	"github.com/chilly266futon/orderService/internal/auth"
+)
+
+// stubProvider возвращает заданный результат и считает вызовы
+type stubProvider struct {
+	roles []spotpb.UserRole
+	err   error
+	calls int
+}
+
+func (p *stubProvider) Roles(context.Context, string) ([]spotpb.UserRole, error) {
+	p.calls++
+	return p.roles, p.err
+}
+
+type mapStore map[string][]string
+
+func (s mapStore) UserRoles(_ context.Context, userID string) ([]string, error) {
+	return s[userID], nil
+}
+
+func TestChain(t *testing.T) {
+	verified := []spotpb.UserRole{spotpb.UserRole_USER_ROLE_VERIFIED}
+	premium := []spotpb.UserRole{spotpb.UserRole_USER_ROLE_PREMIUM}
+	errStore := errors.New("store unavailable")
+
+	tests := []struct {
+		name      string
+		providers []*stubProvider
+		want      []spotpb.UserRole
+		wantErr   error
+		wantCalls []int
+	}{
+		{
+			name:      "first provider knows the user",
+			providers: []*stubProvider{{roles: verified}, {roles: premium}},
+			want:      verified,
+			wantCalls: []int{1, 0},
+		},
+		{
+			name:      "unknown user falls through",
+			providers: []*stubProvider{{err: ErrUnknownUser}, {roles: premium}},
+			want:      premium,
+			wantCalls: []int{1, 1},
+		},
+		{
+			name:      "other errors stop the chain",
+			providers: []*stubProvider{{err: errStore}, {roles: premium}},
+			wantErr:   errStore,
+			wantCalls: []int{1, 0},
+		},
+		{
+			name:      "nobody knows the user",
+			providers: []*stubProvider{{err: ErrUnknownUser}, {err: ErrUnknownUser}},
+			wantErr:   ErrUnknownUser,
+			wantCalls: []int{1, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			providers := make([]Provider, len(tt.providers))
+			for i, p := range tt.providers {
+				providers[i] = p
+			}
+
+			got, err := Chain(providers...).Roles(context.Background(), "user-1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Roles() error = %v, want %v", err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("Roles() = %v, want %v", got, tt.want)
+			}
+			for i, p := range tt.providers {
+				if p.calls != tt.wantCalls[i] {
+					t.Fatalf("provider %d called %d times, want %d", i, p.calls, tt.wantCalls[i])
+				}
+			}
+		})
+	}
+}
+
+func TestChainOfClaimsStoreAndDefaults(t *testing.T) {
+	provider := Chain(
+		NewClaimsProvider(),
+		NewStoreProvider(mapStore{"stored": {"USER_ROLE_PREMIUM"}}),
+		Static(spotpb.UserRole_USER_ROLE_COMMON),
+	)
+
+	tests := []struct {
+		name     string
+		identity *auth.Identity
+		userID   string
+		want     []spotpb.UserRole
+	}{
+		{
+			name:     "token roles win",
+			identity: &auth.Identity{UserID: "stored", Roles: []string{"verified", "order-admin"}},
+			userID:   "stored",
+			want:     []spotpb.UserRole{spotpb.UserRole_USER_ROLE_VERIFIED},
+		},
+		{
+			name:     "token without spot roles falls back to the store",
+			identity: &auth.Identity{UserID: "stored", Roles: []string{"order-admin"}},
+			userID:   "stored",
+			want:     []spotpb.UserRole{spotpb.UserRole_USER_ROLE_PREMIUM},
+		},
+		{
+			name:     "roles of another caller are not used",
+			identity: &auth.Identity{UserID: "admin", Roles: []string{"USER_ROLE_ADMIN"}},
+			userID:   "someone",
+			want:     []spotpb.UserRole{spotpb.UserRole_USER_ROLE_COMMON},
+		},
+		{
+			name:   "no identity and no stored roles",
+			userID: "someone",
+			want:   []spotpb.UserRole{spotpb.UserRole_USER_ROLE_COMMON},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.identity != nil {
+				ctx = auth.WithIdentity(ctx, *tt.identity)
+			}
+
+			got, err := provider.Roles(ctx, tt.userID)
+			if err != nil {
+				t.Fatalf("Roles() error = %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("Roles() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRole(t *testing.T) {
+	tests := []struct {
+		name   string
+		want   spotpb.UserRole
+		wantOK bool
+	}{
+		{"USER_ROLE_PREMIUM", spotpb.UserRole_USER_ROLE_PREMIUM, true},
+		{"premium", spotpb.UserRole_USER_ROLE_PREMIUM, true},
+		{" Verified ", spotpb.UserRole_USER_ROLE_VERIFIED, true},
+		{"USER_ROLE_UNSPECIFIED", spotpb.UserRole_USER_ROLE_UNSPECIFIED, false},
+		{"unspecified", spotpb.UserRole_USER_ROLE_UNSPECIFIED, false},
+		{"order-admin", spotpb.UserRole_USER_ROLE_UNSPECIFIED, false},
+		{"", spotpb.UserRole_USER_ROLE_UNSPECIFIED, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := ParseRole(tt.name)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("ParseRole(%q) = %v, %v, want %v, %v", tt.name, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestNewFileStore(t *testing.T) {
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "roles.yaml")
+	if err := os.WriteFile(valid, []byte("users:\n  user-1: [USER_ROLE_VERIFIED, premium]\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	store, err := NewFileStore(valid)
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+	got, err := NewStoreProvider(store).Roles(context.Background(), "user-1")
+	if err != nil {
+		t.Fatalf("Roles() error = %v", err)
+	}
+	if want := []spotpb.UserRole{spotpb.UserRole_USER_ROLE_VERIFIED, spotpb.UserRole_USER_ROLE_PREMIUM}; !slices.Equal(got, want) {
+		t.Fatalf("Roles() = %v, want %v", got, want)
+	}
+	if _, err := NewStoreProvider(store).Roles(context.Background(), "user-2"); !errors.Is(err, ErrUnknownUser) {
+		t.Fatalf("Roles() for unknown user error = %v, want %v", err, ErrUnknownUser)
+	}
+
+	invalid := filepath.Join(dir, "invalid.yaml")
+	if err := os.WriteFile(invalid, []byte("users:\n  user-1: [USER_ROLE_GOLD]\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if _, err := NewFileStore(invalid); err == nil {
+		t.Fatal("NewFileStore() accepted an unknown role")
+	}
+}
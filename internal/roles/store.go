@@ -0,0 +1,72 @@
+package roles
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	spotpb "github.com/chilly266futon/exchange-service-contracts/gen/pb/spot"
+	"gopkg.in/yaml.v3"
+)
+
+// Store хранилище назначенных пользователям ролей. Для неизвестного пользователя возвращает пустой список
+type Store interface {
+	UserRoles(ctx context.Context, userID string) ([]string, error)
+}
+
+// StoreProvider читает роли из Store
+type StoreProvider struct {
+	store Store
+}
+
+func NewStoreProvider(store Store) *StoreProvider {
+	return &StoreProvider{store: store}
+}
+
+func (p *StoreProvider) Roles(ctx context.Context, userID string) ([]spotpb.UserRole, error) {
+	names, err := p.store.UserRoles(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	roles := ParseRoles(names)
+	if len(roles) == 0 {
+		return nil, ErrUnknownUser
+	}
+	return roles, nil
+}
+
+// FileStore роли из YAML-файла вида
+//
+//	users:
+//	  <user_id>: [USER_ROLE_VERIFIED, USER_ROLE_PREMIUM]
+type FileStore struct {
+	users map[string][]string
+}
+
+func NewFileStore(path string) (*FileStore, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read roles file: %w", err)
+	}
+
+	var file struct {
+		Users map[string][]string `yaml:"users"`
+	}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse roles file: %w", err)
+	}
+
+	for userID, names := range file.Users {
+		for _, name := range names {
+			if _, ok := ParseRole(name); !ok {
+				return nil, fmt.Errorf("roles file: unknown role %q for user %s", name, userID)
+			}
+		}
+	}
+	return &FileStore{users: file.Users}, nil
+}
+
+func (s *FileStore) UserRoles(_ context.Context, userID string) ([]string, error) {
+	return append([]string(nil), s.users[userID]...), nil
+}
@@ -0,0 +1,327 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	spotpb "github.com/chilly266futon/exchange-service-contracts/gen/pb/spot"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/chilly266futon/exchange-shared/pkg/interceptors"
+
+	"github.com/chilly266futon/orderService/internal/auth"
+	"github.com/chilly266futon/orderService/internal/domain"
+	"github.com/chilly266futon/orderService/internal/dto/order"
+	"github.com/chilly266futon/orderService/internal/roles"
+	"github.com/chilly266futon/orderService/internal/storage"
+)
+
+// cancelMarketPageSize сколько заказов пары загружать за раз при массовой отмене
+const cancelMarketPageSize = 500
+
+// defaultAuditLimit сколько записей журнала возвращать, если лимит не указан
+const defaultAuditLimit = 100
+
+// AdminUseCase операции администраторов над заказами любых пользователей.
+// Доступ только с ролью USER_ROLE_ADMIN, каждое действие и каждый отказ пишутся в журнал аудита
+type AdminUseCase struct {
+	repo   storage.OrderRepository
+	audit  storage.AuditRepository
+	roles  roles.Provider
+	logger *zap.Logger
+}
+
+func NewAdminUseCase(
+	repo storage.OrderRepository,
+	audit storage.AuditRepository,
+	roleProvider roles.Provider,
+	logger *zap.Logger,
+) *AdminUseCase {
+	return &AdminUseCase{
+		repo:   repo,
+		audit:  audit,
+		roles:  roleProvider,
+		logger: logger,
+	}
+}
+
+func (uc *AdminUseCase) ListOrders(ctx context.Context, req order.ListOrdersRequest) (order.ListOrdersResponse, error) {
+	record := domain.AuditRecord{
+		Action:   domain.AuditActionListOrders,
+		MarketID: req.MarketID,
+		Details:  fmt.Sprintf("user_id=%q statuses=%v page_token=%q", req.UserID, req.Statuses, req.PageToken),
+	}
+
+	actor, err := uc.requireAdmin(ctx, record)
+	if err != nil {
+		return order.ListOrdersResponse{}, err
+	}
+
+	resp, err := listOrders(ctx, uc.repo, uc.logger, req)
+	uc.record(ctx, actor, record, err)
+	return resp, err
+}
+
+func (uc *AdminUseCase) GetOrder(ctx context.Context, req order.AdminGetOrderRequest) (order.GetOrderResponse, error) {
+	record := domain.AuditRecord{
+		Action:  domain.AuditActionGetOrder,
+		OrderID: req.OrderID,
+	}
+
+	actor, err := uc.requireAdmin(ctx, record)
+	if err != nil {
+		return order.GetOrderResponse{}, err
+	}
+
+	orderInfo, err := uc.repo.GetByID(ctx, req.OrderID)
+	if err != nil && !errors.Is(err, domain.ErrOrderNotFound) {
+		uc.logger.Error("failed to load order",
+			zap.String("trace_id", interceptors.GetTraceID(ctx)),
+			zap.String("order_id", req.OrderID),
+			zap.Error(err),
+		)
+		err = status.Errorf(codes.Internal, "failed to load order")
+	}
+	uc.record(ctx, actor, record, err)
+	if err != nil {
+		return order.GetOrderResponse{}, err
+	}
+
+	return order.GetOrderResponse{Order: orderInfo}, nil
+}
+
+func (uc *AdminUseCase) ForceTransition(ctx context.Context, req order.ForceTransitionRequest) (order.ForceTransitionResponse, error) {
+	traceID := interceptors.GetTraceID(ctx)
+
+	record := domain.AuditRecord{
+		Action:  domain.AuditActionForceTransition,
+		OrderID: req.OrderID,
+		Reason:  req.Reason,
+		Details: "to_status=" + req.ToStatus,
+	}
+
+	actor, err := uc.requireAdmin(ctx, record)
+	if err != nil {
+		return order.ForceTransitionResponse{}, err
+	}
+
+	to, err := domain.ParseOrderStatus(req.ToStatus)
+	if err != nil {
+		uc.record(ctx, actor, record, err)
+		return order.ForceTransitionResponse{}, err
+	}
+
+	var from domain.OrderStatus
+	orderInfo, err := updateOrder(ctx, uc.repo, uc.logger, req.OrderID, func(o *domain.Order) error {
+		from = o.Status
+		record.MarketID = o.MarketID
+		return o.ForceTransitionTo(to, req.Reason, actor, time.Now())
+	})
+	record.Details = fmt.Sprintf("from_status=%s to_status=%s", from, to)
+	uc.record(ctx, actor, record, err)
+	if err != nil {
+		return order.ForceTransitionResponse{}, err
+	}
+
+	uc.logger.Info("order status forced",
+		zap.String("trace_id", traceID),
+		zap.String("order_id", orderInfo.ID),
+		zap.String("actor", actor),
+		zap.String("from_status", from.String()),
+		zap.String("to_status", to.String()),
+	)
+
+	return order.ForceTransitionResponse{Order: orderInfo}, nil
+}
+
+// CancelMarketOrders отменяет все незавершенные заказы торговой пары.
+// Заказы, которые не удалось отменить, попадают в FailedOrderIDs и не прерывают отмену остальных
+func (uc *AdminUseCase) CancelMarketOrders(ctx context.Context, req order.CancelMarketOrdersRequest) (order.CancelMarketOrdersResponse, error) {
+	traceID := interceptors.GetTraceID(ctx)
+
+	record := domain.AuditRecord{
+		Action:   domain.AuditActionCancelMarketOrders,
+		MarketID: req.MarketID,
+		Reason:   req.Reason,
+	}
+
+	actor, err := uc.requireAdmin(ctx, record)
+	if err != nil {
+		return order.CancelMarketOrdersResponse{}, err
+	}
+
+	var resp order.CancelMarketOrdersResponse
+	filter := storage.OrderFilter{
+		MarketID: req.MarketID,
+		Statuses: []domain.OrderStatus{
+			domain.OrderStatusCreated,
+			domain.OrderStatusOpen,
+			domain.OrderStatusPartiallyFilled,
+		},
+		Limit: cancelMarketPageSize,
+	}
+
+	for {
+		page, err := uc.repo.List(ctx, filter)
+		if err != nil {
+			uc.logger.Error("failed to list market orders",
+				zap.String("trace_id", traceID),
+				zap.String("market_id", req.MarketID),
+				zap.Error(err),
+			)
+			err = status.Errorf(codes.Internal, "failed to list market orders")
+			record.Details = cancelDetails(resp)
+			uc.record(ctx, actor, record, err)
+			return order.CancelMarketOrdersResponse{}, err
+		}
+
+		for _, candidate := range page {
+			_, err := updateOrder(ctx, uc.repo, uc.logger, candidate.ID, func(o *domain.Order) error {
+				if err := o.CanBeCancelled(); err != nil {
+					return err
+				}
+				return o.TransitionTo(domain.OrderStatusCancelled, req.Reason, actor, time.Now())
+			})
+			if err != nil {
+				uc.logger.Warn("failed to cancel market order",
+					zap.String("trace_id", traceID),
+					zap.String("order_id", candidate.ID),
+					zap.Error(err),
+				)
+				resp.FailedOrderIDs = append(resp.FailedOrderIDs, candidate.ID)
+				continue
+			}
+			resp.CancelledOrderIDs = append(resp.CancelledOrderIDs, candidate.ID)
+		}
+
+		if len(page) < cancelMarketPageSize {
+			break
+		}
+		cursor := storage.CursorOf(page[len(page)-1])
+		filter.After = &cursor
+	}
+
+	record.Details = cancelDetails(resp)
+	uc.record(ctx, actor, record, nil)
+
+	uc.logger.Info("market orders cancelled",
+		zap.String("trace_id", traceID),
+		zap.String("market_id", req.MarketID),
+		zap.String("actor", actor),
+		zap.Int("cancelled", len(resp.CancelledOrderIDs)),
+		zap.Int("failed", len(resp.FailedOrderIDs)),
+	)
+
+	return resp, nil
+}
+
+func cancelDetails(resp order.CancelMarketOrdersResponse) string {
+	return fmt.Sprintf("cancelled=%d failed=%d", len(resp.CancelledOrderIDs), len(resp.FailedOrderIDs))
+}
+
+func (uc *AdminUseCase) ListAuditRecords(ctx context.Context, req order.ListAuditRecordsRequest) (order.ListAuditRecordsResponse, error) {
+	record := domain.AuditRecord{
+		Action:  domain.AuditActionListAuditRecords,
+		OrderID: req.OrderID,
+	}
+
+	actor, err := uc.requireAdmin(ctx, record)
+	if err != nil {
+		return order.ListAuditRecordsResponse{}, err
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultAuditLimit
+	}
+
+	records, err := uc.audit.List(ctx, storage.AuditFilter{OrderID: req.OrderID, Limit: limit})
+	if err != nil {
+		uc.logger.Error("failed to list audit records",
+			zap.String("trace_id", interceptors.GetTraceID(ctx)),
+			zap.Error(err),
+		)
+		err = status.Errorf(codes.Internal, "failed to list audit records")
+	}
+	uc.record(ctx, actor, record, err)
+	if err != nil {
+		return order.ListAuditRecordsResponse{}, err
+	}
+
+	return order.ListAuditRecordsResponse{Records: records}, nil
+}
+
+// requireAdmin проверяет, что вызывающий аутентифицирован и имеет роль USER_ROLE_ADMIN.
+// Возвращает инициатора для истории и журнала; отказ в доступе тоже пишется в журнал
+func (uc *AdminUseCase) requireAdmin(ctx context.Context, record domain.AuditRecord) (string, error) {
+	traceID := interceptors.GetTraceID(ctx)
+
+	identity, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		uc.logger.Warn("unauthenticated admin call",
+			zap.String("trace_id", traceID),
+			zap.String("action", record.Action),
+		)
+		return "", domain.ErrUnauthenticated
+	}
+	actor := domain.AdminActor(identity.UserID)
+
+	userRoles, err := uc.roles.Roles(ctx, identity.UserID)
+	if err != nil && !errors.Is(err, roles.ErrUnknownUser) {
+		uc.logger.Error("failed to resolve user roles",
+			zap.String("trace_id", traceID),
+			zap.String("user_id", identity.UserID),
+			zap.Error(err),
+		)
+		return "", status.Errorf(codes.Internal, "failed to resolve user roles")
+	}
+
+	if !slices.Contains(userRoles, spotpb.UserRole_USER_ROLE_ADMIN) {
+		uc.logger.Warn("admin role required",
+			zap.String("trace_id", traceID),
+			zap.String("user_id", identity.UserID),
+			zap.String("action", record.Action),
+		)
+		uc.record(ctx, actor, record, domain.ErrAccessDenied)
+		return "", domain.ErrAccessDenied
+	}
+
+	return actor, nil
+}
+
+// record пишет действие в журнал аудита. Действие к этому моменту уже выполнено,
+// поэтому ошибка записи только логируется и не меняет результат вызова
+func (uc *AdminUseCase) record(ctx context.Context, actor string, record domain.AuditRecord, actionErr error) {
+	record.ID = uuid.NewString()
+	record.Actor = actor
+	record.At = time.Now()
+
+	switch {
+	case actionErr == nil:
+		record.Outcome = domain.AuditOutcomeSuccess
+	case errors.Is(actionErr, domain.ErrAccessDenied):
+		record.Outcome = domain.AuditOutcomeDenied
+	default:
+		record.Outcome = domain.AuditOutcomeFailed
+		if record.Details != "" {
+			record.Details += " "
+		}
+		record.Details += "error=" + actionErr.Error()
+	}
+
+	if err := uc.audit.Append(ctx, record); err != nil {
+		uc.logger.Error("failed to write audit record",
+			zap.String("trace_id", interceptors.GetTraceID(ctx)),
+			zap.String("actor", actor),
+			zap.String("action", record.Action),
+			zap.String("outcome", record.Outcome),
+			zap.Error(err),
+		)
+	}
+}
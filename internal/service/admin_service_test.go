@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	spotpb "github.com/chilly266futon/exchange-service-contracts/gen/pb/spot"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/chilly266futon/orderService/internal/auth"
+	"github.com/chilly266futon/orderService/internal/domain"
+	"github.com/chilly266futon/orderService/internal/dto/order"
+	"github.com/chilly266futon/orderService/internal/roles"
+	"github.com/chilly266futon/orderService/internal/storage"
+	"github.com/chilly266futon/orderService/internal/storage/storagetest"
+)
+
+// listCountingRepo считает загруженные страницы
+type listCountingRepo struct {
+	storage.OrderRepository
+	lists atomic.Int64
+}
+
+func (r *listCountingRepo) List(ctx context.Context, filter storage.OrderFilter) ([]*domain.Order, error) {
+	r.lists.Add(1)
+	return r.OrderRepository.List(ctx, filter)
+}
+
+func newTestAdminUseCase(repo storage.OrderRepository, role spotpb.UserRole) (*AdminUseCase, storage.AuditRepository) {
+	audit := storage.NewMemoryAuditRepository()
+	return NewAdminUseCase(repo, audit, roles.Static(role), zap.NewNop()), audit
+}
+
+func addOpenOrder(t *testing.T, repo storage.OrderRepository) *domain.Order {
+	t.Helper()
+
+	o := storagetest.NewOrder(uuid.NewString())
+	o.Status = domain.OrderStatusOpen
+	if err := repo.Add(context.Background(), o); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	return o
+}
+
+func adminContext() context.Context {
+	return auth.WithIdentity(context.Background(), auth.Identity{UserID: "admin-1", Method: auth.MethodJWT})
+}
+
+func lastAuditRecord(t *testing.T, audit storage.AuditRepository) domain.AuditRecord {
+	t.Helper()
+
+	records, err := audit.List(context.Background(), storage.AuditFilter{Limit: 1})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(records) == 0 {
+		t.Fatal("no audit records")
+	}
+	return records[0]
+}
+
+// adminActions вызывает каждое действие администратора над заказом orderID
+func adminActions(orderID, marketID string) map[string]func(context.Context, *AdminUseCase) error {
+	return map[string]func(context.Context, *AdminUseCase) error{
+		domain.AuditActionListOrders: func(ctx context.Context, uc *AdminUseCase) error {
+			_, err := uc.ListOrders(ctx, order.ListOrdersRequest{MarketID: marketID})
+			return err
+		},
+		domain.AuditActionGetOrder: func(ctx context.Context, uc *AdminUseCase) error {
+			_, err := uc.GetOrder(ctx, order.AdminGetOrderRequest{OrderID: orderID})
+			return err
+		},
+		domain.AuditActionForceTransition: func(ctx context.Context, uc *AdminUseCase) error {
+			_, err := uc.ForceTransition(ctx, order.ForceTransitionRequest{OrderID: orderID, ToStatus: "CANCELLED", Reason: "stuck"})
+			return err
+		},
+		domain.AuditActionCancelMarketOrders: func(ctx context.Context, uc *AdminUseCase) error {
+			_, err := uc.CancelMarketOrders(ctx, order.CancelMarketOrdersRequest{MarketID: marketID, Reason: "delisting"})
+			return err
+		},
+		domain.AuditActionListAuditRecords: func(ctx context.Context, uc *AdminUseCase) error {
+			_, err := uc.ListAuditRecords(ctx, order.ListAuditRecordsRequest{OrderID: orderID})
+			return err
+		},
+	}
+}
+
+func TestAdminActionsRequireAdminRole(t *testing.T) {
+	repo := storage.NewMemoryOrderRepository()
+	o := addOpenOrder(t, repo)
+
+	for action, call := range adminActions(o.ID, o.MarketID) {
+		t.Run(action, func(t *testing.T) {
+			uc, audit := newTestAdminUseCase(repo, spotpb.UserRole_USER_ROLE_PREMIUM)
+
+			if err := call(adminContext(), uc); !errors.Is(err, domain.ErrAccessDenied) {
+				t.Fatalf("%s error = %v, want %v", action, err, domain.ErrAccessDenied)
+			}
+			record := lastAuditRecord(t, audit)
+			if record.Action != action || record.Outcome != domain.AuditOutcomeDenied || record.Actor != domain.AdminActor("admin-1") {
+				t.Fatalf("audit record = %+v, want denied %s by admin-1", record, action)
+			}
+
+			if err := call(context.Background(), uc); !errors.Is(err, domain.ErrUnauthenticated) {
+				t.Fatalf("%s without identity error = %v, want %v", action, err, domain.ErrUnauthenticated)
+			}
+		})
+	}
+
+	got, err := repo.GetByID(context.Background(), o.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.Status != domain.OrderStatusOpen {
+		t.Fatalf("order status = %s after denied calls, want OPEN", got.Status)
+	}
+}
+
+func TestAdminActionsWriteAuditRecords(t *testing.T) {
+	for action, call := range adminActions("", "") {
+		t.Run(action, func(t *testing.T) {
+			repo := storage.NewMemoryOrderRepository()
+			o := addOpenOrder(t, repo)
+			uc, audit := newTestAdminUseCase(repo, spotpb.UserRole_USER_ROLE_ADMIN)
+
+			if err := adminActions(o.ID, o.MarketID)[action](adminContext(), uc); err != nil {
+				t.Fatalf("%s error = %v", action, err)
+			}
+			record := lastAuditRecord(t, audit)
+			if record.Action != action || record.Outcome != domain.AuditOutcomeSuccess || record.Actor != domain.AdminActor("admin-1") {
+				t.Fatalf("audit record = %+v, want successful %s by admin-1", record, action)
+			}
+			if record.ID == "" || record.At.IsZero() {
+				t.Fatalf("audit record = %+v, want ID and time set", record)
+			}
+
+			// неудачное действие тоже попадает в журнал
+			_ = call(adminContext(), uc)
+			if action == domain.AuditActionGetOrder || action == domain.AuditActionForceTransition {
+				record = lastAuditRecord(t, audit)
+				if record.Action != action || record.Outcome != domain.AuditOutcomeFailed {
+					t.Fatalf("audit record = %+v, want failed %s", record, action)
+				}
+			}
+		})
+	}
+}
+
+func TestCancelMarketOrdersWalksAllPages(t *testing.T) {
+	ctx := context.Background()
+	repo := &listCountingRepo{OrderRepository: storage.NewMemoryOrderRepository()}
+	uc, audit := newTestAdminUseCase(repo, spotpb.UserRole_USER_ROLE_ADMIN)
+
+	const total = 2*cancelMarketPageSize + 1
+	marketID := "market-1"
+	for range total {
+		o := storagetest.NewOrder("user-1")
+		o.MarketID = marketID
+		o.Status = domain.OrderStatusOpen
+		if err := repo.Add(ctx, o); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+
+	finished := storagetest.NewOrder("user-1")
+	finished.MarketID = marketID
+	finished.Status = domain.OrderStatusFilled
+	other := storagetest.NewOrder("user-1")
+	other.Status = domain.OrderStatusOpen
+	for _, o := range []*domain.Order{finished, other} {
+		if err := repo.Add(ctx, o); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+
+	resp, err := uc.CancelMarketOrders(adminContext(), order.CancelMarketOrdersRequest{MarketID: marketID, Reason: "delisting"})
+	if err != nil {
+		t.Fatalf("CancelMarketOrders() error = %v", err)
+	}
+	if len(resp.CancelledOrderIDs) != total || len(resp.FailedOrderIDs) != 0 {
+		t.Fatalf("cancelled %d, failed %d, want %d and 0", len(resp.CancelledOrderIDs), len(resp.FailedOrderIDs), total)
+	}
+	if n := repo.lists.Load(); n != 3 {
+		t.Fatalf("loaded %d pages, want 3", n)
+	}
+
+	left, err := repo.List(ctx, storage.OrderFilter{MarketID: marketID, Statuses: []domain.OrderStatus{domain.OrderStatusOpen}})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(left) != 0 {
+		t.Fatalf("%d orders left open in the market", len(left))
+	}
+	for _, o := range []*domain.Order{finished, other} {
+		got, err := repo.GetByID(ctx, o.ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if got.Status != o.Status {
+			t.Fatalf("order %s status = %s, want %s", o.ID, got.Status, o.Status)
+		}
+	}
+
+	record := lastAuditRecord(t, audit)
+	if record.Action != domain.AuditActionCancelMarketOrders || record.Details != "cancelled=1001 failed=0" {
+		t.Fatalf("audit record = %+v, want cancel_market_orders with cancelled=1001", record)
+	}
+}
@@ -2,10 +2,11 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	spotpb "github.com/chilly266futon/exchange-service-contracts/gen/pb/spot"
-	"github.com/chilly266futon/exchange-shared/pkg/common"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
@@ -13,26 +14,35 @@ import (
 
 	"github.com/chilly266futon/exchange-shared/pkg/interceptors"
 
+	"github.com/chilly266futon/orderService/internal/auth"
 	"github.com/chilly266futon/orderService/internal/clients"
 	"github.com/chilly266futon/orderService/internal/domain"
 	"github.com/chilly266futon/orderService/internal/dto/order"
+	"github.com/chilly266futon/orderService/internal/outbox"
+	"github.com/chilly266futon/orderService/internal/roles"
 	"github.com/chilly266futon/orderService/internal/storage"
 )
 
 type OrderUseCase struct {
-	storage    *storage.OrderStorage
+	repo       storage.OrderRepository
 	spotClient clients.SpotClient
+	roles      roles.Provider
+	updates    *outbox.Hub
 	logger     *zap.Logger
 }
 
 func NewOrderUseCase(
-	storage *storage.OrderStorage,
+	repo storage.OrderRepository,
 	spotClient clients.SpotClient,
+	roleProvider roles.Provider,
+	updates *outbox.Hub,
 	logger *zap.Logger,
 ) *OrderUseCase {
 	return &OrderUseCase{
-		storage:    storage,
+		repo:       repo,
 		spotClient: spotClient,
+		roles:      roleProvider,
+		updates:    updates,
 		logger:     logger,
 	}
 }
@@ -40,9 +50,6 @@ func NewOrderUseCase(
 func (uc *OrderUseCase) CreateOrder(ctx context.Context, req order.CreateOrderRequest) (order.CreateOrderResponse, error) {
 	traceID := interceptors.GetTraceID(ctx)
 
-	if req.Price.IsNegative() || req.Price.IsZero() {
-		return order.CreateOrderResponse{}, domain.ErrInvalidPrice
-	}
 	if req.Quantity.IsNegative() || req.Quantity.IsZero() {
 		return order.CreateOrderResponse{}, domain.ErrInvalidQuantity
 	}
@@ -51,56 +58,116 @@ func (uc *OrderUseCase) CreateOrder(ctx context.Context, req order.CreateOrderRe
 	if err != nil {
 		return order.CreateOrderResponse{}, err
 	}
+	side, err := domain.ParseOrderSide(req.Side)
+	if err != nil {
+		return order.CreateOrderResponse{}, err
+	}
+	if err := ot.ValidatePrices(side, req.Price, req.StopPrice); err != nil {
+		return order.CreateOrderResponse{}, err
+	}
 
-	userIDFromCtx := common.GetUserID(ctx)
-	if userIDFromCtx != "" && userIDFromCtx != req.UserID {
-		uc.logger.Warn("user ID from context does not match request",
-			zap.String("trace_id", traceID),
-			zap.String("user_id_from_ctx", userIDFromCtx),
-			zap.String("user_id_from_req", req.UserID),
-		)
-		return order.CreateOrderResponse{}, domain.ErrAccessDenied
+	now := time.Now()
+	tif := ot.DefaultTimeInForce()
+	if req.TimeInForce != "" {
+		if tif, err = domain.ParseTimeInForce(req.TimeInForce); err != nil {
+			return order.CreateOrderResponse{}, err
+		}
+	}
+	if err := ot.ValidateTimeInForce(tif, req.ExpiresAt, now); err != nil {
+		return order.CreateOrderResponse{}, err
+	}
+	if err := ot.ValidateExecutionFlags(req.PostOnly, req.ReduceOnly, tif); err != nil {
+		return order.CreateOrderResponse{}, err
+	}
+
+	if err := uc.authorize(ctx, req.UserID); err != nil {
+		return order.CreateOrderResponse{}, err
 	}
 
-	userRoles := uc.getUserRoles(ctx, req.UserID) // TODO: или передать userIDFromCtx и убрать return выше
+	// точность хранилища - микросекунды, иначе повтор запроса не совпадет с сохраненным заказом
+	expiresAt := req.ExpiresAt.Truncate(time.Microsecond)
 
-	exists, err := uc.spotClient.MarketExists(ctx, req.MarketID, userRoles)
+	domainOrder := &domain.Order{
+		ID:            uuid.NewString(),
+		ClientOrderID: req.ClientOrderID,
+		UserID:        req.UserID,
+		MarketID:      req.MarketID,
+		Type:          ot,
+		Side:          side,
+		Price:         req.Price,
+		StopPrice:     req.StopPrice,
+		Quantity:      req.Quantity,
+		TimeInForce:   tif,
+		ExpiresAt:     expiresAt,
+		PostOnly:      req.PostOnly,
+		ReduceOnly:    req.ReduceOnly,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	// повтор запроса с тем же ClientOrderID возвращает ранее созданный заказ
+	if domainOrder.ClientOrderID != "" {
+		existing, err := uc.findByClientOrderID(ctx, domainOrder)
+		if err != nil {
+			return order.CreateOrderResponse{}, err
+		}
+		if existing != nil {
+			return order.CreateOrderResponse{
+				OrderID: existing.ID,
+				Status:  existing.Status.String(),
+			}, nil
+		}
+	}
+
+	rules, err := uc.marketRules(ctx, req.MarketID, req.UserID)
 	if err != nil {
-		uc.logger.Error("failed to check market availability",
+		return order.CreateOrderResponse{}, err
+	}
+	if err := rules.CheckOrder(domainOrder); err != nil {
+		uc.logger.Warn("order violates market rules",
 			zap.String("trace_id", traceID),
 			zap.String("market_id", req.MarketID),
 			zap.String("user_id", req.UserID),
 			zap.Error(err),
 		)
-		return order.CreateOrderResponse{}, status.Errorf(codes.Internal, "failed to check market")
+		return order.CreateOrderResponse{}, err
 	}
-	if !exists {
-		uc.logger.Warn("market not found or not accessible",
-			zap.String("trace_id", traceID),
-			zap.String("market_id", req.MarketID),
-			zap.String("user_id", req.UserID),
-		)
-		return order.CreateOrderResponse{}, domain.ErrMarketNotAvailable
+	if err := uc.checkReduceOnly(ctx, domainOrder); err != nil {
+		return order.CreateOrderResponse{}, err
 	}
 
-	domainOrder := &domain.Order{
-		ID:        uuid.NewString(),
-		UserID:    req.UserID,
-		MarketID:  req.MarketID,
-		Type:      ot,
-		Status:    domain.OrderStatusCreated,
-		Price:     req.Price,
-		Quantity:  req.Quantity,
-		CreatedAt: time.Now(),
+	if err := domainOrder.TransitionTo(domain.OrderStatusCreated, "order created", domain.UserActor(req.UserID), now); err != nil {
+		return order.CreateOrderResponse{}, err
 	}
 
-	uc.storage.Add(domainOrder)
+	if err := uc.repo.Add(ctx, domainOrder); err != nil {
+		if errors.Is(err, domain.ErrDuplicateClientOrderID) {
+			// параллельный запрос с тем же ключом успел создать заказ
+			existing, err := uc.findByClientOrderID(ctx, domainOrder)
+			if err != nil {
+				return order.CreateOrderResponse{}, err
+			}
+			if existing != nil {
+				return order.CreateOrderResponse{
+					OrderID: existing.ID,
+					Status:  existing.Status.String(),
+				}, nil
+			}
+		}
+		uc.logger.Error("failed to save order",
+			zap.String("trace_id", traceID),
+			zap.String("order_id", domainOrder.ID),
+			zap.Error(err),
+		)
+		return order.CreateOrderResponse{}, status.Errorf(codes.Internal, "failed to save order")
+	}
 
 	uc.logger.Info("order created",
 		zap.String("trace_id", traceID),
 		zap.String("order_id", domainOrder.ID),
 		zap.String("user_id", domainOrder.UserID),
 		zap.String("market_id", domainOrder.MarketID),
+		zap.String("side", domainOrder.Side.String()),
 	)
 
 	return order.CreateOrderResponse{
@@ -109,25 +176,82 @@ func (uc *OrderUseCase) CreateOrder(ctx context.Context, req order.CreateOrderRe
 	}, nil
 }
 
-func (uc *OrderUseCase) GetOrderStatus(ctx context.Context, req order.GetOrderStatusRequest) (order.GetOrderStatusResponse, error) {
+// checkReduceOnly проверяет reduce-only заказ по позиции пользователя на рынке - сумме исполнений его заказов
+// за вычетом остатков других его незавершенных reduce-only заказов той же стороны
+func (uc *OrderUseCase) checkReduceOnly(ctx context.Context, o *domain.Order) error {
+	if !o.ReduceOnly {
+		return nil
+	}
 	traceID := interceptors.GetTraceID(ctx)
 
-	orderInfo, exists := uc.storage.GetByID(req.OrderID)
-	if !exists {
-		uc.logger.Warn("order not found",
+	position, err := storage.LoadPosition(ctx, uc.repo, o.UserID, o.MarketID, o.ID)
+	if err != nil {
+		uc.logger.Error("failed to load orders for position",
 			zap.String("trace_id", traceID),
-			zap.String("order_id", req.OrderID),
-			zap.String("user_id", req.UserID),
+			zap.String("user_id", o.UserID),
+			zap.String("market_id", o.MarketID),
+			zap.Error(err),
 		)
-		return order.GetOrderStatusResponse{}, domain.ErrOrderNotFound
+		return status.Errorf(codes.Internal, "failed to check position")
 	}
-	if req.UserID != orderInfo.UserID {
-		uc.logger.Warn("access denied to order",
+
+	if err := o.CheckReduceOnly(position); err != nil {
+		uc.logger.Warn("reduce-only order rejected",
 			zap.String("trace_id", traceID),
-			zap.String("order_id", req.OrderID),
-			zap.String("user_id", req.UserID),
+			zap.String("user_id", o.UserID),
+			zap.String("market_id", o.MarketID),
+			zap.Error(err),
+		)
+		return err
+	}
+	return nil
+}
+
+// findByClientOrderID ищет ранее созданный заказ с тем же ключом идемпотентности.
+// Возвращает nil, если ключ не использовался, и domain.ErrIdempotencyKeyReused, если параметры отличаются
+func (uc *OrderUseCase) findByClientOrderID(ctx context.Context, candidate *domain.Order) (*domain.Order, error) {
+	traceID := interceptors.GetTraceID(ctx)
+
+	existing, err := uc.repo.GetByClientOrderID(ctx, candidate.UserID, candidate.ClientOrderID)
+	if errors.Is(err, domain.ErrOrderNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		uc.logger.Error("failed to look up order by client order ID",
+			zap.String("trace_id", traceID),
+			zap.String("user_id", candidate.UserID),
+			zap.String("client_order_id", candidate.ClientOrderID),
+			zap.Error(err),
+		)
+		return nil, status.Errorf(codes.Internal, "failed to look up order")
+	}
+
+	if !existing.HasSameTerms(candidate) {
+		uc.logger.Warn("client order ID reused with different parameters",
+			zap.String("trace_id", traceID),
+			zap.String("user_id", candidate.UserID),
+			zap.String("client_order_id", candidate.ClientOrderID),
+			zap.String("order_id", existing.ID),
 		)
-		return order.GetOrderStatusResponse{}, domain.ErrAccessDenied
+		return nil, domain.ErrIdempotencyKeyReused
+	}
+
+	uc.logger.Info("duplicate create request, returning existing order",
+		zap.String("trace_id", traceID),
+		zap.String("order_id", existing.ID),
+		zap.String("client_order_id", candidate.ClientOrderID),
+	)
+	return existing, nil
+}
+
+func (uc *OrderUseCase) GetOrderStatus(ctx context.Context, req order.GetOrderStatusRequest) (order.GetOrderStatusResponse, error) {
+	if err := uc.authorize(ctx, req.UserID); err != nil {
+		return order.GetOrderStatusResponse{}, err
+	}
+
+	orderInfo, err := uc.getOwnedOrder(ctx, req.OrderID, req.UserID)
+	if err != nil {
+		return order.GetOrderStatusResponse{}, err
 	}
 
 	return order.GetOrderStatusResponse{
@@ -136,53 +260,134 @@ func (uc *OrderUseCase) GetOrderStatus(ctx context.Context, req order.GetOrderSt
 	}, nil
 }
 
-func (uc *OrderUseCase) CancelOrder(ctx context.Context, req order.CancelOrderRequest) (order.CancelOrderResponse, error) {
+func (uc *OrderUseCase) GetOrder(ctx context.Context, req order.GetOrderRequest) (order.GetOrderResponse, error) {
+	if err := uc.authorize(ctx, req.UserID); err != nil {
+		return order.GetOrderResponse{}, err
+	}
+
+	orderInfo, err := uc.getOwnedOrder(ctx, req.OrderID, req.UserID)
+	if err != nil {
+		return order.GetOrderResponse{}, err
+	}
+
+	return order.GetOrderResponse{Order: orderInfo}, nil
+}
+
+func (uc *OrderUseCase) GetOrderHistory(ctx context.Context, req order.GetOrderHistoryRequest) (order.GetOrderHistoryResponse, error) {
 	traceID := interceptors.GetTraceID(ctx)
 
-	orderInfo, exists := uc.storage.GetByID(req.OrderID)
-	if !exists {
-		uc.logger.Warn("order not found for cancel",
+	if err := uc.authorize(ctx, req.UserID); err != nil {
+		return order.GetOrderHistoryResponse{}, err
+	}
+
+	if _, err := uc.getOwnedOrder(ctx, req.OrderID, req.UserID); err != nil {
+		return order.GetOrderHistoryResponse{}, err
+	}
+
+	transitions, err := uc.repo.History(ctx, req.OrderID)
+	if err != nil {
+		uc.logger.Error("failed to load order history",
 			zap.String("trace_id", traceID),
 			zap.String("order_id", req.OrderID),
-			zap.String("user_id", req.UserID),
+			zap.Error(err),
 		)
-		return order.CancelOrderResponse{}, domain.ErrOrderNotFound
+		return order.GetOrderHistoryResponse{}, status.Errorf(codes.Internal, "failed to load order history")
 	}
 
-	userIDFromCtx := common.GetUserID(ctx)
-	if userIDFromCtx != "" && userIDFromCtx != req.UserID {
-		uc.logger.Warn("user ID from context does not match request",
+	return order.GetOrderHistoryResponse{
+		OrderID:     req.OrderID,
+		Transitions: transitions,
+	}, nil
+}
+
+// authorize проверяет, что вызов выполняет аутентифицированный пользователь userID
+func (uc *OrderUseCase) authorize(ctx context.Context, userID string) error {
+	traceID := interceptors.GetTraceID(ctx)
+
+	identity, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		uc.logger.Warn("unauthenticated call",
 			zap.String("trace_id", traceID),
-			zap.String("order_id", req.OrderID),
-			zap.String("user_id_from_ctx", userIDFromCtx),
-			zap.String("user_id_from_req", req.UserID),
+			zap.String("user_id_from_req", userID),
 		)
-		return order.CancelOrderResponse{}, domain.ErrAccessDenied
+		return domain.ErrUnauthenticated
 	}
-
-	if req.UserID != orderInfo.UserID {
-		uc.logger.Warn("access denied for cancel",
+	if identity.UserID != userID {
+		uc.logger.Warn("user ID from context does not match request",
 			zap.String("trace_id", traceID),
-			zap.String("order_id", req.OrderID),
-			zap.String("user_id", req.UserID),
+			zap.String("user_id_from_ctx", identity.UserID),
+			zap.String("user_id_from_req", userID),
 		)
-		return order.CancelOrderResponse{}, domain.ErrAccessDenied
+		return domain.ErrAccessDenied
 	}
+	return nil
+}
+
+// getOwnedOrder загружает заказ и проверяет, что он принадлежит пользователю
+func (uc *OrderUseCase) getOwnedOrder(ctx context.Context, orderID, userID string) (*domain.Order, error) {
+	traceID := interceptors.GetTraceID(ctx)
 
-	if err := orderInfo.CanBeCancelled(); err != nil {
-		uc.logger.Warn("cannot cancel order",
+	orderInfo, err := uc.repo.GetByID(ctx, orderID)
+	if err != nil {
+		if errors.Is(err, domain.ErrOrderNotFound) {
+			uc.logger.Warn("order not found",
+				zap.String("trace_id", traceID),
+				zap.String("order_id", orderID),
+				zap.String("user_id", userID),
+			)
+			return nil, err
+		}
+		uc.logger.Error("failed to load order",
 			zap.String("trace_id", traceID),
-			zap.String("order_id", req.OrderID),
-			zap.String("current_status", orderInfo.Status.String()),
+			zap.String("order_id", orderID),
 			zap.Error(err),
 		)
+		return nil, status.Errorf(codes.Internal, "failed to load order")
+	}
+	if userID != orderInfo.UserID {
+		uc.logger.Warn("access denied to order",
+			zap.String("trace_id", traceID),
+			zap.String("order_id", orderID),
+			zap.String("user_id", userID),
+		)
+		return nil, domain.ErrAccessDenied
+	}
+
+	return orderInfo, nil
+}
+
+func (uc *OrderUseCase) CancelOrder(ctx context.Context, req order.CancelOrderRequest) (order.CancelOrderResponse, error) {
+	traceID := interceptors.GetTraceID(ctx)
+
+	if err := uc.authorize(ctx, req.UserID); err != nil {
 		return order.CancelOrderResponse{}, err
 	}
 
-	// Меняем статус
-	orderInfo.Status = domain.OrderStatusCancelled
+	orderInfo, err := updateOrder(ctx, uc.repo, uc.logger, req.OrderID, func(o *domain.Order) error {
+		if req.UserID != o.UserID {
+			uc.logger.Warn("access denied for cancel",
+				zap.String("trace_id", traceID),
+				zap.String("order_id", req.OrderID),
+				zap.String("user_id", req.UserID),
+			)
+			return domain.ErrAccessDenied
+		}
 
-	uc.storage.Update(orderInfo)
+		if err := o.CanBeCancelled(); err != nil {
+			uc.logger.Warn("cannot cancel order",
+				zap.String("trace_id", traceID),
+				zap.String("order_id", req.OrderID),
+				zap.String("current_status", o.Status.String()),
+				zap.Error(err),
+			)
+			return err
+		}
+
+		return o.TransitionTo(domain.OrderStatusCancelled, "cancelled by user", domain.UserActor(req.UserID), time.Now())
+	})
+	if err != nil {
+		return order.CancelOrderResponse{}, err
+	}
 
 	uc.logger.Info("order cancelled",
 		zap.String("trace_id", traceID),
@@ -197,9 +402,456 @@ func (uc *OrderUseCase) CancelOrder(ctx context.Context, req order.CancelOrderRe
 
 }
 
-func (uc *OrderUseCase) getUserRoles(ctx context.Context, userID string) []spotpb.UserRole {
-	// TODO: Implement actual user role retrieval logic, e.g. from auth service or context
-	// Пока возвращаем дефолт
-	return []spotpb.UserRole{spotpb.UserRole_USER_ROLE_COMMON}
+// ReplaceOrder меняет цену и/или объем незавершенного заказа владельца. Нулевое значение оставляет
+// параметр без изменений. Новые параметры проверяются по правилам рынка, изменение записывается в историю.
+// Изменение атомарно: если заказ параллельно исполнился, проверки повторяются на свежем состоянии
+func (uc *OrderUseCase) ReplaceOrder(ctx context.Context, req order.ReplaceOrderRequest) (order.ReplaceOrderResponse, error) {
+	traceID := interceptors.GetTraceID(ctx)
+
+	if req.Price.IsNegative() {
+		return order.ReplaceOrderResponse{}, domain.ErrInvalidPrice
+	}
+	if req.Quantity.IsNegative() {
+		return order.ReplaceOrderResponse{}, domain.ErrInvalidQuantity
+	}
+
+	if err := uc.authorize(ctx, req.UserID); err != nil {
+		return order.ReplaceOrderResponse{}, err
+	}
+
+	var rules *domain.MarketRules
+	orderInfo, err := updateOrder(ctx, uc.repo, uc.logger, req.OrderID, func(o *domain.Order) error {
+		if req.UserID != o.UserID {
+			uc.logger.Warn("access denied for replace",
+				zap.String("trace_id", traceID),
+				zap.String("order_id", req.OrderID),
+				zap.String("user_id", req.UserID),
+			)
+			return domain.ErrAccessDenied
+		}
+
+		if rules == nil {
+			var err error
+			if rules, err = uc.marketRules(ctx, o.MarketID, req.UserID); err != nil {
+				return err
+			}
+		}
+
+		quantityIncreased := req.Quantity.GreaterThan(o.Quantity)
+		if err := o.Amend(req.Price, req.Quantity, domain.UserActor(req.UserID), time.Now()); err != nil {
+			uc.logger.Warn("cannot amend order",
+				zap.String("trace_id", traceID),
+				zap.String("order_id", req.OrderID),
+				zap.String("current_status", o.Status.String()),
+				zap.Error(err),
+			)
+			return err
+		}
+		if err := rules.CheckOrder(o); err != nil {
+			uc.logger.Warn("amended order violates market rules",
+				zap.String("trace_id", traceID),
+				zap.String("order_id", req.OrderID),
+				zap.String("market_id", o.MarketID),
+				zap.Error(err),
+			)
+			return err
+		}
+		if quantityIncreased {
+			return uc.checkReduceOnly(ctx, o)
+		}
+		return nil
+	})
+	if err != nil {
+		return order.ReplaceOrderResponse{}, err
+	}
+
+	uc.logger.Info("order amended",
+		zap.String("trace_id", traceID),
+		zap.String("order_id", orderInfo.ID),
+		zap.String("user_id", orderInfo.UserID),
+		zap.String("price", orderInfo.Price.String()),
+		zap.String("quantity", orderInfo.Quantity.String()),
+	)
+
+	return order.ReplaceOrderResponse{Order: orderInfo}, nil
+}
+
+func (uc *OrderUseCase) ListOrders(ctx context.Context, req order.ListOrdersRequest) (order.ListOrdersResponse, error) {
+	if err := uc.authorize(ctx, req.UserID); err != nil {
+		return order.ListOrdersResponse{}, err
+	}
+
+	return listOrders(ctx, uc.repo, uc.logger, req)
+}
+
+// listOrders возвращает страницу заказов по фильтру запроса. Пустой UserID - заказы всех пользователей
+func listOrders(ctx context.Context, repo storage.OrderRepository, logger *zap.Logger, req order.ListOrdersRequest) (order.ListOrdersResponse, error) {
+	traceID := interceptors.GetTraceID(ctx)
+
+	filter := storage.OrderFilter{
+		UserID:      req.UserID,
+		MarketID:    req.MarketID,
+		CreatedFrom: req.CreatedFrom,
+		CreatedTo:   req.CreatedTo,
+	}
+
+	if !filter.CreatedFrom.IsZero() && !filter.CreatedTo.IsZero() && !filter.CreatedFrom.Before(filter.CreatedTo) {
+		return order.ListOrdersResponse{}, domain.ErrInvalidTimeRange
+	}
+
+	for _, s := range req.Statuses {
+		st, err := domain.ParseOrderStatus(s)
+		if err != nil {
+			return order.ListOrdersResponse{}, err
+		}
+		filter.Statuses = append(filter.Statuses, st)
+	}
+	for _, t := range req.OrderTypes {
+		ot, err := domain.ParseOrderType(t)
+		if err != nil {
+			return order.ListOrdersResponse{}, err
+		}
+		filter.Types = append(filter.Types, ot)
+	}
+
+	after, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return order.ListOrdersResponse{}, err
+	}
+	filter.After = after
+
+	// запрашиваем на один заказ больше, чтобы понять, есть ли следующая страница
+	pageSize := normalizePageSize(req.PageSize)
+	filter.Limit = pageSize + 1
+
+	orders, err := repo.List(ctx, filter)
+	if err != nil {
+		logger.Error("failed to list orders",
+			zap.String("trace_id", traceID),
+			zap.String("user_id", req.UserID),
+			zap.Error(err),
+		)
+		return order.ListOrdersResponse{}, status.Errorf(codes.Internal, "failed to list orders")
+	}
+
+	var nextPageToken string
+	if len(orders) > pageSize {
+		orders = orders[:pageSize]
+		nextPageToken = encodePageToken(storage.CursorOf(orders[len(orders)-1]))
+	}
+
+	return order.ListOrdersResponse{
+		Orders:        orders,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+// replayPageSize сколько сохраненных событий читать за раз при возобновлении подписки
+const replayPageSize = 500
+
+// SubscribeOrderUpdates передает в send события заказов пользователя (или одного заказа), пока не отменен ctx.
+// При FromSequence > 0 сначала досылаются сохраненные события после него, затем новые.
+// Если подписчик не успевает забирать события, возвращается codes.ResourceExhausted
+func (uc *OrderUseCase) SubscribeOrderUpdates(ctx context.Context, req order.SubscribeOrderUpdatesRequest, send func(domain.OrderEvent) error) error {
+	traceID := interceptors.GetTraceID(ctx)
+
+	if err := uc.authorize(ctx, req.UserID); err != nil {
+		return err
+	}
+
+	if req.OrderID != "" {
+		if _, err := uc.getOwnedOrder(ctx, req.OrderID, req.UserID); err != nil {
+			return err
+		}
+	}
+
+	filter := storage.EventFilter{UserID: req.UserID, OrderID: req.OrderID}
+
+	// подписываемся до чтения сохраненных событий, чтобы не пропустить опубликованные в это время
+	sub := uc.updates.Subscribe(filter)
+	defer uc.updates.Unsubscribe(sub)
+
+	lastSeq := req.FromSequence
+	if req.FromSequence > 0 {
+		filter.AfterSequence = req.FromSequence
+		filter.Limit = replayPageSize
+		for {
+			events, err := uc.repo.EventsAfter(ctx, filter)
+			if err != nil {
+				uc.logger.Error("failed to load order events",
+					zap.String("trace_id", traceID),
+					zap.String("user_id", req.UserID),
+					zap.Int64("from_sequence", filter.AfterSequence),
+					zap.Error(err),
+				)
+				return status.Errorf(codes.Internal, "failed to load order events")
+			}
+
+			for _, event := range events {
+				if err := send(event); err != nil {
+					return err
+				}
+				lastSeq = event.Sequence
+			}
+			if len(events) < replayPageSize {
+				break
+			}
+			filter.AfterSequence = lastSeq
+		}
+	}
+
+	uc.logger.Info("order updates subscribed",
+		zap.String("trace_id", traceID),
+		zap.String("user_id", req.UserID),
+		zap.String("order_id", req.OrderID),
+		zap.Int64("from_sequence", req.FromSequence),
+	)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case event, ok := <-sub.Events():
+			if !ok {
+				if sub.Overflowed() {
+					uc.logger.Warn("order updates subscriber is too slow",
+						zap.String("trace_id", traceID),
+						zap.String("user_id", req.UserID),
+						zap.Int64("last_sequence", lastSeq),
+					)
+					return status.Errorf(codes.ResourceExhausted,
+						"subscriber is too slow, resubscribe from sequence %d", lastSeq)
+				}
+				return nil
+			}
+
+			// Hub отдает события по порядку Sequence, более ранние уже отправлены при досылке сохраненных
+			if event.Sequence <= lastSeq {
+				continue
+			}
+			if err := send(event); err != nil {
+				return err
+			}
+			lastSeq = event.Sequence
+		}
+	}
+}
+
+// errFillAlreadyApplied сделка уже учтена в заказе
+var errFillAlreadyApplied = errors.New("fill already applied")
+
+// ApplyFill учитывает исполнение сделки по заказу. Внутренний API для исполнения сделок:
+// повтор сделки с тем же TradeID не меняет заказ и возвращает его текущее состояние
+func (uc *OrderUseCase) ApplyFill(ctx context.Context, req order.ApplyFillRequest) (order.ApplyFillResponse, error) {
+	traceID := interceptors.GetTraceID(ctx)
+
+	orderInfo, err := updateOrder(ctx, uc.repo, uc.logger, req.OrderID, func(o *domain.Order) error {
+		applied, err := uc.isFillApplied(ctx, req.OrderID, req.TradeID)
+		if err != nil {
+			uc.logger.Error("failed to load order fills",
+				zap.String("trace_id", traceID),
+				zap.String("order_id", req.OrderID),
+				zap.Error(err),
+			)
+			return status.Errorf(codes.Internal, "failed to load order fills")
+		}
+		if applied {
+			return errFillAlreadyApplied
+		}
+
+		fill := domain.Fill{
+			TradeID:  req.TradeID,
+			Price:    req.Price,
+			Quantity: req.Quantity,
+			At:       time.Now(),
+		}
+		if err := o.ApplyFill(fill, domain.ActorSystem); err != nil {
+			uc.logger.Warn("cannot apply fill",
+				zap.String("trace_id", traceID),
+				zap.String("order_id", req.OrderID),
+				zap.String("trade_id", req.TradeID),
+				zap.String("current_status", o.Status.String()),
+				zap.Error(err),
+			)
+			return err
+		}
+		return nil
+	})
+	if errors.Is(err, errFillAlreadyApplied) || errors.Is(err, domain.ErrDuplicateFill) {
+		current, err := uc.repo.GetByID(ctx, req.OrderID)
+		if err != nil {
+			return order.ApplyFillResponse{}, status.Errorf(codes.Internal, "failed to load order")
+		}
+		return order.ApplyFillResponse{Order: current, Duplicate: true}, nil
+	}
+	if err != nil {
+		return order.ApplyFillResponse{}, err
+	}
+
+	uc.logger.Info("fill applied",
+		zap.String("trace_id", traceID),
+		zap.String("order_id", orderInfo.ID),
+		zap.String("trade_id", req.TradeID),
+		zap.String("filled_quantity", orderInfo.FilledQuantity.String()),
+		zap.String("status", orderInfo.Status.String()),
+	)
+
+	return order.ApplyFillResponse{Order: orderInfo}, nil
+}
+
+// TriggerStopOrder активирует стоп-заказ по цене последней сделки. Внутренний API движка стоп-заказов:
+// заказ, уже активированный, отмененный или не достигший стоп-цены, не меняется и дает domain.ErrOrderNotTriggerable
+func (uc *OrderUseCase) TriggerStopOrder(ctx context.Context, req order.TriggerStopOrderRequest) (order.TriggerStopOrderResponse, error) {
+	traceID := interceptors.GetTraceID(ctx)
+
+	orderInfo, err := updateOrder(ctx, uc.repo, uc.logger, req.OrderID, func(o *domain.Order) error {
+		if !o.ShouldTrigger(req.LastPrice) {
+			return fmt.Errorf("%w: %s %s stop %s at %s", domain.ErrOrderNotTriggerable, o.Type, o.Status, o.StopPrice, req.LastPrice)
+		}
+		return o.Trigger(req.LastPrice, time.Now())
+	})
+	if err != nil {
+		return order.TriggerStopOrderResponse{}, err
+	}
+
+	uc.logger.Info("stop order triggered",
+		zap.String("trace_id", traceID),
+		zap.String("order_id", orderInfo.ID),
+		zap.String("market_id", orderInfo.MarketID),
+		zap.String("stop_price", orderInfo.StopPrice.String()),
+		zap.String("last_price", req.LastPrice.String()),
+		zap.String("type", orderInfo.Type.String()),
+	)
+
+	return order.TriggerStopOrderResponse{Order: orderInfo}, nil
+}
+
+func (uc *OrderUseCase) isFillApplied(ctx context.Context, orderID, tradeID string) (bool, error) {
+	fills, err := uc.repo.Fills(ctx, orderID)
+	if err != nil {
+		return false, err
+	}
+	for _, fill := range fills {
+		if fill.TradeID == tradeID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// maxUpdateAttempts сколько раз перечитывать заказ при конфликте версий
+const maxUpdateAttempts = 3
+
+// updateOrder читает заказ, применяет к нему mutate и сохраняет с проверкой версии.
+// При конфликте версий заказ перечитывается и mutate применяется заново; если конфликт не разрешился
+// за maxUpdateAttempts попыток, возвращается codes.Aborted. Ошибки mutate возвращаются как есть
+func updateOrder(
+	ctx context.Context,
+	repo storage.OrderRepository,
+	logger *zap.Logger,
+	orderID string,
+	mutate func(o *domain.Order) error,
+) (*domain.Order, error) {
+	traceID := interceptors.GetTraceID(ctx)
+
+	for attempt := 1; ; attempt++ {
+		orderInfo, err := repo.GetByID(ctx, orderID)
+		if err != nil {
+			if errors.Is(err, domain.ErrOrderNotFound) {
+				logger.Warn("order not found",
+					zap.String("trace_id", traceID),
+					zap.String("order_id", orderID),
+				)
+				return nil, err
+			}
+			logger.Error("failed to load order",
+				zap.String("trace_id", traceID),
+				zap.String("order_id", orderID),
+				zap.Error(err),
+			)
+			return nil, status.Errorf(codes.Internal, "failed to load order")
+		}
+
+		if err := mutate(orderInfo); err != nil {
+			return nil, err
+		}
+
+		err = repo.Update(ctx, orderInfo)
+		switch {
+		case err == nil:
+			return orderInfo, nil
+
+		case errors.Is(err, domain.ErrVersionConflict):
+			if attempt < maxUpdateAttempts {
+				logger.Debug("order version conflict, retrying",
+					zap.String("trace_id", traceID),
+					zap.String("order_id", orderID),
+					zap.Int("attempt", attempt),
+				)
+				continue
+			}
+			logger.Warn("order version conflict, giving up",
+				zap.String("trace_id", traceID),
+				zap.String("order_id", orderID),
+				zap.Int("attempts", attempt),
+			)
+			return nil, status.Error(codes.Aborted, err.Error())
+
+		case errors.Is(err, domain.ErrOrderNotFound), errors.Is(err, domain.ErrDuplicateFill):
+			return nil, err
+
+		default:
+			logger.Error("failed to update order",
+				zap.String("trace_id", traceID),
+				zap.String("order_id", orderID),
+				zap.Error(err),
+			)
+			return nil, status.Errorf(codes.Internal, "failed to update order")
+		}
+	}
+}
+
+// marketRules торговые правила рынка, доступного пользователю. Недоступный рынок дает domain.ErrMarketNotAvailable
+func (uc *OrderUseCase) marketRules(ctx context.Context, marketID, userID string) (*domain.MarketRules, error) {
+	traceID := interceptors.GetTraceID(ctx)
+
+	userRoles, err := uc.getUserRoles(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
 
+	rules, err := uc.spotClient.MarketRules(ctx, marketID, userRoles)
+	if err != nil {
+		uc.logger.Error("failed to check market availability",
+			zap.String("trace_id", traceID),
+			zap.String("market_id", marketID),
+			zap.String("user_id", userID),
+			zap.Error(err),
+		)
+		return nil, status.Errorf(codes.Internal, "failed to check market")
+	}
+	if rules == nil {
+		uc.logger.Warn("market not found or not accessible",
+			zap.String("trace_id", traceID),
+			zap.String("market_id", marketID),
+			zap.String("user_id", userID),
+		)
+		return nil, domain.ErrMarketNotAvailable
+	}
+	return rules, nil
+}
+
+// getUserRoles роли пользователя для spot-service
+func (uc *OrderUseCase) getUserRoles(ctx context.Context, userID string) ([]spotpb.UserRole, error) {
+	userRoles, err := uc.roles.Roles(ctx, userID)
+	if err != nil {
+		uc.logger.Error("failed to resolve user roles",
+			zap.String("trace_id", interceptors.GetTraceID(ctx)),
+			zap.String("user_id", userID),
+			zap.Error(err),
+		)
+		return nil, status.Errorf(codes.Internal, "failed to resolve user roles")
+	}
+	return userRoles, nil
 }
@@ -0,0 +1,317 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/chilly266futon/orderService/internal/auth"
+	"github.com/chilly266futon/orderService/internal/domain"
+	"github.com/chilly266futon/orderService/internal/dto/order"
+	"github.com/chilly266futon/orderService/internal/storage"
+	"github.com/chilly266futon/orderService/internal/storage/storagetest"
+)
+
+// racingRepo задерживает первые reads чтений, пока их не сделают все участники гонки,
+// поэтому все они меняют одну и ту же версию заказа и хотя бы одно сохранение дает конфликт
+type racingRepo struct {
+	storage.OrderRepository
+	reads     int64
+	arrived   sync.WaitGroup
+	calls     atomic.Int64
+	conflicts atomic.Int64
+}
+
+func newRacingRepo(repo storage.OrderRepository, reads int) *racingRepo {
+	r := &racingRepo{OrderRepository: repo, reads: int64(reads)}
+	r.arrived.Add(reads)
+	return r
+}
+
+func (r *racingRepo) GetByID(ctx context.Context, id string) (*domain.Order, error) {
+	o, err := r.OrderRepository.GetByID(ctx, id)
+	if r.calls.Add(1) <= r.reads {
+		r.arrived.Done()
+		r.arrived.Wait()
+	}
+	return o, err
+}
+
+func (r *racingRepo) Update(ctx context.Context, o *domain.Order) error {
+	err := r.OrderRepository.Update(ctx, o)
+	if errors.Is(err, domain.ErrVersionConflict) {
+		r.conflicts.Add(1)
+	}
+	return err
+}
+
+// conflictingRepo всегда отвечает на сохранение конфликтом версий
+type conflictingRepo struct {
+	storage.OrderRepository
+	updates atomic.Int64
+}
+
+func (r *conflictingRepo) Update(context.Context, *domain.Order) error {
+	r.updates.Add(1)
+	return domain.ErrVersionConflict
+}
+
+func newTestUseCase(repo storage.OrderRepository) *OrderUseCase {
+	return NewOrderUseCase(repo, nil, nil, nil, zap.NewNop())
+}
+
+func TestConcurrentCancelAndFill(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		repo := newRacingRepo(storage.NewMemoryOrderRepository(), 2)
+		uc := newTestUseCase(repo)
+		o := addOpenOrder(t, repo)
+		ctx := auth.WithIdentity(context.Background(), auth.Identity{UserID: o.UserID})
+
+		var (
+			wg                 sync.WaitGroup
+			cancelErr, fillErr error
+		)
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, cancelErr = uc.CancelOrder(ctx, order.CancelOrderRequest{OrderID: o.ID, UserID: o.UserID})
+		}()
+		go func() {
+			defer wg.Done()
+			_, fillErr = uc.ApplyFill(ctx, order.ApplyFillRequest{
+				OrderID:  o.ID,
+				TradeID:  uuid.NewString(),
+				Price:    o.Price,
+				Quantity: o.Quantity,
+			})
+		}()
+		wg.Wait()
+
+		if repo.conflicts.Load() == 0 {
+			t.Fatal("no version conflict, the race was not exercised")
+		}
+
+		got, err := repo.GetByID(ctx, o.ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+
+		// проигравший перечитал заказ и получил ошибку состояния, а не конфликт версий
+		switch {
+		case cancelErr == nil && fillErr != nil:
+			if !errors.Is(fillErr, domain.ErrOrderNotFillable) {
+				t.Fatalf("ApplyFill() error = %v, want %v", fillErr, domain.ErrOrderNotFillable)
+			}
+			if got.Status != domain.OrderStatusCancelled || !got.FilledQuantity.IsZero() {
+				t.Fatalf("order = %s filled %s, want CANCELLED without fills", got.Status, got.FilledQuantity)
+			}
+		case fillErr == nil && cancelErr != nil:
+			if !errors.Is(cancelErr, domain.ErrOrderCannotBeCancelled) {
+				t.Fatalf("CancelOrder() error = %v, want %v", cancelErr, domain.ErrOrderCannotBeCancelled)
+			}
+			if got.Status != domain.OrderStatusFilled || !got.FilledQuantity.Equal(o.Quantity) {
+				t.Fatalf("order = %s filled %s, want FILLED", got.Status, got.FilledQuantity)
+			}
+		default:
+			t.Fatalf("CancelOrder() error = %v, ApplyFill() error = %v, want exactly one winner", cancelErr, fillErr)
+		}
+
+		history, err := repo.History(ctx, o.ID)
+		if err != nil {
+			t.Fatalf("History() error = %v", err)
+		}
+		final := 0
+		for _, tr := range history {
+			if tr.To.IsFinal() {
+				final++
+			}
+		}
+		if final != 1 {
+			t.Fatalf("history has %d final transitions, want 1: %+v", final, history)
+		}
+	}
+}
+
+func TestConcurrentPartialFills(t *testing.T) {
+	const fills = 4
+
+	repo := newRacingRepo(storage.NewMemoryOrderRepository(), fills)
+	uc := newTestUseCase(repo)
+	o := addOpenOrder(t, repo)
+	part := o.Quantity.Div(decimal.NewFromInt(fills))
+
+	var (
+		wg     sync.WaitGroup
+		errs   = make([]error, fills)
+		ctx    = context.Background()
+		traded = make([]string, fills)
+	)
+	for i := range fills {
+		traded[i] = uuid.NewString()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = uc.ApplyFill(ctx, order.ApplyFillRequest{
+				OrderID:  o.ID,
+				TradeID:  traded[i],
+				Price:    o.Price,
+				Quantity: part,
+			})
+		}()
+	}
+	wg.Wait()
+
+	// все стартовали с одной версии: без повторов выиграл бы только один, а с maxUpdateAttempts
+	// попытками проигравшие либо доходят до сохранения, либо получают codes.Aborted
+	applied := 0
+	for i, err := range errs {
+		switch {
+		case err == nil:
+			applied++
+		case status.Code(err) == codes.Aborted:
+		default:
+			t.Fatalf("ApplyFill(%d) error = %v, want nil or Aborted", i, err)
+		}
+	}
+	if applied < 2 {
+		t.Fatalf("%d fills applied, want retries to apply more than one", applied)
+	}
+
+	got, err := repo.GetByID(ctx, o.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if want := part.Mul(decimal.NewFromInt(int64(applied))); !got.FilledQuantity.Equal(want) {
+		t.Fatalf("FilledQuantity = %s, want %s", got.FilledQuantity, want)
+	}
+	fillsSaved, err := repo.Fills(ctx, o.ID)
+	if err != nil {
+		t.Fatalf("Fills() error = %v", err)
+	}
+	if len(fillsSaved) != applied {
+		t.Fatalf("%d fills saved, want %d", len(fillsSaved), applied)
+	}
+}
+
+func TestUpdateOrderGivesUpAfterMaxAttempts(t *testing.T) {
+	repo := &conflictingRepo{OrderRepository: storage.NewMemoryOrderRepository()}
+	uc := newTestUseCase(repo)
+	o := addOpenOrder(t, repo)
+	ctx := auth.WithIdentity(context.Background(), auth.Identity{UserID: o.UserID})
+
+	_, err := uc.CancelOrder(ctx, order.CancelOrderRequest{OrderID: o.ID, UserID: o.UserID})
+	if status.Code(err) != codes.Aborted {
+		t.Fatalf("CancelOrder() error = %v, want code %v", err, codes.Aborted)
+	}
+	if got := repo.updates.Load(); got != maxUpdateAttempts {
+		t.Fatalf("Update() called %d times, want %d", got, maxUpdateAttempts)
+	}
+
+	got, err := repo.GetByID(ctx, o.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.Status != domain.OrderStatusOpen {
+		t.Fatalf("Status = %s, want OPEN", got.Status)
+	}
+}
+
+func TestCheckReduceOnlyCountsOpenReduceOnlyOrders(t *testing.T) {
+	ctx := context.Background()
+	repo := storage.NewMemoryOrderRepository()
+	uc := newTestUseCase(repo)
+
+	// позиция пользователя: куплено 1
+	bought := storagetest.NewOrder(uuid.NewString())
+	bought.Status = domain.OrderStatusFilled
+	bought.Quantity = decimal.RequireFromString("1")
+	bought.FilledQuantity = bought.Quantity
+	if err := repo.Add(ctx, bought); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	reduce := func(quantity string) *domain.Order {
+		o := storagetest.NewOrder(bought.UserID)
+		o.MarketID = bought.MarketID
+		o.Side = domain.OrderSideSell
+		o.Quantity = decimal.RequireFromString(quantity)
+		o.ReduceOnly = true
+		return o
+	}
+
+	first := reduce("0.6")
+	if err := uc.checkReduceOnly(ctx, first); err != nil {
+		t.Fatalf("checkReduceOnly(first) error = %v", err)
+	}
+	first.Status = domain.OrderStatusOpen
+	if err := repo.Add(ctx, first); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	if err := uc.checkReduceOnly(ctx, reduce("0.6")); !errors.Is(err, domain.ErrReduceOnlyViolation) {
+		t.Fatalf("checkReduceOnly(second) error = %v, want %v", err, domain.ErrReduceOnlyViolation)
+	}
+	if err := uc.checkReduceOnly(ctx, reduce("0.4")); err != nil {
+		t.Fatalf("checkReduceOnly(rest of position) error = %v", err)
+	}
+
+	// при изменении заказа его собственный остаток не считается занятым
+	first.Quantity = decimal.RequireFromString("1")
+	if err := uc.checkReduceOnly(ctx, first); err != nil {
+		t.Fatalf("checkReduceOnly(amended first) error = %v", err)
+	}
+}
+
+func TestCreateOrderRetryAfterAmend(t *testing.T) {
+	repo := storage.NewMemoryOrderRepository()
+	uc := newTestUseCase(repo)
+
+	o := storagetest.NewOrder(uuid.NewString())
+	o.ClientOrderID = "retry-1"
+	o.Status = domain.OrderStatusOpen
+	if err := repo.Add(context.Background(), o); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	ctx := auth.WithIdentity(context.Background(), auth.Identity{UserID: o.UserID})
+
+	req := order.CreateOrderRequest{
+		UserID:        o.UserID,
+		MarketID:      o.MarketID,
+		ClientOrderID: o.ClientOrderID,
+		OrderType:     o.Type.String(),
+		Side:          o.Side.String(),
+		Price:         o.Price,
+		Quantity:      o.Quantity,
+		TimeInForce:   o.TimeInForce.String(),
+	}
+
+	if err := o.Amend(o.Price.Add(decimal.NewFromInt(1)), o.Quantity.Mul(decimal.NewFromInt(2)), domain.UserActor(o.UserID), time.Now()); err != nil {
+		t.Fatalf("Amend() error = %v", err)
+	}
+	if err := repo.Update(ctx, o); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	// повтор исходного запроса возвращает измененный заказ, а не ошибку идемпотентности
+	resp, err := uc.CreateOrder(ctx, req)
+	if err != nil {
+		t.Fatalf("CreateOrder() error = %v", err)
+	}
+	if resp.OrderID != o.ID {
+		t.Fatalf("CreateOrder() order ID = %s, want %s", resp.OrderID, o.ID)
+	}
+
+	req.Quantity = o.Quantity
+	if _, err := uc.CreateOrder(ctx, req); !errors.Is(err, domain.ErrIdempotencyKeyReused) {
+		t.Fatalf("CreateOrder(amended terms) error = %v, want %v", err, domain.ErrIdempotencyKeyReused)
+	}
+}
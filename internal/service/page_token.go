@@ -0,0 +1,56 @@
+package service
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chilly266futon/orderService/internal/domain"
+	"github.com/chilly266futon/orderService/internal/storage"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 500
+)
+
+// encodePageToken упаковывает курсор в непрозрачный для клиента токен
+func encodePageToken(c storage.Cursor) string {
+	raw := strconv.FormatInt(c.CreatedAt.UnixNano(), 10) + ":" + c.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodePageToken(token string) (*storage.Cursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, domain.ErrInvalidPageToken
+	}
+
+	nanos, id, ok := strings.Cut(string(raw), ":")
+	if !ok || id == "" {
+		return nil, domain.ErrInvalidPageToken
+	}
+
+	ns, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return nil, domain.ErrInvalidPageToken
+	}
+
+	return &storage.Cursor{CreatedAt: time.Unix(0, ns).UTC(), ID: id}, nil
+}
+
+func normalizePageSize(size int) int {
+	switch {
+	case size <= 0:
+		return defaultPageSize
+	case size > maxPageSize:
+		return maxPageSize
+	default:
+		return size
+	}
+}
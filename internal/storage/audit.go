@@ -0,0 +1,22 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/chilly266futon/orderService/internal/domain"
+)
+
+// AuditRepository журнал действий администраторов. Записи только добавляются
+type AuditRepository interface {
+	Append(ctx context.Context, record domain.AuditRecord) error
+	// List возвращает записи от новых к старым
+	List(ctx context.Context, filter AuditFilter) ([]domain.AuditRecord, error)
+}
+
+// AuditFilter условия выборки записей журнала. Пустые поля не ограничивают выборку
+type AuditFilter struct {
+	OrderID string
+	Actor   string
+	// Limit максимальное число записей, 0 - без ограничения
+	Limit int
+}
@@ -0,0 +1,26 @@
+package storage
+
+import "context"
+
+// Lease право одного экземпляра сервиса выполнять фоновую работу, которую нельзя запускать параллельно.
+// TryAcquire получает аренду или подтверждает, что она еще за этим экземпляром; false - аренда у другого.
+// Release отпускает аренду, если она была получена
+type Lease interface {
+	TryAcquire(ctx context.Context) (bool, error)
+	Release(ctx context.Context) error
+}
+
+// memoryLease аренда для хранилища в памяти: оно не разделяется между экземплярами, поэтому аренда всегда свободна
+type memoryLease struct{}
+
+func NewMemoryLease() Lease {
+	return memoryLease{}
+}
+
+func (memoryLease) TryAcquire(context.Context) (bool, error) {
+	return true, nil
+}
+
+func (memoryLease) Release(context.Context) error {
+	return nil
+}
@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"context"
+	"sync"
+
+	"github.com/chilly266futon/orderService/internal/domain"
+)
+
+// memoryAuditRepository хранит журнал в памяти процесса. Используется в тестах и при локальном запуске
+type memoryAuditRepository struct {
+	records []domain.AuditRecord
+	mu      sync.RWMutex
+}
+
+func NewMemoryAuditRepository() AuditRepository {
+	return &memoryAuditRepository{}
+}
+
+func (s *memoryAuditRepository) Append(_ context.Context, record domain.AuditRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.records = append(s.records, record)
+	return nil
+}
+
+func (s *memoryAuditRepository) List(_ context.Context, filter AuditFilter) ([]domain.AuditRecord, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]domain.AuditRecord, 0)
+	for i := len(s.records) - 1; i >= 0; i-- {
+		if filter.Limit > 0 && len(result) == filter.Limit {
+			break
+		}
+		record := s.records[i]
+		if filter.OrderID != "" && record.OrderID != filter.OrderID {
+			continue
+		}
+		if filter.Actor != "" && record.Actor != filter.Actor {
+			continue
+		}
+		result = append(result, record)
+	}
+	return result, nil
+}
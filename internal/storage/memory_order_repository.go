@@ -0,0 +1,291 @@
+package storage
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/chilly266futon/orderService/internal/domain"
+)
+
+// memoryOrderRepository хранит заказы в памяти процесса. Используется в тестах и при локальном запуске
+type memoryOrderRepository struct {
+	orders  map[string]*domain.Order
+	history map[string][]domain.OrderTransition
+	fills   map[string][]domain.Fill
+	// clientOrderIDs индекс (пользователь, ClientOrderID) -> ID заказа
+	clientOrderIDs map[clientOrderKey]string
+	// outbox события в порядке Sequence: событие с номером n лежит по индексу n-1
+	outbox []outboxEntry
+	// unpublished индекс первого неопубликованного события
+	unpublished int
+	mu          sync.RWMutex
+}
+
+type outboxEntry struct {
+	event     domain.OrderEvent
+	published bool
+}
+
+type clientOrderKey struct {
+	userID        string
+	clientOrderID string
+}
+
+func NewMemoryOrderRepository() OrderRepository {
+	return &memoryOrderRepository{
+		orders:         make(map[string]*domain.Order),
+		history:        make(map[string][]domain.OrderTransition),
+		fills:          make(map[string][]domain.Fill),
+		clientOrderIDs: make(map[clientOrderKey]string),
+	}
+}
+
+func (s *memoryOrderRepository) GetByID(_ context.Context, id string) (*domain.Order, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	order, exists := s.orders[id]
+	if !exists {
+		return nil, domain.ErrOrderNotFound
+	}
+	return order.Clone(), nil
+}
+
+func (s *memoryOrderRepository) Add(_ context.Context, order *domain.Order) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.orders[order.ID]; exists {
+		return domain.ErrOrderAlreadyExists
+	}
+
+	key := clientOrderKey{userID: order.UserID, clientOrderID: order.ClientOrderID}
+	if order.ClientOrderID != "" {
+		if _, exists := s.clientOrderIDs[key]; exists {
+			return domain.ErrDuplicateClientOrderID
+		}
+	}
+
+	if err := s.save(order, 1); err != nil {
+		return err
+	}
+	if order.ClientOrderID != "" {
+		s.clientOrderIDs[key] = order.ID
+	}
+	return nil
+}
+
+func (s *memoryOrderRepository) Update(_ context.Context, order *domain.Order) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, exists := s.orders[order.ID]
+	if !exists {
+		return domain.ErrOrderNotFound
+	}
+	if stored.Version != order.Version {
+		return domain.ErrVersionConflict
+	}
+
+	return s.save(order, order.Version+1)
+}
+
+func (s *memoryOrderRepository) UpdateMany(_ context.Context, orders ...*domain.Order) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// все проверки до первой записи, чтобы не сохранить часть заказов
+	for _, order := range orders {
+		stored, exists := s.orders[order.ID]
+		if !exists {
+			return domain.ErrOrderNotFound
+		}
+		if stored.Version != order.Version {
+			return domain.ErrVersionConflict
+		}
+		if err := s.checkFills(order); err != nil {
+			return err
+		}
+	}
+
+	for _, order := range orders {
+		if err := s.save(order, order.Version+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkFills проверяет, что исполнения заказа еще не сохранены. Вызывается под s.mu
+func (s *memoryOrderRepository) checkFills(order *domain.Order) error {
+	for _, fill := range order.PendingFills() {
+		for _, saved := range s.fills[order.ID] {
+			if saved.TradeID == fill.TradeID {
+				return domain.ErrDuplicateFill
+			}
+		}
+	}
+	return nil
+}
+
+// save сохраняет копию заказа с новой версией, его переходы и исполнения. Вызывается под s.mu
+func (s *memoryOrderRepository) save(order *domain.Order, version int64) error {
+	if err := s.checkFills(order); err != nil {
+		return err
+	}
+
+	s.history[order.ID] = append(s.history[order.ID], order.PendingTransitions()...)
+	s.fills[order.ID] = append(s.fills[order.ID], order.PendingFills()...)
+	for _, event := range order.PendingEvents() {
+		event.Sequence = int64(len(s.outbox) + 1)
+		s.outbox = append(s.outbox, outboxEntry{event: event})
+	}
+	order.MarkPersisted()
+	order.Version = version
+	s.orders[order.ID] = order.Clone()
+	return nil
+}
+
+func (s *memoryOrderRepository) GetByUserID(_ context.Context, userID string) ([]*domain.Order, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]*domain.Order, 0)
+	for _, order := range s.orders {
+		if order.UserID == userID {
+			result = append(result, order.Clone())
+		}
+	}
+	return result, nil
+}
+
+func (s *memoryOrderRepository) GetByClientOrderID(_ context.Context, userID, clientOrderID string) (*domain.Order, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	id, exists := s.clientOrderIDs[clientOrderKey{userID: userID, clientOrderID: clientOrderID}]
+	if !exists {
+		return nil, domain.ErrOrderNotFound
+	}
+	return s.orders[id].Clone(), nil
+}
+
+func (s *memoryOrderRepository) List(_ context.Context, filter OrderFilter) ([]*domain.Order, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]*domain.Order, 0)
+	for _, order := range s.orders {
+		if !filter.Match(order) {
+			continue
+		}
+		if filter.After != nil && !isAfter(order, *filter.After) {
+			continue
+		}
+		result = append(result, order.Clone())
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return isAfter(result[j], CursorOf(result[i]))
+	})
+
+	if filter.Limit > 0 && len(result) > filter.Limit {
+		result = result[:filter.Limit]
+	}
+	return result, nil
+}
+
+func (s *memoryOrderRepository) Count(_ context.Context) (int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.orders), nil
+}
+
+func (s *memoryOrderRepository) History(_ context.Context, orderID string) ([]domain.OrderTransition, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, exists := s.orders[orderID]; !exists {
+		return nil, domain.ErrOrderNotFound
+	}
+
+	return append([]domain.OrderTransition{}, s.history[orderID]...), nil
+}
+
+func (s *memoryOrderRepository) Fills(_ context.Context, orderID string) ([]domain.Fill, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, exists := s.orders[orderID]; !exists {
+		return nil, domain.ErrOrderNotFound
+	}
+
+	return append([]domain.Fill{}, s.fills[orderID]...), nil
+}
+
+func (s *memoryOrderRepository) UnpublishedEvents(_ context.Context, limit int) ([]domain.OrderEvent, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]domain.OrderEvent, 0)
+	for _, entry := range s.outbox[s.unpublished:] {
+		if limit > 0 && len(result) == limit {
+			break
+		}
+		if !entry.published {
+			result = append(result, entry.event)
+		}
+	}
+	return result, nil
+}
+
+func (s *memoryOrderRepository) MarkPublished(_ context.Context, sequences []int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, seq := range sequences {
+		if seq >= 1 && seq <= int64(len(s.outbox)) {
+			s.outbox[seq-1].published = true
+		}
+	}
+	for s.unpublished < len(s.outbox) && s.outbox[s.unpublished].published {
+		s.unpublished++
+	}
+	return nil
+}
+
+func (s *memoryOrderRepository) LastSequence(context.Context) (int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return int64(len(s.outbox)), nil
+}
+
+func (s *memoryOrderRepository) EventsAfter(_ context.Context, filter EventFilter) ([]domain.OrderEvent, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]domain.OrderEvent, 0)
+	if filter.AfterSequence < 0 {
+		filter.AfterSequence = 0
+	}
+	for i := filter.AfterSequence; i < int64(len(s.outbox)); i++ {
+		if filter.Limit > 0 && len(result) == filter.Limit {
+			break
+		}
+		if event := s.outbox[i].event; filter.Match(event) {
+			result = append(result, event)
+		}
+	}
+	return result, nil
+}
+
+// isAfter сообщает, идет ли заказ после курсора при сортировке (CreatedAt, ID) по убыванию
+func isAfter(order *domain.Order, c Cursor) bool {
+	if !order.CreatedAt.Equal(c.CreatedAt) {
+		return order.CreatedAt.Before(c.CreatedAt)
+	}
+	return order.ID < c.ID
+}
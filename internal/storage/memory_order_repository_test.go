@@ -0,0 +1,14 @@
+package storage_test
+
+import (
+	"testing"
+
+	"github.com/chilly266futon/orderService/internal/storage"
+	"github.com/chilly266futon/orderService/internal/storage/storagetest"
+)
+
+func TestMemoryOrderRepository(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) storage.OrderRepository {
+		return storage.NewMemoryOrderRepository()
+	})
+}
@@ -0,0 +1,43 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/chilly266futon/orderService/internal/domain"
+)
+
+// Outbox очередь доменных событий, записанных вместе с изменением заказа.
+// Add и Update репозитория атомарно добавляют в нее несохраненные события заказа,
+// назначая им возрастающий Sequence. События становятся видны в порядке Sequence:
+// если видно событие n, видны и все события до него, поэтому Sequence годится как курсор чтения
+type Outbox interface {
+	// UnpublishedEvents возвращает до limit неопубликованных событий в порядке Sequence
+	UnpublishedEvents(ctx context.Context, limit int) ([]domain.OrderEvent, error)
+	// MarkPublished помечает события опубликованными. Неизвестные номера игнорируются
+	MarkPublished(ctx context.Context, sequences []int64) error
+	// LastSequence возвращает Sequence последнего записанного события, 0 - outbox пуст
+	LastSequence(ctx context.Context) (int64, error)
+	// EventsAfter возвращает события с Sequence больше filter.AfterSequence в порядке Sequence
+	// независимо от того, опубликованы ли они
+	EventsAfter(ctx context.Context, filter EventFilter) ([]domain.OrderEvent, error)
+}
+
+// EventFilter условия выборки событий. Пустые поля не ограничивают выборку
+type EventFilter struct {
+	UserID        string
+	OrderID       string
+	AfterSequence int64
+	// Limit максимальное число событий, 0 - без ограничения
+	Limit int
+}
+
+// Match проверяет событие на соответствие фильтру без учета AfterSequence и Limit
+func (f EventFilter) Match(event domain.OrderEvent) bool {
+	if f.UserID != "" && event.UserID != f.UserID {
+		return false
+	}
+	if f.OrderID != "" && event.OrderID != f.OrderID {
+		return false
+	}
+	return true
+}
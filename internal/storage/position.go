@@ -0,0 +1,36 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/chilly266futon/orderService/internal/domain"
+)
+
+// positionPageSize сколько заказов читать за раз при сборе позиции
+const positionPageSize = 500
+
+// LoadPosition собирает позицию пользователя на рынке по всем его заказам.
+// Заказ excludeID учитывается только исполнениями: его остаток проверяет вызывающий
+func LoadPosition(ctx context.Context, repo OrderRepository, userID, marketID, excludeID string) (domain.Position, error) {
+	filter := OrderFilter{UserID: userID, MarketID: marketID, Limit: positionPageSize}
+
+	var position domain.Position
+	for {
+		page, err := repo.List(ctx, filter)
+		if err != nil {
+			return domain.Position{}, err
+		}
+		for _, o := range page {
+			if o.ID == excludeID {
+				position.Net = position.Net.Add(o.SignedFilledQuantity())
+				continue
+			}
+			position.Add(o)
+		}
+		if len(page) < positionPageSize {
+			return position, nil
+		}
+		cursor := CursorOf(page[len(page)-1])
+		filter.After = &cursor
+	}
+}
@@ -0,0 +1,97 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/chilly266futon/orderService/internal/domain"
+	"github.com/chilly266futon/orderService/internal/storage"
+)
+
+// AuditRepository журнал действий администраторов в таблице audit_log
+type AuditRepository struct {
+	db *sql.DB
+}
+
+var _ storage.AuditRepository = (*AuditRepository)(nil)
+
+func NewAuditRepository(db *sql.DB) *AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+func (r *AuditRepository) Append(ctx context.Context, record domain.AuditRecord) error {
+	if _, err := r.db.ExecContext(ctx, `
+		INSERT INTO audit_log (id, actor, action, order_id, market_id, reason, outcome, details, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		record.ID,
+		record.Actor,
+		record.Action,
+		record.OrderID,
+		record.MarketID,
+		record.Reason,
+		record.Outcome,
+		record.Details,
+		record.At,
+	); err != nil {
+		return fmt.Errorf("failed to insert audit record: %w", err)
+	}
+	return nil
+}
+
+func (r *AuditRepository) List(ctx context.Context, filter storage.AuditFilter) ([]domain.AuditRecord, error) {
+	var (
+		conds []string
+		args  []any
+	)
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if filter.OrderID != "" {
+		conds = append(conds, "order_id = "+arg(filter.OrderID))
+	}
+	if filter.Actor != "" {
+		conds = append(conds, "actor = "+arg(filter.Actor))
+	}
+
+	query := `SELECT id, actor, action, order_id, market_id, reason, outcome, details, created_at FROM audit_log`
+	if len(conds) > 0 {
+		query += ` WHERE ` + strings.Join(conds, " AND ")
+	}
+	query += ` ORDER BY seq DESC`
+	if filter.Limit > 0 {
+		query += ` LIMIT ` + arg(filter.Limit)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query audit log: %w", err)
+	}
+	defer rows.Close()
+
+	result := make([]domain.AuditRecord, 0)
+	for rows.Next() {
+		var record domain.AuditRecord
+		if err := rows.Scan(
+			&record.ID,
+			&record.Actor,
+			&record.Action,
+			&record.OrderID,
+			&record.MarketID,
+			&record.Reason,
+			&record.Outcome,
+			&record.Details,
+			&record.At,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan audit record: %w", err)
+		}
+		result = append(result, record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query audit log: %w", err)
+	}
+	return result, nil
+}
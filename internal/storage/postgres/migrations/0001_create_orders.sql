@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS orders (
+    id         TEXT PRIMARY KEY,
+    user_id    TEXT           NOT NULL,
+    market_id  TEXT           NOT NULL,
+    type       TEXT           NOT NULL,
+    status     TEXT           NOT NULL,
+    price      NUMERIC(36, 18) NOT NULL,
+    quantity   NUMERIC(36, 18) NOT NULL,
+    created_at TIMESTAMPTZ    NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS orders_user_id_idx ON orders (user_id);
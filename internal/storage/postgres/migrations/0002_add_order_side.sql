@@ -0,0 +1 @@
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS side TEXT NOT NULL DEFAULT 'UNSPECIFIED';
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS order_transitions (
+    id          BIGSERIAL PRIMARY KEY,
+    order_id    TEXT        NOT NULL REFERENCES orders (id),
+    from_status TEXT        NOT NULL,
+    to_status   TEXT        NOT NULL,
+    reason      TEXT        NOT NULL,
+    actor       TEXT        NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS order_transitions_order_id_idx ON order_transitions (order_id, id);
@@ -0,0 +1,12 @@
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS filled_quantity NUMERIC(36, 18) NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS avg_fill_price NUMERIC(36, 18) NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS order_fills (
+    seq        BIGSERIAL      NOT NULL,
+    order_id   TEXT           NOT NULL REFERENCES orders (id),
+    trade_id   TEXT           NOT NULL,
+    price      NUMERIC(36, 18) NOT NULL,
+    quantity   NUMERIC(36, 18) NOT NULL,
+    created_at TIMESTAMPTZ    NOT NULL,
+    PRIMARY KEY (order_id, trade_id)
+);
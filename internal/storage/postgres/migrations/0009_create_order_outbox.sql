@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS order_outbox (
+    seq             BIGSERIAL       PRIMARY KEY,
+    event_id        TEXT            NOT NULL UNIQUE,
+    type            TEXT            NOT NULL,
+    order_id        TEXT            NOT NULL REFERENCES orders (id),
+    user_id         TEXT            NOT NULL,
+    market_id       TEXT            NOT NULL,
+    status          TEXT            NOT NULL,
+    filled_quantity NUMERIC(36, 18) NOT NULL,
+    reason          TEXT            NOT NULL DEFAULT '',
+    trade_id        TEXT,
+    fill_price      NUMERIC(36, 18),
+    fill_quantity   NUMERIC(36, 18),
+    occurred_at     TIMESTAMPTZ     NOT NULL,
+    published_at    TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS order_outbox_unpublished_idx ON order_outbox (seq) WHERE published_at IS NULL;
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS order_outbox_user_seq_idx ON order_outbox (user_id, seq);
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS audit_log (
+    seq        BIGSERIAL   PRIMARY KEY,
+    id         TEXT        NOT NULL UNIQUE,
+    actor      TEXT        NOT NULL,
+    action     TEXT        NOT NULL,
+    order_id   TEXT        NOT NULL DEFAULT '',
+    market_id  TEXT        NOT NULL DEFAULT '',
+    reason     TEXT        NOT NULL DEFAULT '',
+    outcome    TEXT        NOT NULL,
+    details    TEXT        NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS audit_log_order_idx ON audit_log (order_id, seq);
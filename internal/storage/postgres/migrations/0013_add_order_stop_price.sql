@@ -0,0 +1 @@
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS stop_price NUMERIC(36, 18) NOT NULL DEFAULT 0;
@@ -0,0 +1 @@
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS triggered_at TIMESTAMPTZ;
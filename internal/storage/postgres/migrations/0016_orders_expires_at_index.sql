@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS orders_expires_at_idx ON orders (expires_at) WHERE expires_at IS NOT NULL;
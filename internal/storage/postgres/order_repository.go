@@ -0,0 +1,574 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	_ "github.com/jackc/pgx/v5/stdlib"
+
+	"github.com/chilly266futon/orderService/internal/domain"
+	"github.com/chilly266futon/orderService/internal/storage"
+)
+
+type Config struct {
+	DSN             string
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+}
+
+const (
+	// uniqueViolation код ошибки PostgreSQL при нарушении уникального ключа
+	uniqueViolation = "23505"
+	// serializationFailure и deadlockDetected транзакция прервана из-за параллельной транзакции,
+	// ее можно повторить на свежем состоянии
+	serializationFailure = "40001"
+	deadlockDetected     = "40P01"
+
+	clientOrderIDIndex = "orders_user_client_order_id_idx"
+)
+
+// OrderRepository хранит заказы в PostgreSQL
+type OrderRepository struct {
+	db *sql.DB
+}
+
+var _ storage.OrderRepository = (*OrderRepository)(nil)
+
+func NewOrderRepository(ctx context.Context, cfg Config) (*OrderRepository, error) {
+	db, err := sql.Open("pgx", cfg.DSN)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open postgres: %w", err)
+	}
+
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to connect to postgres: %w", err)
+	}
+
+	return &OrderRepository{db: db}, nil
+}
+
+// DB возвращает пул соединений, например для применения миграций
+func (s *OrderRepository) DB() *sql.DB {
+	return s.db
+}
+
+func (s *OrderRepository) Close() error {
+	return s.db.Close()
+}
+
+const orderColumns = `id, user_id, market_id, type, side, status, price, quantity, created_at, updated_at,
+	filled_quantity, avg_fill_price, version, client_order_id, stop_price, time_in_force, expires_at,
+	post_only, reduce_only, triggered_at, submitted_price, submitted_quantity, requeued_at`
+
+func (s *OrderRepository) GetByID(ctx context.Context, id string) (*domain.Order, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT `+orderColumns+` FROM orders WHERE id = $1`, id)
+
+	order, err := scanOrder(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrOrderNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	return order, nil
+}
+
+func (s *OrderRepository) Add(ctx context.Context, order *domain.Order) error {
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO orders (`+orderColumns+`)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, 1, NULLIF($13, ''), $14, $15, $16, $17, $18, $19, $20, $21, $22)`,
+			order.ID,
+			order.UserID,
+			order.MarketID,
+			order.Type.String(),
+			order.Side.String(),
+			order.Status.String(),
+			order.Price,
+			order.Quantity,
+			order.CreatedAt,
+			order.UpdatedAt,
+			order.FilledQuantity,
+			order.AvgFillPrice,
+			order.ClientOrderID,
+			order.StopPrice,
+			order.TimeInForce.String(),
+			nullTime(order.ExpiresAt),
+			order.PostOnly,
+			order.ReduceOnly,
+			nullTime(order.TriggeredAt),
+			order.SubmittedPrice,
+			order.SubmittedQuantity,
+			nullTime(order.RequeuedAt),
+		)
+		if isUniqueViolation(err, clientOrderIDIndex) {
+			return domain.ErrDuplicateClientOrderID
+		}
+		if isUniqueViolation(err, "") {
+			return domain.ErrOrderAlreadyExists
+		}
+		if err != nil {
+			return fmt.Errorf("failed to insert order: %w", err)
+		}
+
+		return s.savePending(ctx, tx, order)
+	}, order, 1)
+}
+
+func (s *OrderRepository) Update(ctx context.Context, order *domain.Order) error {
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		if err := s.update(ctx, tx, order); err != nil {
+			return err
+		}
+		return s.savePending(ctx, tx, order)
+	}, order, order.Version+1)
+}
+
+// UpdateMany сначала обновляет все строки заказов в порядке ID, затем пишет их изменения и события
+// под одной блокировкой outbox. Иначе сделка, удерживающая строку одного заказа и блокировку outbox,
+// ждала бы строку второго, которую держит Update, ожидающий ту же блокировку
+func (s *OrderRepository) UpdateMany(ctx context.Context, orders ...*domain.Order) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	sorted := slices.Clone(orders)
+	slices.SortFunc(sorted, func(a, b *domain.Order) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	for _, order := range sorted {
+		if err := s.update(ctx, tx, order); err != nil {
+			return retryable(err)
+		}
+	}
+	if err := s.savePending(ctx, tx, orders...); err != nil {
+		return retryable(err)
+	}
+	if err := tx.Commit(); err != nil {
+		return retryable(fmt.Errorf("failed to commit tx: %w", err))
+	}
+
+	for _, order := range orders {
+		order.MarkPersisted()
+		order.Version++
+	}
+	return nil
+}
+
+// update сохраняет строку заказа с проверкой версии в транзакции tx. Несохраненные изменения пишет savePending
+func (s *OrderRepository) update(ctx context.Context, tx *sql.Tx, order *domain.Order) error {
+	res, err := tx.ExecContext(ctx, `
+		UPDATE orders
+		SET user_id = $2, market_id = $3, type = $4, side = $5, status = $6, price = $7, quantity = $8,
+		    updated_at = $9, filled_quantity = $10, avg_fill_price = $11, stop_price = $13,
+		    time_in_force = $14, expires_at = $15, post_only = $16, reduce_only = $17, triggered_at = $18,
+		    submitted_price = $19, submitted_quantity = $20, requeued_at = $21, version = version + 1
+		WHERE id = $1 AND version = $12`,
+		order.ID,
+		order.UserID,
+		order.MarketID,
+		order.Type.String(),
+		order.Side.String(),
+		order.Status.String(),
+		order.Price,
+		order.Quantity,
+		order.UpdatedAt,
+		order.FilledQuantity,
+		order.AvgFillPrice,
+		order.Version,
+		order.StopPrice,
+		order.TimeInForce.String(),
+		nullTime(order.ExpiresAt),
+		order.PostOnly,
+		order.ReduceOnly,
+		nullTime(order.TriggeredAt),
+		order.SubmittedPrice,
+		order.SubmittedQuantity,
+		nullTime(order.RequeuedAt),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update order: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to update order: %w", err)
+	}
+	if affected == 0 {
+		if err := s.ensureExists(ctx, order.ID); err != nil {
+			return err
+		}
+		return domain.ErrVersionConflict
+	}
+	return nil
+}
+
+// inTx выполняет fn в транзакции и после коммита сбрасывает несохраненные изменения заказа
+// и выставляет ему сохраненную версию
+func (s *OrderRepository) inTx(ctx context.Context, fn func(tx *sql.Tx) error, order *domain.Order, version int64) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return retryable(err)
+	}
+	if err := tx.Commit(); err != nil {
+		return retryable(fmt.Errorf("failed to commit tx: %w", err))
+	}
+
+	order.MarkPersisted()
+	order.Version = version
+	return nil
+}
+
+// savePending записывает несохраненные переходы статусов, исполнения и события заказов
+func (s *OrderRepository) savePending(ctx context.Context, tx *sql.Tx, orders ...*domain.Order) error {
+	for _, order := range orders {
+		if err := s.saveHistory(ctx, tx, order); err != nil {
+			return err
+		}
+	}
+	return s.saveEvents(ctx, tx, orders...)
+}
+
+// saveHistory записывает несохраненные исполнения и переходы статусов заказа
+func (s *OrderRepository) saveHistory(ctx context.Context, tx *sql.Tx, order *domain.Order) error {
+	for _, fill := range order.PendingFills() {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO order_fills (order_id, trade_id, price, quantity, created_at)
+			VALUES ($1, $2, $3, $4, $5)`,
+			fill.OrderID,
+			fill.TradeID,
+			fill.Price,
+			fill.Quantity,
+			fill.At,
+		)
+		if isUniqueViolation(err, "") {
+			return domain.ErrDuplicateFill
+		}
+		if err != nil {
+			return fmt.Errorf("failed to insert order fill: %w", err)
+		}
+	}
+
+	for _, tr := range order.PendingTransitions() {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO order_transitions (order_id, from_status, to_status, reason, actor, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6)`,
+			tr.OrderID,
+			tr.From.String(),
+			tr.To.String(),
+			tr.Reason,
+			tr.Actor,
+			tr.At,
+		); err != nil {
+			return fmt.Errorf("failed to insert order transition: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *OrderRepository) GetByUserID(ctx context.Context, userID string) ([]*domain.Order, error) {
+	return s.queryOrders(ctx, `SELECT `+orderColumns+` FROM orders WHERE user_id = $1`, userID)
+}
+
+func (s *OrderRepository) GetByClientOrderID(ctx context.Context, userID, clientOrderID string) (*domain.Order, error) {
+	row := s.db.QueryRowContext(ctx,
+		`SELECT `+orderColumns+` FROM orders WHERE user_id = $1 AND client_order_id = $2`,
+		userID, clientOrderID,
+	)
+
+	order, err := scanOrder(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrOrderNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	return order, nil
+}
+
+func (s *OrderRepository) List(ctx context.Context, filter storage.OrderFilter) ([]*domain.Order, error) {
+	var (
+		conds []string
+		args  []any
+	)
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if filter.UserID != "" {
+		conds = append(conds, "user_id = "+arg(filter.UserID))
+	}
+	if filter.MarketID != "" {
+		conds = append(conds, "market_id = "+arg(filter.MarketID))
+	}
+	if len(filter.Statuses) > 0 {
+		conds = append(conds, "status = ANY("+arg(stringsOf(filter.Statuses))+")")
+	}
+	if len(filter.Types) > 0 {
+		conds = append(conds, "type = ANY("+arg(stringsOf(filter.Types))+")")
+	}
+	if !filter.CreatedFrom.IsZero() {
+		conds = append(conds, "created_at >= "+arg(filter.CreatedFrom))
+	}
+	if !filter.CreatedTo.IsZero() {
+		conds = append(conds, "created_at < "+arg(filter.CreatedTo))
+	}
+	if !filter.ExpiresTo.IsZero() {
+		conds = append(conds, "expires_at < "+arg(filter.ExpiresTo))
+	}
+	if filter.After != nil {
+		conds = append(conds, "(created_at, id) < ("+arg(filter.After.CreatedAt)+", "+arg(filter.After.ID)+")")
+	}
+
+	query := `SELECT ` + orderColumns + ` FROM orders`
+	if len(conds) > 0 {
+		query += ` WHERE ` + strings.Join(conds, " AND ")
+	}
+	query += ` ORDER BY created_at DESC, id DESC`
+	if filter.Limit > 0 {
+		query += ` LIMIT ` + arg(filter.Limit)
+	}
+
+	return s.queryOrders(ctx, query, args...)
+}
+
+func (s *OrderRepository) Count(ctx context.Context) (int, error) {
+	var count int
+	if err := s.db.QueryRowContext(ctx, `SELECT count(*) FROM orders`).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count orders: %w", err)
+	}
+	return count, nil
+}
+
+func (s *OrderRepository) History(ctx context.Context, orderID string) ([]domain.OrderTransition, error) {
+	if err := s.ensureExists(ctx, orderID); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT order_id, from_status, to_status, reason, actor, created_at
+		FROM order_transitions
+		WHERE order_id = $1
+		ORDER BY id`, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query order history: %w", err)
+	}
+	defer rows.Close()
+
+	result := make([]domain.OrderTransition, 0)
+	for rows.Next() {
+		var (
+			tr       domain.OrderTransition
+			from, to string
+		)
+		if err := rows.Scan(&tr.OrderID, &from, &to, &tr.Reason, &tr.Actor, &tr.At); err != nil {
+			return nil, fmt.Errorf("failed to scan order transition: %w", err)
+		}
+		if tr.From, err = parseStoredStatus(from); err != nil {
+			return nil, err
+		}
+		if tr.To, err = parseStoredStatus(to); err != nil {
+			return nil, err
+		}
+		result = append(result, tr)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query order history: %w", err)
+	}
+	return result, nil
+}
+
+func (s *OrderRepository) Fills(ctx context.Context, orderID string) ([]domain.Fill, error) {
+	if err := s.ensureExists(ctx, orderID); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT order_id, trade_id, price, quantity, created_at
+		FROM order_fills
+		WHERE order_id = $1
+		ORDER BY seq`, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query order fills: %w", err)
+	}
+	defer rows.Close()
+
+	result := make([]domain.Fill, 0)
+	for rows.Next() {
+		var fill domain.Fill
+		if err := rows.Scan(&fill.OrderID, &fill.TradeID, &fill.Price, &fill.Quantity, &fill.At); err != nil {
+			return nil, fmt.Errorf("failed to scan order fill: %w", err)
+		}
+		result = append(result, fill)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query order fills: %w", err)
+	}
+	return result, nil
+}
+
+// ensureExists возвращает domain.ErrOrderNotFound, если заказа нет
+func (s *OrderRepository) ensureExists(ctx context.Context, orderID string) error {
+	var exists bool
+	if err := s.db.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM orders WHERE id = $1)`, orderID,
+	).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to get order: %w", err)
+	}
+	if !exists {
+		return domain.ErrOrderNotFound
+	}
+	return nil
+}
+
+func (s *OrderRepository) queryOrders(ctx context.Context, query string, args ...any) ([]*domain.Order, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query orders: %w", err)
+	}
+	defer rows.Close()
+
+	result := make([]*domain.Order, 0)
+	for rows.Next() {
+		order, err := scanOrder(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan order: %w", err)
+		}
+		result = append(result, order)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query orders: %w", err)
+	}
+	return result, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanOrder(row rowScanner) (*domain.Order, error) {
+	var (
+		order         domain.Order
+		orderType     string
+		orderSide     string
+		orderStatus   string
+		clientOrderID sql.NullString
+		timeInForce   string
+		expiresAt     sql.NullTime
+		triggeredAt   sql.NullTime
+		requeuedAt    sql.NullTime
+	)
+
+	if err := row.Scan(
+		&order.ID,
+		&order.UserID,
+		&order.MarketID,
+		&orderType,
+		&orderSide,
+		&orderStatus,
+		&order.Price,
+		&order.Quantity,
+		&order.CreatedAt,
+		&order.UpdatedAt,
+		&order.FilledQuantity,
+		&order.AvgFillPrice,
+		&order.Version,
+		&clientOrderID,
+		&order.StopPrice,
+		&timeInForce,
+		&expiresAt,
+		&order.PostOnly,
+		&order.ReduceOnly,
+		&triggeredAt,
+		&order.SubmittedPrice,
+		&order.SubmittedQuantity,
+		&requeuedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	order.ClientOrderID = clientOrderID.String
+	order.ExpiresAt = expiresAt.Time
+	order.TriggeredAt = triggeredAt.Time
+	order.RequeuedAt = requeuedAt.Time
+
+	var err error
+	if order.Type, err = domain.ParseOrderType(orderType); err != nil {
+		return nil, err
+	}
+	// заказы, созданные до появления стороны, хранятся как UNSPECIFIED
+	if orderSide != domain.OrderSide(domain.OrderSideUnspecified).String() {
+		if order.Side, err = domain.ParseOrderSide(orderSide); err != nil {
+			return nil, err
+		}
+	}
+	if order.Status, err = domain.ParseOrderStatus(orderStatus); err != nil {
+		return nil, err
+	}
+	if timeInForce != domain.TimeInForce(domain.TimeInForceUnspecified).String() {
+		if order.TimeInForce, err = domain.ParseTimeInForce(timeInForce); err != nil {
+			return nil, err
+		}
+	}
+	return &order, nil
+}
+
+// nullTime нулевое время хранится как NULL
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
+
+// isUniqueViolation проверяет нарушение уникальности. Пустой constraint - любое ограничение
+func isUniqueViolation(err error, constraint string) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != uniqueViolation {
+		return false
+	}
+	return constraint == "" || pgErr.ConstraintName == constraint
+}
+
+// retryable сводит прерывание транзакции из-за параллельной (взаимная блокировка, ошибка сериализации)
+// к конфликту версий: вызывающий перечитает заказы и повторит изменение
+func retryable(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && (pgErr.Code == deadlockDetected || pgErr.Code == serializationFailure) {
+		return fmt.Errorf("%w: %v", domain.ErrVersionConflict, err)
+	}
+	return err
+}
+
+func stringsOf[T fmt.Stringer](values []T) []string {
+	result := make([]string, len(values))
+	for i, v := range values {
+		result[i] = v.String()
+	}
+	return result
+}
+
+// parseStoredStatus разбирает статус из БД. UNSPECIFIED встречается в истории как исходный статус при создании
+func parseStoredStatus(s string) (domain.OrderStatus, error) {
+	if s == domain.OrderStatus(domain.OrderStatusUnspecified).String() {
+		return domain.OrderStatusUnspecified, nil
+	}
+	return domain.ParseOrderStatus(s)
+}
@@ -0,0 +1,48 @@
+package postgres_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/chilly266futon/orderService/internal/storage"
+	"github.com/chilly266futon/orderService/internal/storage/postgres"
+	"github.com/chilly266futon/orderService/internal/storage/storagetest"
+)
+
+// dsnEnv переменная окружения с DSN тестовой базы. Без нее тесты PostgreSQL пропускаются.
+// База очищается перед каждым подтестом, поэтому рабочую указывать нельзя
+const dsnEnv = "ORDER_SERVICE_TEST_POSTGRES_DSN"
+
+func TestOrderRepository(t *testing.T) {
+	dsn := os.Getenv(dsnEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set", dsnEnv)
+	}
+
+	ctx := context.Background()
+	repo, err := postgres.NewOrderRepository(ctx, postgres.Config{
+		DSN:             dsn,
+		MaxOpenConns:    10,
+		MaxIdleConns:    10,
+		ConnMaxLifetime: time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("NewOrderRepository() error = %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+
+	if err := postgres.Migrate(ctx, repo.DB()); err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+
+	storagetest.Run(t, func(t *testing.T) storage.OrderRepository {
+		_, err := repo.DB().ExecContext(ctx,
+			`TRUNCATE orders, order_transitions, order_fills, order_outbox RESTART IDENTITY CASCADE`)
+		if err != nil {
+			t.Fatalf("failed to truncate tables: %v", err)
+		}
+		return repo
+	})
+}
@@ -0,0 +1,194 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/chilly266futon/orderService/internal/domain"
+	"github.com/chilly266futon/orderService/internal/storage"
+)
+
+const outboxColumns = `seq, event_id, type, order_id, user_id, market_id, status, filled_quantity, reason,
+	trade_id, fill_price, fill_quantity, occurred_at`
+
+// saveEvents записывает несохраненные события заказов в outbox.
+// Номера seq выдаются под транзакционной блокировкой outbox, поэтому транзакции с событиями фиксируются
+// в порядке seq: читатель, увидевший событие n, уже видит все события до него.
+// Блокировка берется последней в транзакции, когда строки заказов уже обновлены
+func (s *OrderRepository) saveEvents(ctx context.Context, tx *sql.Tx, orders ...*domain.Order) error {
+	var events []domain.OrderEvent
+	for _, order := range orders {
+		events = append(events, order.PendingEvents()...)
+	}
+	if len(events) == 0 {
+		return nil
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`SELECT pg_advisory_xact_lock('order_outbox'::regclass::oid::int, 0)`,
+	); err != nil {
+		return fmt.Errorf("failed to lock order outbox: %w", err)
+	}
+
+	for _, event := range events {
+		var (
+			tradeID      sql.NullString
+			fillPrice    decimal.NullDecimal
+			fillQuantity decimal.NullDecimal
+		)
+		if event.Fill != nil {
+			tradeID = sql.NullString{String: event.Fill.TradeID, Valid: true}
+			fillPrice = decimal.NewNullDecimal(event.Fill.Price)
+			fillQuantity = decimal.NewNullDecimal(event.Fill.Quantity)
+		}
+
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO order_outbox (event_id, type, order_id, user_id, market_id, status, filled_quantity, reason,
+			                          trade_id, fill_price, fill_quantity, occurred_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`,
+			event.ID,
+			event.Type.String(),
+			event.OrderID,
+			event.UserID,
+			event.MarketID,
+			event.Status.String(),
+			event.FilledQuantity,
+			event.Reason,
+			tradeID,
+			fillPrice,
+			fillQuantity,
+			event.OccurredAt,
+		); err != nil {
+			return fmt.Errorf("failed to insert order event: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *OrderRepository) UnpublishedEvents(ctx context.Context, limit int) ([]domain.OrderEvent, error) {
+	query := `SELECT ` + outboxColumns + ` FROM order_outbox WHERE published_at IS NULL ORDER BY seq`
+	args := []any{}
+	if limit > 0 {
+		query += ` LIMIT $1`
+		args = append(args, limit)
+	}
+
+	return s.queryEvents(ctx, query, args...)
+}
+
+func (s *OrderRepository) EventsAfter(ctx context.Context, filter storage.EventFilter) ([]domain.OrderEvent, error) {
+	args := []any{filter.AfterSequence}
+	conds := []string{"seq > $1"}
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if filter.UserID != "" {
+		conds = append(conds, "user_id = "+arg(filter.UserID))
+	}
+	if filter.OrderID != "" {
+		conds = append(conds, "order_id = "+arg(filter.OrderID))
+	}
+
+	query := `SELECT ` + outboxColumns + ` FROM order_outbox WHERE ` + strings.Join(conds, " AND ") + ` ORDER BY seq`
+	if filter.Limit > 0 {
+		query += ` LIMIT ` + arg(filter.Limit)
+	}
+
+	return s.queryEvents(ctx, query, args...)
+}
+
+func (s *OrderRepository) LastSequence(ctx context.Context) (int64, error) {
+	var seq int64
+	if err := s.db.QueryRowContext(ctx, `SELECT COALESCE(MAX(seq), 0) FROM order_outbox`).Scan(&seq); err != nil {
+		return 0, fmt.Errorf("failed to query last order event: %w", err)
+	}
+	return seq, nil
+}
+
+func (s *OrderRepository) MarkPublished(ctx context.Context, sequences []int64) error {
+	if len(sequences) == 0 {
+		return nil
+	}
+
+	if _, err := s.db.ExecContext(ctx, `
+		UPDATE order_outbox SET published_at = now()
+		WHERE seq = ANY($1) AND published_at IS NULL`, sequences,
+	); err != nil {
+		return fmt.Errorf("failed to mark order events published: %w", err)
+	}
+	return nil
+}
+
+func (s *OrderRepository) queryEvents(ctx context.Context, query string, args ...any) ([]domain.OrderEvent, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query order events: %w", err)
+	}
+	defer rows.Close()
+
+	result := make([]domain.OrderEvent, 0)
+	for rows.Next() {
+		event, err := scanEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan order event: %w", err)
+		}
+		result = append(result, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query order events: %w", err)
+	}
+	return result, nil
+}
+
+func scanEvent(row rowScanner) (domain.OrderEvent, error) {
+	var (
+		event        domain.OrderEvent
+		eventType    string
+		status       string
+		tradeID      sql.NullString
+		fillPrice    decimal.NullDecimal
+		fillQuantity decimal.NullDecimal
+	)
+
+	if err := row.Scan(
+		&event.Sequence,
+		&event.ID,
+		&eventType,
+		&event.OrderID,
+		&event.UserID,
+		&event.MarketID,
+		&status,
+		&event.FilledQuantity,
+		&event.Reason,
+		&tradeID,
+		&fillPrice,
+		&fillQuantity,
+		&event.OccurredAt,
+	); err != nil {
+		return domain.OrderEvent{}, err
+	}
+
+	var err error
+	if event.Type, err = domain.ParseOrderEventType(eventType); err != nil {
+		return domain.OrderEvent{}, err
+	}
+	if event.Status, err = domain.ParseOrderStatus(status); err != nil {
+		return domain.OrderEvent{}, err
+	}
+	if tradeID.Valid {
+		event.Fill = &domain.Fill{
+			OrderID:  event.OrderID,
+			TradeID:  tradeID.String,
+			Price:    fillPrice.Decimal,
+			Quantity: fillQuantity.Decimal,
+			At:       event.OccurredAt,
+		}
+	}
+	return event, nil
+}
@@ -0,0 +1,37 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// UserRoleRepository роли пользователей из таблицы user_roles
+type UserRoleRepository struct {
+	db *sql.DB
+}
+
+func NewUserRoleRepository(db *sql.DB) *UserRoleRepository {
+	return &UserRoleRepository{db: db}
+}
+
+func (r *UserRoleRepository) UserRoles(ctx context.Context, userID string) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT role FROM user_roles WHERE user_id = $1 ORDER BY role`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query user roles: %w", err)
+	}
+	defer rows.Close()
+
+	result := make([]string, 0)
+	for rows.Next() {
+		var role string
+		if err := rows.Scan(&role); err != nil {
+			return nil, fmt.Errorf("failed to scan user role: %w", err)
+		}
+		result = append(result, role)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query user roles: %w", err)
+	}
+	return result, nil
+}
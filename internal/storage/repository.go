@@ -0,0 +1,101 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/chilly266futon/orderService/internal/domain"
+)
+
+// OrderRepository хранилище заказов.
+// Реализации возвращают domain.ErrOrderNotFound, если заказа нет, и domain.ErrOrderAlreadyExists
+// при повторном Add. Возвращаемые заказы - копии: их изменение не затрагивает хранилище до вызова Update.
+// Update - compare-and-swap по Version: если заказ успели изменить после чтения, возвращается
+// domain.ErrVersionConflict. Add сохраняет заказ с версией 1, успешный Update увеличивает версию на 1.
+// Add и Update атомарно с заказом сохраняют его несохраненные переходы статусов, исполнения
+// и события (в Outbox) и вызывают MarkPersisted. Если исполнение с таким TradeID уже сохранено, возвращается
+// domain.ErrDuplicateFill и ничего не записывается
+type OrderRepository interface {
+	Outbox
+
+	GetByID(ctx context.Context, id string) (*domain.Order, error)
+	Add(ctx context.Context, order *domain.Order) error
+	Update(ctx context.Context, order *domain.Order) error
+	// UpdateMany сохраняет несколько заказов атомарно: при конфликте версии или отсутствии
+	// хотя бы одного заказа не сохраняется ни один. Используется для сделки между двумя заказами
+	UpdateMany(ctx context.Context, orders ...*domain.Order) error
+	GetByUserID(ctx context.Context, userID string) ([]*domain.Order, error)
+	// GetByClientOrderID ищет заказ пользователя по ключу идемпотентности.
+	// Add возвращает domain.ErrDuplicateClientOrderID, если ключ у пользователя уже занят
+	GetByClientOrderID(ctx context.Context, userID, clientOrderID string) (*domain.Order, error)
+	// List возвращает заказы, подходящие под фильтр, в порядке (CreatedAt, ID) по убыванию
+	List(ctx context.Context, filter OrderFilter) ([]*domain.Order, error)
+	Count(ctx context.Context) (int, error)
+	// History возвращает переходы статусов заказа в порядке их записи
+	History(ctx context.Context, orderID string) ([]domain.OrderTransition, error)
+	// Fills возвращает исполнения заказа в порядке их записи
+	Fills(ctx context.Context, orderID string) ([]domain.Fill, error)
+}
+
+// OrderFilter условия выборки заказов. Пустые поля не ограничивают выборку
+type OrderFilter struct {
+	UserID      string
+	MarketID    string
+	Statuses    []domain.OrderStatus
+	Types       []domain.OrderType
+	CreatedFrom time.Time // включительно
+	CreatedTo   time.Time // не включительно
+	// ExpiresTo только заказы со сроком действия, истекающим раньше ExpiresTo
+	ExpiresTo time.Time
+
+	// After ключ последнего заказа предыдущей страницы. Возвращаются заказы строго после него
+	After *Cursor
+	// Limit максимальное число заказов, 0 - без ограничения
+	Limit int
+}
+
+// Cursor позиция в выборке, отсортированной по (CreatedAt, ID) по убыванию
+type Cursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// CursorOf возвращает курсор, указывающий на заказ
+func CursorOf(order *domain.Order) Cursor {
+	return Cursor{CreatedAt: order.CreatedAt, ID: order.ID}
+}
+
+// Match проверяет заказ на соответствие фильтру без учета After и Limit
+func (f OrderFilter) Match(order *domain.Order) bool {
+	if f.UserID != "" && order.UserID != f.UserID {
+		return false
+	}
+	if f.MarketID != "" && order.MarketID != f.MarketID {
+		return false
+	}
+	if len(f.Statuses) > 0 && !contains(f.Statuses, order.Status) {
+		return false
+	}
+	if len(f.Types) > 0 && !contains(f.Types, order.Type) {
+		return false
+	}
+	if !f.CreatedFrom.IsZero() && order.CreatedAt.Before(f.CreatedFrom) {
+		return false
+	}
+	if !f.CreatedTo.IsZero() && !order.CreatedAt.Before(f.CreatedTo) {
+		return false
+	}
+	if !f.ExpiresTo.IsZero() && (order.ExpiresAt.IsZero() || !order.ExpiresAt.Before(f.ExpiresTo)) {
+		return false
+	}
+	return true
+}
+
+func contains[T comparable](values []T, v T) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
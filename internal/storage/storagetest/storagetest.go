@@ -0,0 +1,1252 @@
+// Package storagetest содержит общий набор проверок для реализаций storage.OrderRepository.
+// Любая реализация (память, SQL, embedded KV) подключает его из своего _test.go:
+//
+//	func TestOrderRepository(t *testing.T) {
+//		storagetest.Run(t, func(t *testing.T) storage.OrderRepository {
+//			return storage.NewMemoryOrderRepository()
+//		})
+//	}
+package storagetest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/chilly266futon/orderService/internal/domain"
+	"github.com/chilly266futon/orderService/internal/storage"
+)
+
+// Factory возвращает пустое хранилище. Вызывается для каждого подтеста
+type Factory func(t *testing.T) storage.OrderRepository
+
+// Run прогоняет все проверки для реализации, созданной newRepo
+func Run(t *testing.T, newRepo Factory) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, repo storage.OrderRepository)
+	}{
+		{"GetByIDNotFound", testGetByIDNotFound},
+		{"AddAndGet", testAddAndGet},
+		{"AddDuplicate", testAddDuplicate},
+		{"StopOrder", testStopOrder},
+		{"TimeInForce", testTimeInForce},
+		{"ExecutionFlags", testExecutionFlags},
+		{"Update", testUpdate},
+		{"Amend", testAmend},
+		{"UpdateNotFound", testUpdateNotFound},
+		{"ReturnsCopies", testReturnsCopies},
+		{"GetByUserID", testGetByUserID},
+		{"ClientOrderID", testClientOrderID},
+		{"DuplicateClientOrderID", testDuplicateClientOrderID},
+		{"ListFilters", testListFilters},
+		{"ListPagination", testListPagination},
+		{"Count", testCount},
+		{"History", testHistory},
+		{"HistoryNotFound", testHistoryNotFound},
+		{"Version", testVersion},
+		{"VersionConflict", testVersionConflict},
+		{"UpdateMany", testUpdateMany},
+		{"UpdateManyConflict", testUpdateManyConflict},
+		{"ConcurrentUpdates", testConcurrentUpdates},
+		{"ConcurrentCancelAndTrade", testConcurrentCancelAndTrade},
+		{"Fills", testFills},
+		{"DuplicateFill", testDuplicateFill},
+		{"Outbox", testOutbox},
+		{"OutboxMarkPublished", testOutboxMarkPublished},
+		{"OutboxAtomic", testOutboxAtomic},
+		{"EventsAfter", testEventsAfter},
+		{"LastSequence", testLastSequence},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, newRepo(t))
+		})
+	}
+}
+
+// NewOrder создает валидный заказ в статусе CREATED для указанного пользователя
+func NewOrder(userID string) *domain.Order {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	return &domain.Order{
+		ID:          uuid.NewString(),
+		UserID:      userID,
+		MarketID:    uuid.NewString(),
+		Type:        domain.OrderTypeLimit,
+		Side:        domain.OrderSideBuy,
+		Status:      domain.OrderStatusCreated,
+		Price:       decimal.RequireFromString("101.5"),
+		Quantity:    decimal.RequireFromString("0.25"),
+		TimeInForce: domain.TimeInForceGTC,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+func testGetByIDNotFound(t *testing.T, repo storage.OrderRepository) {
+	_, err := repo.GetByID(context.Background(), uuid.NewString())
+	if !errors.Is(err, domain.ErrOrderNotFound) {
+		t.Fatalf("GetByID() error = %v, want %v", err, domain.ErrOrderNotFound)
+	}
+}
+
+func testAddAndGet(t *testing.T, repo storage.OrderRepository) {
+	ctx := context.Background()
+	want := NewOrder(uuid.NewString())
+
+	mustAdd(t, repo, want)
+
+	got, err := repo.GetByID(ctx, want.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	AssertOrderEqual(t, got, want)
+}
+
+func testStopOrder(t *testing.T, repo storage.OrderRepository) {
+	ctx := context.Background()
+	want := NewOrder(uuid.NewString())
+	want.Type = domain.OrderTypeStopMarket
+	want.Side = domain.OrderSideSell
+	want.Price = decimal.Zero
+	want.StopPrice = decimal.RequireFromString("98.25")
+
+	mustAdd(t, repo, want)
+
+	got, err := repo.GetByID(ctx, want.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	AssertOrderEqual(t, got, want)
+
+	// активация не меняет тип: заказ по-прежнему находится фильтром по стоп-типу
+	at := time.Now().UTC().Truncate(time.Microsecond)
+	if err := got.Trigger(decimal.RequireFromString("98"), at); err != nil {
+		t.Fatalf("Trigger() error = %v", err)
+	}
+	if err := repo.Update(ctx, got); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	triggered, err := repo.GetByID(ctx, want.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	AssertOrderEqual(t, triggered, got)
+	if triggered.Type != domain.OrderTypeStopMarket || !triggered.TriggeredAt.Equal(at) {
+		t.Fatalf("triggered order type = %s, triggered_at = %v", triggered.Type, triggered.TriggeredAt)
+	}
+
+	listed, err := repo.List(ctx, storage.OrderFilter{
+		UserID: want.UserID,
+		Types:  []domain.OrderType{domain.OrderTypeStopMarket},
+	})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(listed) != 1 || listed[0].ID != want.ID {
+		t.Fatalf("List(STOP_MARKET) = %d orders, want triggered order %s", len(listed), want.ID)
+	}
+}
+
+func testTimeInForce(t *testing.T, repo storage.OrderRepository) {
+	ctx := context.Background()
+	want := NewOrder(uuid.NewString())
+	want.TimeInForce = domain.TimeInForceGTD
+	want.ExpiresAt = want.CreatedAt.Add(time.Hour)
+
+	mustAdd(t, repo, want)
+
+	got, err := repo.GetByID(ctx, want.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	AssertOrderEqual(t, got, want)
+
+	if err := got.Expire(got.ExpiresAt); err != nil {
+		t.Fatalf("Expire() error = %v", err)
+	}
+	if err := repo.Update(ctx, got); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	expired, err := repo.GetByID(ctx, want.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	AssertOrderEqual(t, expired, got)
+}
+
+func testExecutionFlags(t *testing.T, repo storage.OrderRepository) {
+	ctx := context.Background()
+	want := NewOrder(uuid.NewString())
+	want.PostOnly = true
+
+	mustAdd(t, repo, want)
+
+	got, err := repo.GetByID(ctx, want.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	AssertOrderEqual(t, got, want)
+
+	got.PostOnly = false
+	got.ReduceOnly = true
+	if err := repo.Update(ctx, got); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	updated, err := repo.GetByID(ctx, want.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	AssertOrderEqual(t, updated, got)
+}
+
+func testAddDuplicate(t *testing.T, repo storage.OrderRepository) {
+	order := NewOrder(uuid.NewString())
+	mustAdd(t, repo, order)
+
+	err := repo.Add(context.Background(), order)
+	if !errors.Is(err, domain.ErrOrderAlreadyExists) {
+		t.Fatalf("Add() duplicate error = %v, want %v", err, domain.ErrOrderAlreadyExists)
+	}
+}
+
+func testUpdate(t *testing.T, repo storage.OrderRepository) {
+	ctx := context.Background()
+	order := NewOrder(uuid.NewString())
+	mustAdd(t, repo, order)
+
+	order.Status = domain.OrderStatusCancelled
+	order.Price = decimal.RequireFromString("99.75")
+	order.UpdatedAt = order.UpdatedAt.Add(time.Second)
+	if err := repo.Update(ctx, order); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	got, err := repo.GetByID(ctx, order.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	AssertOrderEqual(t, got, order)
+}
+
+func testAmend(t *testing.T, repo storage.OrderRepository) {
+	ctx := context.Background()
+	order := NewOrder(uuid.NewString())
+	order.Status = domain.OrderStatusOpen
+	mustAdd(t, repo, order)
+
+	price, quantity := order.Price, order.Quantity
+	at := time.Now().UTC().Truncate(time.Microsecond)
+	if err := order.Amend(decimal.RequireFromString("99.75"), decimal.RequireFromString("0.5"), domain.ActorSystem, at); err != nil {
+		t.Fatalf("Amend() error = %v", err)
+	}
+	if !order.RequeuedAt.Equal(at) {
+		t.Fatalf("RequeuedAt = %v after a price change, want %v", order.RequeuedAt, at)
+	}
+	if err := repo.Update(ctx, order); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	got, err := repo.GetByID(ctx, order.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	AssertOrderEqual(t, got, order)
+
+	gotPrice, gotQuantity := got.SubmittedTerms()
+	if !gotPrice.Equal(price) || !gotQuantity.Equal(quantity) {
+		t.Fatalf("SubmittedTerms() = %s, %s, want %s, %s", gotPrice, gotQuantity, price, quantity)
+	}
+}
+
+func testUpdateNotFound(t *testing.T, repo storage.OrderRepository) {
+	err := repo.Update(context.Background(), NewOrder(uuid.NewString()))
+	if !errors.Is(err, domain.ErrOrderNotFound) {
+		t.Fatalf("Update() error = %v, want %v", err, domain.ErrOrderNotFound)
+	}
+}
+
+func testReturnsCopies(t *testing.T, repo storage.OrderRepository) {
+	ctx := context.Background()
+	order := NewOrder(uuid.NewString())
+	mustAdd(t, repo, order)
+
+	// изменения исходного и полученного объекта не должны попадать в хранилище без Update
+	order.Status = domain.OrderStatusRejected
+	got, err := repo.GetByID(ctx, order.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	got.Status = domain.OrderStatusFilled
+
+	again, err := repo.GetByID(ctx, order.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if again.Status != domain.OrderStatusCreated {
+		t.Fatalf("stored status = %s, want %s", again.Status, domain.OrderStatus(domain.OrderStatusCreated))
+	}
+}
+
+func testGetByUserID(t *testing.T, repo storage.OrderRepository) {
+	ctx := context.Background()
+	userID := uuid.NewString()
+
+	mine := map[string]bool{}
+	for i := 0; i < 3; i++ {
+		order := NewOrder(userID)
+		mustAdd(t, repo, order)
+		mine[order.ID] = true
+	}
+	mustAdd(t, repo, NewOrder(uuid.NewString()))
+
+	got, err := repo.GetByUserID(ctx, userID)
+	if err != nil {
+		t.Fatalf("GetByUserID() error = %v", err)
+	}
+	if len(got) != len(mine) {
+		t.Fatalf("GetByUserID() returned %d orders, want %d", len(got), len(mine))
+	}
+	for _, order := range got {
+		if !mine[order.ID] {
+			t.Fatalf("GetByUserID() returned foreign order %s", order.ID)
+		}
+	}
+
+	empty, err := repo.GetByUserID(ctx, uuid.NewString())
+	if err != nil {
+		t.Fatalf("GetByUserID() error = %v", err)
+	}
+	if len(empty) != 0 {
+		t.Fatalf("GetByUserID() for unknown user returned %d orders", len(empty))
+	}
+}
+
+func testClientOrderID(t *testing.T, repo storage.OrderRepository) {
+	ctx := context.Background()
+
+	order := NewOrder(uuid.NewString())
+	order.ClientOrderID = "client-1"
+	mustAdd(t, repo, order)
+
+	got, err := repo.GetByClientOrderID(ctx, order.UserID, "client-1")
+	if err != nil {
+		t.Fatalf("GetByClientOrderID() error = %v", err)
+	}
+	AssertOrderEqual(t, got, order)
+
+	// ключ уникален только в пределах пользователя
+	if _, err := repo.GetByClientOrderID(ctx, uuid.NewString(), "client-1"); !errors.Is(err, domain.ErrOrderNotFound) {
+		t.Fatalf("GetByClientOrderID() other user error = %v, want %v", err, domain.ErrOrderNotFound)
+	}
+	other := NewOrder(uuid.NewString())
+	other.ClientOrderID = "client-1"
+	mustAdd(t, repo, other)
+
+	// заказы без ключа не конфликтуют между собой
+	mustAdd(t, repo, NewOrder(order.UserID))
+	mustAdd(t, repo, NewOrder(order.UserID))
+}
+
+func testDuplicateClientOrderID(t *testing.T, repo storage.OrderRepository) {
+	userID := uuid.NewString()
+
+	first := NewOrder(userID)
+	first.ClientOrderID = "client-1"
+	mustAdd(t, repo, first)
+
+	second := NewOrder(userID)
+	second.ClientOrderID = "client-1"
+	if err := repo.Add(context.Background(), second); !errors.Is(err, domain.ErrDuplicateClientOrderID) {
+		t.Fatalf("Add() error = %v, want %v", err, domain.ErrDuplicateClientOrderID)
+	}
+	if _, err := repo.GetByID(context.Background(), second.ID); !errors.Is(err, domain.ErrOrderNotFound) {
+		t.Fatalf("GetByID() rejected order error = %v, want %v", err, domain.ErrOrderNotFound)
+	}
+}
+
+func testListFilters(t *testing.T, repo storage.OrderRepository) {
+	ctx := context.Background()
+	userID := uuid.NewString()
+	marketID := uuid.NewString()
+	base := time.Now().UTC().Truncate(time.Microsecond)
+
+	limit := NewOrder(userID)
+	limit.MarketID = marketID
+	limit.CreatedAt = base.Add(-3 * time.Minute)
+	limit.TimeInForce = domain.TimeInForceGTD
+	limit.ExpiresAt = base
+
+	market := NewOrder(userID)
+	market.MarketID = marketID
+	market.Type = domain.OrderTypeMarket
+	market.CreatedAt = base.Add(-2 * time.Minute)
+
+	cancelled := NewOrder(userID)
+	cancelled.Status = domain.OrderStatusCancelled
+	cancelled.CreatedAt = base.Add(-time.Minute)
+	cancelled.TimeInForce = domain.TimeInForceGTD
+	cancelled.ExpiresAt = base.Add(time.Hour)
+
+	for _, o := range []*domain.Order{limit, market, cancelled, NewOrder(uuid.NewString())} {
+		mustAdd(t, repo, o)
+	}
+
+	tests := []struct {
+		name   string
+		filter storage.OrderFilter
+		want   []*domain.Order
+	}{
+		{
+			name:   "by user",
+			filter: storage.OrderFilter{UserID: userID},
+			want:   []*domain.Order{cancelled, market, limit},
+		},
+		{
+			name:   "by market",
+			filter: storage.OrderFilter{UserID: userID, MarketID: marketID},
+			want:   []*domain.Order{market, limit},
+		},
+		{
+			name:   "by status",
+			filter: storage.OrderFilter{UserID: userID, Statuses: []domain.OrderStatus{domain.OrderStatusCancelled}},
+			want:   []*domain.Order{cancelled},
+		},
+		{
+			name:   "by type",
+			filter: storage.OrderFilter{UserID: userID, Types: []domain.OrderType{domain.OrderTypeMarket}},
+			want:   []*domain.Order{market},
+		},
+		{
+			name: "by created range",
+			filter: storage.OrderFilter{
+				UserID:      userID,
+				CreatedFrom: market.CreatedAt,
+				CreatedTo:   cancelled.CreatedAt,
+			},
+			want: []*domain.Order{market},
+		},
+		{
+			name:   "by expiry",
+			filter: storage.OrderFilter{UserID: userID, ExpiresTo: base.Add(time.Minute)},
+			want:   []*domain.Order{limit},
+		},
+		{
+			name:   "limit",
+			filter: storage.OrderFilter{UserID: userID, Limit: 1},
+			want:   []*domain.Order{cancelled},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.List(ctx, tt.filter)
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			assertIDs(t, got, tt.want)
+		})
+	}
+}
+
+func testListPagination(t *testing.T, repo storage.OrderRepository) {
+	ctx := context.Background()
+	userID := uuid.NewString()
+	createdAt := time.Now().UTC().Truncate(time.Microsecond)
+
+	// половина заказов с одинаковым временем создания: порядок должен определяться ID
+	var want []*domain.Order
+	for i := 0; i < 7; i++ {
+		order := NewOrder(userID)
+		if i%2 == 0 {
+			order.CreatedAt = createdAt.Add(time.Duration(i) * time.Second)
+		} else {
+			order.CreatedAt = createdAt
+		}
+		mustAdd(t, repo, order)
+		want = append(want, order)
+	}
+
+	all, err := repo.List(ctx, storage.OrderFilter{UserID: userID})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(all) != len(want) {
+		t.Fatalf("List() returned %d orders, want %d", len(all), len(want))
+	}
+	for i := 1; i < len(all); i++ {
+		prev, cur := all[i-1], all[i]
+		if cur.CreatedAt.After(prev.CreatedAt) ||
+			(cur.CreatedAt.Equal(prev.CreatedAt) && cur.ID > prev.ID) {
+			t.Fatalf("List() is not sorted by (created_at, id) desc at %d", i)
+		}
+	}
+
+	var (
+		paged []*domain.Order
+		after *storage.Cursor
+	)
+	for page := 0; page < len(want); page++ {
+		got, err := repo.List(ctx, storage.OrderFilter{UserID: userID, After: after, Limit: 3})
+		if err != nil {
+			t.Fatalf("List() error = %v", err)
+		}
+		if len(got) == 0 {
+			break
+		}
+		paged = append(paged, got...)
+		c := storage.CursorOf(got[len(got)-1])
+		after = &c
+	}
+	assertIDs(t, paged, all)
+}
+
+func testCount(t *testing.T, repo storage.OrderRepository) {
+	ctx := context.Background()
+
+	before, err := repo.Count(ctx)
+	if err != nil {
+		t.Fatalf("Count() error = %v", err)
+	}
+
+	mustAdd(t, repo, NewOrder(uuid.NewString()))
+	mustAdd(t, repo, NewOrder(uuid.NewString()))
+
+	after, err := repo.Count(ctx)
+	if err != nil {
+		t.Fatalf("Count() error = %v", err)
+	}
+	if after-before != 2 {
+		t.Fatalf("Count() grew by %d, want 2", after-before)
+	}
+}
+
+func assertIDs(t *testing.T, got, want []*domain.Order) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("got %d orders, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i].ID != want[i].ID {
+			t.Fatalf("order[%d] = %s, want %s", i, got[i].ID, want[i].ID)
+		}
+	}
+}
+
+func testHistory(t *testing.T, repo storage.OrderRepository) {
+	ctx := context.Background()
+	at := time.Now().UTC().Truncate(time.Microsecond)
+
+	order := NewOrder(uuid.NewString())
+	order.Status = domain.OrderStatusUnspecified
+	if err := order.TransitionTo(domain.OrderStatusCreated, "order created", domain.UserActor(order.UserID), at); err != nil {
+		t.Fatalf("TransitionTo() error = %v", err)
+	}
+	mustAdd(t, repo, order)
+
+	if len(order.PendingTransitions()) != 0 {
+		t.Fatalf("Add() left %d pending transitions", len(order.PendingTransitions()))
+	}
+
+	if err := order.TransitionTo(domain.OrderStatusCancelled, "cancelled by user", domain.UserActor(order.UserID), at.Add(time.Second)); err != nil {
+		t.Fatalf("TransitionTo() error = %v", err)
+	}
+	if err := repo.Update(ctx, order); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	got, err := repo.History(ctx, order.ID)
+	if err != nil {
+		t.Fatalf("History() error = %v", err)
+	}
+
+	want := []domain.OrderTransition{
+		{OrderID: order.ID, From: domain.OrderStatusUnspecified, To: domain.OrderStatusCreated, Reason: "order created", Actor: domain.UserActor(order.UserID), At: at},
+		{OrderID: order.ID, From: domain.OrderStatusCreated, To: domain.OrderStatusCancelled, Reason: "cancelled by user", Actor: domain.UserActor(order.UserID), At: at.Add(time.Second)},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("History() returned %d transitions, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i].OrderID != want[i].OrderID ||
+			got[i].From != want[i].From ||
+			got[i].To != want[i].To ||
+			got[i].Reason != want[i].Reason ||
+			got[i].Actor != want[i].Actor ||
+			!got[i].At.Equal(want[i].At) {
+			t.Fatalf("transition[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func testHistoryNotFound(t *testing.T, repo storage.OrderRepository) {
+	_, err := repo.History(context.Background(), uuid.NewString())
+	if !errors.Is(err, domain.ErrOrderNotFound) {
+		t.Fatalf("History() error = %v, want %v", err, domain.ErrOrderNotFound)
+	}
+}
+
+func testVersion(t *testing.T, repo storage.OrderRepository) {
+	ctx := context.Background()
+	order := NewOrder(uuid.NewString())
+	mustAdd(t, repo, order)
+
+	if order.Version != 1 {
+		t.Fatalf("Add() set version %d, want 1", order.Version)
+	}
+
+	order.Status = domain.OrderStatusOpen
+	if err := repo.Update(ctx, order); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if order.Version != 2 {
+		t.Fatalf("Update() set version %d, want 2", order.Version)
+	}
+
+	got, err := repo.GetByID(ctx, order.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.Version != 2 {
+		t.Fatalf("stored version = %d, want 2", got.Version)
+	}
+}
+
+func testVersionConflict(t *testing.T, repo storage.OrderRepository) {
+	ctx := context.Background()
+	order := NewOrder(uuid.NewString())
+	mustAdd(t, repo, order)
+
+	first, err := repo.GetByID(ctx, order.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	second, err := repo.GetByID(ctx, order.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+
+	first.Status = domain.OrderStatusCancelled
+	if err := repo.Update(ctx, first); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	second.Status = domain.OrderStatusFilled
+	if err := repo.Update(ctx, second); !errors.Is(err, domain.ErrVersionConflict) {
+		t.Fatalf("Update() stale error = %v, want %v", err, domain.ErrVersionConflict)
+	}
+
+	got, err := repo.GetByID(ctx, order.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	AssertOrderEqual(t, got, first)
+}
+
+func testUpdateMany(t *testing.T, repo storage.OrderRepository) {
+	ctx := context.Background()
+	at := time.Now().UTC().Truncate(time.Microsecond)
+
+	maker := NewOrder(uuid.NewString())
+	taker := NewOrder(uuid.NewString())
+	taker.Side = domain.OrderSideSell
+	mustAdd(t, repo, maker)
+	mustAdd(t, repo, taker)
+
+	mustApplyFill(t, maker, "trade-1", "101.5", "0.25", at)
+	mustApplyFill(t, taker, "trade-1", "101.5", "0.25", at)
+	if err := repo.UpdateMany(ctx, maker, taker); err != nil {
+		t.Fatalf("UpdateMany() error = %v", err)
+	}
+
+	for _, want := range []*domain.Order{maker, taker} {
+		if want.Version != 2 || len(want.PendingFills()) != 0 {
+			t.Fatalf("UpdateMany() version = %d, pending fills = %d", want.Version, len(want.PendingFills()))
+		}
+		got, err := repo.GetByID(ctx, want.ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		AssertOrderEqual(t, got, want)
+
+		fills, err := repo.Fills(ctx, want.ID)
+		if err != nil || len(fills) != 1 {
+			t.Fatalf("Fills() = %d, %v, want 1 fill", len(fills), err)
+		}
+	}
+}
+
+// testUpdateManyConflict конфликт версии одного заказа не дает сохранить и другой
+func testUpdateManyConflict(t *testing.T, repo storage.OrderRepository) {
+	ctx := context.Background()
+	at := time.Now().UTC().Truncate(time.Microsecond)
+
+	maker := NewOrder(uuid.NewString())
+	taker := NewOrder(uuid.NewString())
+	mustAdd(t, repo, maker)
+	mustAdd(t, repo, taker)
+
+	stale, err := repo.GetByID(ctx, taker.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	taker.Status = domain.OrderStatusCancelled
+	if err := repo.Update(ctx, taker); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	before, err := repo.UnpublishedEvents(ctx, 0)
+	if err != nil {
+		t.Fatalf("UnpublishedEvents() error = %v", err)
+	}
+
+	mustApplyFill(t, maker, "trade-1", "101.5", "0.1", at)
+	mustApplyFill(t, stale, "trade-1", "101.5", "0.1", at)
+	if err := repo.UpdateMany(ctx, maker, stale); !errors.Is(err, domain.ErrVersionConflict) {
+		t.Fatalf("UpdateMany() error = %v, want %v", err, domain.ErrVersionConflict)
+	}
+
+	got, err := repo.GetByID(ctx, maker.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.Version != 1 || !got.FilledQuantity.IsZero() {
+		t.Fatalf("maker saved despite conflict: version = %d, filled = %s", got.Version, got.FilledQuantity)
+	}
+	if fills, err := repo.Fills(ctx, maker.ID); err != nil || len(fills) != 0 {
+		t.Fatalf("Fills() = %d, %v, want none", len(fills), err)
+	}
+	after, err := repo.UnpublishedEvents(ctx, 0)
+	if err != nil {
+		t.Fatalf("UnpublishedEvents() error = %v", err)
+	}
+	if len(after) != len(before) {
+		t.Fatalf("events written despite conflict: %d, want %d", len(after), len(before))
+	}
+}
+
+// testConcurrentUpdates применяет исполнения из нескольких горутин с повтором при конфликте версий.
+// Ни одно исполнение не должно потеряться. Запускать с -race
+func testConcurrentUpdates(t *testing.T, repo storage.OrderRepository) {
+	ctx := context.Background()
+	const workers = 16
+
+	order := NewOrder(uuid.NewString())
+	mustAdd(t, repo, order)
+	part := order.Quantity.Div(decimal.NewFromInt(workers))
+
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for {
+				current, err := repo.GetByID(ctx, order.ID)
+				if err != nil {
+					errs <- err
+					return
+				}
+				fill := domain.Fill{
+					TradeID:  fmt.Sprintf("trade-%d", i),
+					Price:    current.Price,
+					Quantity: part,
+					At:       time.Now().UTC().Truncate(time.Microsecond),
+				}
+				if err := current.ApplyFill(fill, domain.ActorSystem); err != nil {
+					errs <- err
+					return
+				}
+				err = repo.Update(ctx, current)
+				if errors.Is(err, domain.ErrVersionConflict) {
+					continue
+				}
+				if err != nil {
+					errs <- err
+				}
+				return
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatalf("concurrent update error = %v", err)
+	}
+
+	got, err := repo.GetByID(ctx, order.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if !got.FilledQuantity.Equal(order.Quantity) || got.Status != domain.OrderStatusFilled {
+		t.Fatalf("filled %s (%s), want %s (FILLED)", got.FilledQuantity, got.Status, order.Quantity)
+	}
+	if got.Version != workers+1 {
+		t.Fatalf("version = %d, want %d", got.Version, workers+1)
+	}
+
+	fills, err := repo.Fills(ctx, order.ID)
+	if err != nil {
+		t.Fatalf("Fills() error = %v", err)
+	}
+	if len(fills) != workers {
+		t.Fatalf("Fills() returned %d fills, want %d", len(fills), workers)
+	}
+}
+
+// testConcurrentCancelAndTrade сделка двух заказов и параллельная отмена одного из них: ровно одно изменение
+// сохраняется, второе получает конфликт версии, а не другую ошибку. Запускать с -race
+func testConcurrentCancelAndTrade(t *testing.T, repo storage.OrderRepository) {
+	ctx := context.Background()
+	const rounds = 20
+
+	for i := 0; i < rounds; i++ {
+		maker := NewOrder(uuid.NewString())
+		taker := NewOrder(uuid.NewString())
+		taker.MarketID = maker.MarketID
+		taker.Side = domain.OrderSideSell
+		mustAdd(t, repo, maker)
+		mustAdd(t, repo, taker)
+
+		cancelled, err := repo.GetByID(ctx, maker.ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		at := time.Now().UTC().Truncate(time.Microsecond)
+		if err := cancelled.TransitionTo(domain.OrderStatusCancelled, "cancelled", domain.ActorSystem, at); err != nil {
+			t.Fatalf("TransitionTo() error = %v", err)
+		}
+		tradeID := fmt.Sprintf("trade-%d", i)
+		mustApplyFill(t, maker, tradeID, "101.5", "0.1", at)
+		mustApplyFill(t, taker, tradeID, "101.5", "0.1", at)
+
+		var (
+			wg                  sync.WaitGroup
+			tradeErr, cancelErr error
+		)
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			tradeErr = repo.UpdateMany(ctx, taker, maker)
+		}()
+		go func() {
+			defer wg.Done()
+			cancelErr = repo.Update(ctx, cancelled)
+		}()
+		wg.Wait()
+
+		switch {
+		case tradeErr == nil && errors.Is(cancelErr, domain.ErrVersionConflict):
+		case cancelErr == nil && errors.Is(tradeErr, domain.ErrVersionConflict):
+		default:
+			t.Fatalf("trade error = %v, cancel error = %v, want one success and one version conflict", tradeErr, cancelErr)
+		}
+
+		got, err := repo.GetByID(ctx, maker.ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if got.Version != 2 {
+			t.Fatalf("maker version = %d, want 2", got.Version)
+		}
+	}
+}
+
+func testFills(t *testing.T, repo storage.OrderRepository) {
+	ctx := context.Background()
+	at := time.Now().UTC().Truncate(time.Microsecond)
+
+	order := NewOrder(uuid.NewString())
+	mustAdd(t, repo, order)
+
+	mustApplyFill(t, order, "trade-1", "100", "0.1", at)
+	mustApplyFill(t, order, "trade-2", "103", "0.15", at.Add(time.Second))
+	if err := repo.Update(ctx, order); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if len(order.PendingFills()) != 0 {
+		t.Fatalf("Update() left %d pending fills", len(order.PendingFills()))
+	}
+
+	got, err := repo.GetByID(ctx, order.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	AssertOrderEqual(t, got, order)
+	if got.Status != domain.OrderStatusFilled {
+		t.Fatalf("status = %s, want FILLED", got.Status)
+	}
+
+	fills, err := repo.Fills(ctx, order.ID)
+	if err != nil {
+		t.Fatalf("Fills() error = %v", err)
+	}
+	if len(fills) != 2 || fills[0].TradeID != "trade-1" || fills[1].TradeID != "trade-2" {
+		t.Fatalf("Fills() = %+v, want trade-1, trade-2", fills)
+	}
+	if !fills[1].Price.Equal(decimal.RequireFromString("103")) || !fills[1].Quantity.Equal(decimal.RequireFromString("0.15")) {
+		t.Fatalf("fill[1] = %+v", fills[1])
+	}
+}
+
+func testDuplicateFill(t *testing.T, repo storage.OrderRepository) {
+	ctx := context.Background()
+	at := time.Now().UTC().Truncate(time.Microsecond)
+
+	order := NewOrder(uuid.NewString())
+	mustAdd(t, repo, order)
+
+	mustApplyFill(t, order, "trade-1", "100", "0.1", at)
+	if err := repo.Update(ctx, order); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	// заказ не знает о сохраненных сделках: повтор отклоняет хранилище, ничего не записывая
+	fresh, err := repo.GetByID(ctx, order.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	mustApplyFill(t, fresh, "trade-1", "100", "0.1", at)
+	if err := repo.Update(ctx, fresh); !errors.Is(err, domain.ErrDuplicateFill) {
+		t.Fatalf("Update() error = %v, want %v", err, domain.ErrDuplicateFill)
+	}
+
+	got, err := repo.GetByID(ctx, order.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	AssertOrderEqual(t, got, order)
+
+	fills, err := repo.Fills(ctx, order.ID)
+	if err != nil {
+		t.Fatalf("Fills() error = %v", err)
+	}
+	if len(fills) != 1 {
+		t.Fatalf("Fills() returned %d fills, want 1", len(fills))
+	}
+}
+
+func testOutbox(t *testing.T, repo storage.OrderRepository) {
+	ctx := context.Background()
+	at := time.Now().UTC().Truncate(time.Microsecond)
+
+	order := NewOrder(uuid.NewString())
+	order.Status = domain.OrderStatusUnspecified
+	if err := order.TransitionTo(domain.OrderStatusCreated, "order created", domain.UserActor(order.UserID), at); err != nil {
+		t.Fatalf("TransitionTo() error = %v", err)
+	}
+	mustAdd(t, repo, order)
+	if len(order.PendingEvents()) != 0 {
+		t.Fatalf("Add() left %d pending events", len(order.PendingEvents()))
+	}
+
+	mustApplyFill(t, order, "trade-1", "100", "0.25", at.Add(time.Second))
+	if err := repo.Update(ctx, order); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	events := orderEvents(t, repo, order.ID)
+	wantTypes := []domain.OrderEventType{domain.OrderEventCreated, domain.OrderEventStatusChanged, domain.OrderEventFilled}
+	if len(events) != len(wantTypes) {
+		t.Fatalf("got %d events, want %d", len(events), len(wantTypes))
+	}
+	for i, event := range events {
+		if event.Type != wantTypes[i] {
+			t.Fatalf("event[%d].Type = %s, want %s", i, event.Type, domain.OrderEventType(wantTypes[i]))
+		}
+		if i > 0 && event.Sequence <= events[i-1].Sequence {
+			t.Fatalf("event[%d].Sequence = %d, not after %d", i, event.Sequence, events[i-1].Sequence)
+		}
+		if event.ID == "" || event.UserID != order.UserID || event.MarketID != order.MarketID {
+			t.Fatalf("event[%d] = %+v", i, event)
+		}
+	}
+
+	if events[0].Status != domain.OrderStatusCreated || !events[0].OccurredAt.Equal(at) {
+		t.Fatalf("created event = %+v", events[0])
+	}
+	filled := events[2]
+	if filled.Status != domain.OrderStatusFilled || !filled.FilledQuantity.Equal(order.Quantity) {
+		t.Fatalf("filled event = %+v", filled)
+	}
+	if filled.Fill == nil || filled.Fill.TradeID != "trade-1" ||
+		!filled.Fill.Price.Equal(decimal.RequireFromString("100")) ||
+		!filled.Fill.Quantity.Equal(decimal.RequireFromString("0.25")) {
+		t.Fatalf("filled event fill = %+v", filled.Fill)
+	}
+}
+
+func testOutboxMarkPublished(t *testing.T, repo storage.OrderRepository) {
+	ctx := context.Background()
+	at := time.Now().UTC().Truncate(time.Microsecond)
+
+	order := NewOrder(uuid.NewString())
+	mustAdd(t, repo, order)
+	if err := order.TransitionTo(domain.OrderStatusOpen, "open", domain.ActorSystem, at); err != nil {
+		t.Fatalf("TransitionTo() error = %v", err)
+	}
+	if err := order.TransitionTo(domain.OrderStatusCancelled, "cancelled", domain.ActorSystem, at); err != nil {
+		t.Fatalf("TransitionTo() error = %v", err)
+	}
+	if err := repo.Update(ctx, order); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	events := orderEvents(t, repo, order.ID)
+	if len(events) != 2 {
+		t.Fatalf("got %d events, want 2", len(events))
+	}
+
+	if err := repo.MarkPublished(ctx, []int64{events[0].Sequence}); err != nil {
+		t.Fatalf("MarkPublished() error = %v", err)
+	}
+	left := orderEvents(t, repo, order.ID)
+	if len(left) != 1 || left[0].Sequence != events[1].Sequence || left[0].Type != domain.OrderEventCancelled {
+		t.Fatalf("unpublished after MarkPublished = %+v", left)
+	}
+
+	// повторная пометка безопасна
+	if err := repo.MarkPublished(ctx, []int64{events[0].Sequence, events[1].Sequence}); err != nil {
+		t.Fatalf("MarkPublished() error = %v", err)
+	}
+	if left := orderEvents(t, repo, order.ID); len(left) != 0 {
+		t.Fatalf("unpublished after MarkPublished = %+v", left)
+	}
+}
+
+// testOutboxAtomic проверяет, что отклоненное изменение не оставляет событий
+func testOutboxAtomic(t *testing.T, repo storage.OrderRepository) {
+	ctx := context.Background()
+	at := time.Now().UTC().Truncate(time.Microsecond)
+
+	order := NewOrder(uuid.NewString())
+	mustAdd(t, repo, order)
+
+	stale, err := repo.GetByID(ctx, order.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+
+	mustApplyFill(t, order, "trade-1", "100", "0.1", at)
+	if err := repo.Update(ctx, order); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	if err := stale.TransitionTo(domain.OrderStatusCancelled, "cancelled", domain.ActorSystem, at); err != nil {
+		t.Fatalf("TransitionTo() error = %v", err)
+	}
+	if err := repo.Update(ctx, stale); !errors.Is(err, domain.ErrVersionConflict) {
+		t.Fatalf("Update() stale error = %v, want %v", err, domain.ErrVersionConflict)
+	}
+
+	fresh, err := repo.GetByID(ctx, order.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	mustApplyFill(t, fresh, "trade-1", "100", "0.1", at)
+	if err := repo.Update(ctx, fresh); !errors.Is(err, domain.ErrDuplicateFill) {
+		t.Fatalf("Update() error = %v, want %v", err, domain.ErrDuplicateFill)
+	}
+
+	events := orderEvents(t, repo, order.ID)
+	if len(events) != 2 || events[0].Type != domain.OrderEventStatusChanged || events[1].Type != domain.OrderEventFilled {
+		t.Fatalf("events = %+v, want STATUS_CHANGED, FILLED", events)
+	}
+}
+
+func testLastSequence(t *testing.T, repo storage.OrderRepository) {
+	ctx := context.Background()
+
+	last, err := repo.LastSequence(ctx)
+	if err != nil {
+		t.Fatalf("LastSequence() error = %v", err)
+	}
+	if last != 0 {
+		t.Fatalf("LastSequence() on empty outbox = %d, want 0", last)
+	}
+
+	order := NewOrder(uuid.NewString())
+	mustAdd(t, repo, order)
+	if err := order.TransitionTo(domain.OrderStatusCancelled, "cancelled", domain.ActorSystem, time.Now()); err != nil {
+		t.Fatalf("TransitionTo() error = %v", err)
+	}
+	if err := repo.Update(ctx, order); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	events := orderEvents(t, repo, order.ID)
+	if len(events) == 0 {
+		t.Fatal("no events saved")
+	}
+	if err := repo.MarkPublished(ctx, []int64{events[len(events)-1].Sequence}); err != nil {
+		t.Fatalf("MarkPublished() error = %v", err)
+	}
+
+	// опубликованные события тоже учитываются
+	last, err = repo.LastSequence(ctx)
+	if err != nil {
+		t.Fatalf("LastSequence() error = %v", err)
+	}
+	if want := events[len(events)-1].Sequence; last != want {
+		t.Fatalf("LastSequence() = %d, want %d", last, want)
+	}
+}
+
+func testEventsAfter(t *testing.T, repo storage.OrderRepository) {
+	ctx := context.Background()
+	at := time.Now().UTC().Truncate(time.Microsecond)
+	userID := uuid.NewString()
+
+	first := NewOrder(userID)
+	second := NewOrder(userID)
+	other := NewOrder(uuid.NewString())
+	for _, order := range []*domain.Order{first, second, other} {
+		mustAdd(t, repo, order)
+		if err := order.TransitionTo(domain.OrderStatusCancelled, "cancelled", domain.ActorSystem, at); err != nil {
+			t.Fatalf("TransitionTo() error = %v", err)
+		}
+		if err := repo.Update(ctx, order); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+	}
+
+	all, err := repo.EventsAfter(ctx, storage.EventFilter{UserID: userID})
+	if err != nil {
+		t.Fatalf("EventsAfter() error = %v", err)
+	}
+	if len(all) != 2 || all[0].OrderID != first.ID || all[1].OrderID != second.ID {
+		t.Fatalf("EventsAfter(user) = %+v, want events of %s, %s", all, first.ID, second.ID)
+	}
+
+	// опубликованные события тоже возвращаются
+	if err := repo.MarkPublished(ctx, []int64{all[0].Sequence}); err != nil {
+		t.Fatalf("MarkPublished() error = %v", err)
+	}
+	after, err := repo.EventsAfter(ctx, storage.EventFilter{UserID: userID, AfterSequence: all[0].Sequence - 1})
+	if err != nil {
+		t.Fatalf("EventsAfter() error = %v", err)
+	}
+	if len(after) != 2 {
+		t.Fatalf("EventsAfter(after) returned %d events, want 2", len(after))
+	}
+
+	limited, err := repo.EventsAfter(ctx, storage.EventFilter{UserID: userID, AfterSequence: all[0].Sequence, Limit: 1})
+	if err != nil {
+		t.Fatalf("EventsAfter() error = %v", err)
+	}
+	if len(limited) != 1 || limited[0].Sequence != all[1].Sequence {
+		t.Fatalf("EventsAfter(limit) = %+v, want sequence %d", limited, all[1].Sequence)
+	}
+
+	byOrder, err := repo.EventsAfter(ctx, storage.EventFilter{OrderID: other.ID})
+	if err != nil {
+		t.Fatalf("EventsAfter() error = %v", err)
+	}
+	if len(byOrder) != 1 || byOrder[0].OrderID != other.ID {
+		t.Fatalf("EventsAfter(order) = %+v", byOrder)
+	}
+}
+
+// orderEvents возвращает неопубликованные события заказа
+func orderEvents(t *testing.T, repo storage.OrderRepository, orderID string) []domain.OrderEvent {
+	t.Helper()
+	events, err := repo.UnpublishedEvents(context.Background(), 0)
+	if err != nil {
+		t.Fatalf("UnpublishedEvents() error = %v", err)
+	}
+
+	result := make([]domain.OrderEvent, 0)
+	for _, event := range events {
+		if event.OrderID == orderID {
+			result = append(result, event)
+		}
+	}
+	return result
+}
+
+func mustApplyFill(t *testing.T, order *domain.Order, tradeID, price, qty string, at time.Time) {
+	t.Helper()
+	fill := domain.Fill{
+		TradeID:  tradeID,
+		Price:    decimal.RequireFromString(price),
+		Quantity: decimal.RequireFromString(qty),
+		At:       at,
+	}
+	if err := order.ApplyFill(fill, domain.ActorSystem); err != nil {
+		t.Fatalf("ApplyFill(%s) error = %v", tradeID, err)
+	}
+}
+
+func mustAdd(t *testing.T, repo storage.OrderRepository, order *domain.Order) {
+	t.Helper()
+	if err := repo.Add(context.Background(), order); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+}
+
+// AssertOrderEqual сравнивает заказы по всем полям. Decimal и время сравниваются по значению
+func AssertOrderEqual(t *testing.T, got, want *domain.Order) {
+	t.Helper()
+
+	if got.ID != want.ID ||
+		got.Version != want.Version ||
+		got.ClientOrderID != want.ClientOrderID ||
+		got.UserID != want.UserID ||
+		got.MarketID != want.MarketID ||
+		got.Type != want.Type ||
+		got.Side != want.Side ||
+		got.Status != want.Status {
+		t.Fatalf("order mismatch:\n got  %+v\n want %+v", got, want)
+	}
+	if !got.Price.Equal(want.Price) {
+		t.Fatalf("price = %s, want %s", got.Price, want.Price)
+	}
+	if !got.StopPrice.Equal(want.StopPrice) {
+		t.Fatalf("stop_price = %s, want %s", got.StopPrice, want.StopPrice)
+	}
+	if !got.Quantity.Equal(want.Quantity) {
+		t.Fatalf("quantity = %s, want %s", got.Quantity, want.Quantity)
+	}
+	if got.TimeInForce != want.TimeInForce {
+		t.Fatalf("time_in_force = %s, want %s", got.TimeInForce, want.TimeInForce)
+	}
+	if !got.ExpiresAt.Equal(want.ExpiresAt) {
+		t.Fatalf("expires_at = %v, want %v", got.ExpiresAt, want.ExpiresAt)
+	}
+	if !got.SubmittedPrice.Equal(want.SubmittedPrice) || !got.SubmittedQuantity.Equal(want.SubmittedQuantity) {
+		t.Fatalf("submitted price, quantity = %s, %s, want %s, %s",
+			got.SubmittedPrice, got.SubmittedQuantity, want.SubmittedPrice, want.SubmittedQuantity)
+	}
+	if !got.TriggeredAt.Equal(want.TriggeredAt) {
+		t.Fatalf("triggered_at = %v, want %v", got.TriggeredAt, want.TriggeredAt)
+	}
+	if !got.RequeuedAt.Equal(want.RequeuedAt) {
+		t.Fatalf("requeued_at = %v, want %v", got.RequeuedAt, want.RequeuedAt)
+	}
+	if got.PostOnly != want.PostOnly || got.ReduceOnly != want.ReduceOnly {
+		t.Fatalf("post_only, reduce_only = %v, %v, want %v, %v", got.PostOnly, got.ReduceOnly, want.PostOnly, want.ReduceOnly)
+	}
+	if !got.FilledQuantity.Equal(want.FilledQuantity) {
+		t.Fatalf("filled_quantity = %s, want %s", got.FilledQuantity, want.FilledQuantity)
+	}
+	if !got.AvgFillPrice.Equal(want.AvgFillPrice) {
+		t.Fatalf("avg_fill_price = %s, want %s", got.AvgFillPrice, want.AvgFillPrice)
+	}
+	if !got.CreatedAt.Equal(want.CreatedAt) {
+		t.Fatalf("created_at = %s, want %s", got.CreatedAt, want.CreatedAt)
+	}
+	if !got.UpdatedAt.Equal(want.UpdatedAt) {
+		t.Fatalf("updated_at = %s, want %s", got.UpdatedAt, want.UpdatedAt)
+	}
+}
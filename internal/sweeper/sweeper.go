@@ -0,0 +1,200 @@
+package sweeper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/chilly266futon/orderService/internal/domain"
+	"github.com/chilly266futon/orderService/internal/storage"
+)
+
+const (
+	defaultInterval  = 30 * time.Second
+	defaultBatchSize = 500
+	releaseTimeout   = 5 * time.Second
+)
+
+type Config struct {
+	Interval time.Duration
+	// CreatedTimeout сколько LIMIT или MARKET заказ может пробыть в CREATED, после чего он отклоняется.
+	// 0 - не отклонять. Стоп-заказы ждут активации в CREATED и не отклоняются
+	CreatedTimeout time.Duration
+	BatchSize      int
+}
+
+// Sweeper фоновая очистка заказов: переводит в EXPIRED заказы с истекшим сроком действия
+// и в REJECTED заказы, зависшие в CREATED дольше CreatedTimeout. Каждый переход записывается
+// в историю и публикуется через outbox как обычно. Проход выполняет только экземпляр, получивший аренду
+type Sweeper struct {
+	repo   storage.OrderRepository
+	lease  storage.Lease
+	cfg    Config
+	logger *zap.Logger
+
+	cancel context.CancelFunc
+	done   chan struct{}
+	mu     sync.Mutex
+}
+
+func NewSweeper(repo storage.OrderRepository, lease storage.Lease, cfg Config, logger *zap.Logger) *Sweeper {
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaultInterval
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultBatchSize
+	}
+
+	return &Sweeper{
+		repo:   repo,
+		lease:  lease,
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+// Start запускает очистку в отдельной горутине
+func (s *Sweeper) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.done = make(chan struct{})
+
+	go func() {
+		defer close(s.done)
+		s.run(ctx)
+	}()
+}
+
+// Stop останавливает очистку, ждет завершения текущего прохода и отпускает аренду
+func (s *Sweeper) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	<-s.done
+	s.cancel = nil
+
+	ctx, cancel := context.WithTimeout(context.Background(), releaseTimeout)
+	defer cancel()
+	if err := s.lease.Release(ctx); err != nil {
+		s.logger.Warn("failed to release sweeper lease", zap.Error(err))
+	}
+}
+
+func (s *Sweeper) run(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.Sweep(ctx); err != nil && ctx.Err() == nil {
+			s.logger.Error("failed to sweep orders", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sweep выполняет один проход, если аренда у этого экземпляра, и возвращает число измененных заказов
+func (s *Sweeper) Sweep(ctx context.Context) (int, error) {
+	acquired, err := s.lease.TryAcquire(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to acquire sweeper lease: %w", err)
+	}
+	if !acquired {
+		return 0, nil
+	}
+
+	now := time.Now()
+
+	expired, err := s.sweep(ctx, storage.OrderFilter{
+		Statuses: []domain.OrderStatus{
+			domain.OrderStatusCreated,
+			domain.OrderStatusOpen,
+			domain.OrderStatusPartiallyFilled,
+		},
+		ExpiresTo: now,
+	}, func(o *domain.Order) error {
+		return o.Expire(now)
+	})
+	if err != nil {
+		return expired, err
+	}
+
+	if s.cfg.CreatedTimeout <= 0 {
+		return expired, nil
+	}
+
+	reason := fmt.Sprintf("not accepted within %s", s.cfg.CreatedTimeout)
+	rejected, err := s.sweep(ctx, storage.OrderFilter{
+		Statuses:  []domain.OrderStatus{domain.OrderStatusCreated},
+		Types:     []domain.OrderType{domain.OrderTypeLimit, domain.OrderTypeMarket},
+		CreatedTo: now.Add(-s.cfg.CreatedTimeout),
+	}, func(o *domain.Order) error {
+		return o.TransitionTo(domain.OrderStatusRejected, reason, domain.ActorSystem, now)
+	})
+	return expired + rejected, err
+}
+
+// sweep применяет mutate ко всем заказам фильтра. Заказ, измененный параллельно, пропускается:
+// если он все еще подходит под фильтр, его заберет следующий проход
+func (s *Sweeper) sweep(ctx context.Context, filter storage.OrderFilter, mutate func(o *domain.Order) error) (int, error) {
+	filter.Limit = s.cfg.BatchSize
+
+	swept := 0
+	for {
+		page, err := s.repo.List(ctx, filter)
+		if err != nil {
+			return swept, err
+		}
+
+		for _, o := range page {
+			from := o.Status
+			if err := mutate(o); err != nil {
+				s.logger.Error("cannot sweep order",
+					zap.String("order_id", o.ID),
+					zap.Error(err),
+				)
+				continue
+			}
+
+			err := s.repo.Update(ctx, o)
+			if errors.Is(err, domain.ErrVersionConflict) {
+				continue
+			}
+			if err != nil {
+				return swept, err
+			}
+
+			swept++
+			s.logger.Info("order swept",
+				zap.String("order_id", o.ID),
+				zap.String("from", from.String()),
+				zap.String("to", o.Status.String()),
+			)
+		}
+
+		if len(page) < filter.Limit {
+			return swept, nil
+		}
+		cursor := storage.CursorOf(page[len(page)-1])
+		filter.After = &cursor
+	}
+}
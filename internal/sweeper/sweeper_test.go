@@ -0,0 +1,257 @@
+package sweeper
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/chilly266futon/orderService/internal/domain"
+	"github.com/chilly266futon/orderService/internal/storage"
+	"github.com/chilly266futon/orderService/internal/storage/storagetest"
+)
+
+// fixedLease аренда, которая либо всегда у этого экземпляра, либо никогда
+type fixedLease struct {
+	held     bool
+	released atomic.Bool
+}
+
+func (l *fixedLease) TryAcquire(context.Context) (bool, error) {
+	return l.held, nil
+}
+
+func (l *fixedLease) Release(context.Context) error {
+	l.released.Store(true)
+	return nil
+}
+
+// sweepRepo считает загруженные страницы и отвечает конфликтом версий на сохранение заказов из conflicts
+type sweepRepo struct {
+	storage.OrderRepository
+	lists     atomic.Int64
+	conflicts map[string]bool
+}
+
+func newSweepRepo() *sweepRepo {
+	return &sweepRepo{OrderRepository: storage.NewMemoryOrderRepository(), conflicts: make(map[string]bool)}
+}
+
+func (r *sweepRepo) List(ctx context.Context, filter storage.OrderFilter) ([]*domain.Order, error) {
+	r.lists.Add(1)
+	return r.OrderRepository.List(ctx, filter)
+}
+
+func (r *sweepRepo) Update(ctx context.Context, o *domain.Order) error {
+	if r.conflicts[o.ID] {
+		return domain.ErrVersionConflict
+	}
+	return r.OrderRepository.Update(ctx, o)
+}
+
+func addOrder(t *testing.T, repo storage.OrderRepository, mutate func(o *domain.Order)) *domain.Order {
+	t.Helper()
+
+	o := storagetest.NewOrder("user-1")
+	mutate(o)
+	if err := repo.Add(context.Background(), o); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	return o
+}
+
+func gtd(status domain.OrderStatus, expiresAt time.Time) func(o *domain.Order) {
+	return func(o *domain.Order) {
+		o.Status = status
+		o.TimeInForce = domain.TimeInForceGTD
+		o.ExpiresAt = expiresAt.UTC().Truncate(time.Microsecond)
+	}
+}
+
+func createdAt(typ domain.OrderType, at time.Time) func(o *domain.Order) {
+	return func(o *domain.Order) {
+		o.Type = typ
+		if typ.IsStop() {
+			o.StopPrice = o.Price
+		}
+		o.CreatedAt = at.UTC().Truncate(time.Microsecond)
+		o.UpdatedAt = o.CreatedAt
+	}
+}
+
+func assertStatus(t *testing.T, repo storage.OrderRepository, o *domain.Order, want domain.OrderStatus) {
+	t.Helper()
+
+	got, err := repo.GetByID(context.Background(), o.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.Status != want {
+		t.Fatalf("order %s status = %s, want %s", o.ID, got.Status, want)
+	}
+}
+
+func TestSweepExpiresGTDOrders(t *testing.T) {
+	repo := newSweepRepo()
+	s := NewSweeper(repo, &fixedLease{held: true}, Config{}, zap.NewNop())
+
+	past := time.Now().Add(-time.Minute)
+	open := addOrder(t, repo, gtd(domain.OrderStatusOpen, past))
+	partial := addOrder(t, repo, gtd(domain.OrderStatusPartiallyFilled, past))
+	created := addOrder(t, repo, gtd(domain.OrderStatusCreated, past))
+	future := addOrder(t, repo, gtd(domain.OrderStatusOpen, time.Now().Add(time.Hour)))
+	gtc := addOrder(t, repo, func(o *domain.Order) { o.Status = domain.OrderStatusOpen })
+
+	swept, err := s.Sweep(context.Background())
+	if err != nil {
+		t.Fatalf("Sweep() error = %v", err)
+	}
+	if swept != 3 {
+		t.Fatalf("Sweep() = %d, want 3", swept)
+	}
+	for _, o := range []*domain.Order{open, partial, created} {
+		assertStatus(t, repo, o, domain.OrderStatusExpired)
+	}
+	assertStatus(t, repo, future, domain.OrderStatusOpen)
+	assertStatus(t, repo, gtc, domain.OrderStatusOpen)
+}
+
+func TestSweepRejectsOrdersStuckInCreated(t *testing.T) {
+	old := time.Now().Add(-2 * time.Hour)
+
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		want    domain.OrderStatus
+	}{
+		{"timeout elapsed", time.Hour, domain.OrderStatusRejected},
+		{"timeout not elapsed", 3 * time.Hour, domain.OrderStatusCreated},
+		{"zero timeout disables rejection", 0, domain.OrderStatusCreated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newSweepRepo()
+			s := NewSweeper(repo, &fixedLease{held: true}, Config{CreatedTimeout: tt.timeout}, zap.NewNop())
+
+			limit := addOrder(t, repo, createdAt(domain.OrderTypeLimit, old))
+			market := addOrder(t, repo, createdAt(domain.OrderTypeMarket, old))
+			stop := addOrder(t, repo, createdAt(domain.OrderTypeStopLimit, old))
+			recent := addOrder(t, repo, createdAt(domain.OrderTypeLimit, time.Now()))
+
+			if _, err := s.Sweep(context.Background()); err != nil {
+				t.Fatalf("Sweep() error = %v", err)
+			}
+			assertStatus(t, repo, limit, tt.want)
+			assertStatus(t, repo, market, tt.want)
+			// стоп-заказы ждут активации в CREATED
+			assertStatus(t, repo, stop, domain.OrderStatusCreated)
+			assertStatus(t, repo, recent, domain.OrderStatusCreated)
+		})
+	}
+}
+
+func TestSweepWalksBatches(t *testing.T) {
+	repo := newSweepRepo()
+	s := NewSweeper(repo, &fixedLease{held: true}, Config{BatchSize: 2}, zap.NewNop())
+
+	past := time.Now().Add(-time.Minute)
+	orders := make([]*domain.Order, 5)
+	for i := range orders {
+		orders[i] = addOrder(t, repo, gtd(domain.OrderStatusOpen, past))
+	}
+
+	swept, err := s.Sweep(context.Background())
+	if err != nil {
+		t.Fatalf("Sweep() error = %v", err)
+	}
+	if swept != len(orders) {
+		t.Fatalf("Sweep() = %d, want %d", swept, len(orders))
+	}
+	if n := repo.lists.Load(); n != 3 {
+		t.Fatalf("loaded %d pages, want 3", n)
+	}
+	for _, o := range orders {
+		assertStatus(t, repo, o, domain.OrderStatusExpired)
+	}
+}
+
+func TestSweepWithoutLease(t *testing.T) {
+	repo := newSweepRepo()
+	s := NewSweeper(repo, &fixedLease{}, Config{CreatedTimeout: time.Minute}, zap.NewNop())
+
+	expired := addOrder(t, repo, gtd(domain.OrderStatusOpen, time.Now().Add(-time.Minute)))
+	stuck := addOrder(t, repo, createdAt(domain.OrderTypeLimit, time.Now().Add(-time.Hour)))
+
+	swept, err := s.Sweep(context.Background())
+	if err != nil || swept != 0 {
+		t.Fatalf("Sweep() = %d, %v, want 0 without the lease", swept, err)
+	}
+	if n := repo.lists.Load(); n != 0 {
+		t.Fatalf("loaded %d pages without the lease", n)
+	}
+	assertStatus(t, repo, expired, domain.OrderStatusOpen)
+	assertStatus(t, repo, stuck, domain.OrderStatusCreated)
+}
+
+// failingLease аренда, которую не удается проверить
+type failingLease struct{ fixedLease }
+
+func (l *failingLease) TryAcquire(context.Context) (bool, error) {
+	return false, errors.New("connection refused")
+}
+
+func TestSweepLeaseError(t *testing.T) {
+	repo := newSweepRepo()
+	s := NewSweeper(repo, &failingLease{}, Config{}, zap.NewNop())
+
+	if _, err := s.Sweep(context.Background()); err == nil {
+		t.Fatal("Sweep() error = nil, want the lease error")
+	}
+	if n := repo.lists.Load(); n != 0 {
+		t.Fatalf("loaded %d pages without the lease", n)
+	}
+}
+
+func TestSweepSkipsVersionConflicts(t *testing.T) {
+	repo := newSweepRepo()
+	s := NewSweeper(repo, &fixedLease{held: true}, Config{BatchSize: 2}, zap.NewNop())
+
+	past := time.Now().Add(-time.Minute)
+	first := addOrder(t, repo, gtd(domain.OrderStatusOpen, past))
+	conflicting := addOrder(t, repo, gtd(domain.OrderStatusOpen, past))
+	last := addOrder(t, repo, gtd(domain.OrderStatusOpen, past))
+	repo.conflicts[conflicting.ID] = true
+
+	swept, err := s.Sweep(context.Background())
+	if err != nil {
+		t.Fatalf("Sweep() error = %v", err)
+	}
+	if swept != 2 {
+		t.Fatalf("Sweep() = %d, want 2", swept)
+	}
+	assertStatus(t, repo, first, domain.OrderStatusExpired)
+	assertStatus(t, repo, conflicting, domain.OrderStatusOpen)
+	assertStatus(t, repo, last, domain.OrderStatusExpired)
+
+	// следующий проход забирает заказ, если он все еще подходит
+	delete(repo.conflicts, conflicting.ID)
+	if swept, err := s.Sweep(context.Background()); err != nil || swept != 1 {
+		t.Fatalf("second Sweep() = %d, %v, want 1", swept, err)
+	}
+	assertStatus(t, repo, conflicting, domain.OrderStatusExpired)
+}
+
+func TestStopReleasesLease(t *testing.T) {
+	lease := &fixedLease{held: true}
+	s := NewSweeper(newSweepRepo(), lease, Config{Interval: time.Hour}, zap.NewNop())
+
+	s.Start()
+	s.Stop()
+	if !lease.released.Load() {
+		t.Fatal("Stop() did not release the lease")
+	}
+}
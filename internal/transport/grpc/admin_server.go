@@ -0,0 +1,112 @@
+package grpc
+
+import (
+	"context"
+
+	pb "github.com/chilly266futon/orderService/gen/pb/order"
+	"github.com/chilly266futon/orderService/internal/dto/order"
+	"github.com/chilly266futon/orderService/internal/mappers"
+	"github.com/chilly266futon/orderService/internal/service"
+)
+
+type AdminServer struct {
+	pb.UnimplementedOrderAdminServiceServer
+	useCase *service.AdminUseCase
+}
+
+func NewAdminServer(useCase *service.AdminUseCase) *AdminServer {
+	return &AdminServer{useCase: useCase}
+}
+
+func (s *AdminServer) ListOrders(ctx context.Context, pbReq *pb.AdminListOrdersRequest) (*pb.ListOrdersResponse, error) {
+	dtoReq := order.ListOrdersRequest{
+		UserID:    pbReq.UserId,
+		MarketID:  pbReq.MarketId,
+		PageSize:  int(pbReq.PageSize),
+		PageToken: pbReq.PageToken,
+	}
+
+	for _, st := range pbReq.Statuses {
+		dtoReq.Statuses = append(dtoReq.Statuses, mappers.OrderStatusFromProto(st).String())
+	}
+	for _, ot := range pbReq.OrderTypes {
+		dtoReq.OrderTypes = append(dtoReq.OrderTypes, mappers.OrderTypeFromProto(ot).String())
+	}
+	if pbReq.CreatedFrom != nil {
+		dtoReq.CreatedFrom = pbReq.CreatedFrom.AsTime()
+	}
+	if pbReq.CreatedTo != nil {
+		dtoReq.CreatedTo = pbReq.CreatedTo.AsTime()
+	}
+
+	dtoResp, err := s.useCase.ListOrders(ctx, dtoReq)
+	if err != nil {
+		return nil, errorToStatus(err)
+	}
+
+	return &pb.ListOrdersResponse{
+		Orders:        mappers.OrdersToProto(dtoResp.Orders),
+		NextPageToken: dtoResp.NextPageToken,
+	}, nil
+}
+
+func (s *AdminServer) GetOrder(ctx context.Context, pbReq *pb.AdminGetOrderRequest) (*pb.GetOrderResponse, error) {
+	resp, err := s.useCase.GetOrder(ctx, order.AdminGetOrderRequest{OrderID: pbReq.OrderId})
+	if err != nil {
+		return nil, errorToStatus(err)
+	}
+
+	return &pb.GetOrderResponse{
+		Order: mappers.OrderToProto(resp.Order),
+	}, nil
+}
+
+func (s *AdminServer) ForceTransition(ctx context.Context, pbReq *pb.ForceTransitionRequest) (*pb.ForceTransitionResponse, error) {
+	dtoReq := order.ForceTransitionRequest{
+		OrderID:  pbReq.OrderId,
+		ToStatus: mappers.OrderStatusFromProto(pbReq.ToStatus).String(),
+		Reason:   pbReq.Reason,
+	}
+
+	resp, err := s.useCase.ForceTransition(ctx, dtoReq)
+	if err != nil {
+		return nil, errorToStatus(err)
+	}
+
+	return &pb.ForceTransitionResponse{
+		Order: mappers.OrderToProto(resp.Order),
+	}, nil
+}
+
+func (s *AdminServer) CancelMarketOrders(ctx context.Context, pbReq *pb.CancelMarketOrdersRequest) (*pb.CancelMarketOrdersResponse, error) {
+	dtoReq := order.CancelMarketOrdersRequest{
+		MarketID: pbReq.MarketId,
+		Reason:   pbReq.Reason,
+	}
+
+	resp, err := s.useCase.CancelMarketOrders(ctx, dtoReq)
+	if err != nil {
+		return nil, errorToStatus(err)
+	}
+
+	return &pb.CancelMarketOrdersResponse{
+		CancelledOrderIds: resp.CancelledOrderIDs,
+		FailedOrderIds:    resp.FailedOrderIDs,
+	}, nil
+}
+
+func (s *AdminServer) ListAuditRecords(ctx context.Context, pbReq *pb.ListAuditRecordsRequest) (*pb.ListAuditRecordsResponse, error) {
+	dtoReq := order.ListAuditRecordsRequest{
+		OrderID: pbReq.OrderId,
+		Limit:   int(pbReq.Limit),
+	}
+
+	resp, err := s.useCase.ListAuditRecords(ctx, dtoReq)
+	if err != nil {
+		return nil, errorToStatus(err)
+	}
+
+	return &pb.ListAuditRecordsResponse{
+		Records: mappers.AuditRecordsToProto(resp.Records),
+	}, nil
+}
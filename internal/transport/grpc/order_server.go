@@ -4,12 +4,13 @@ import (
 	"context"
 	"errors"
 
-	pb "github.com/chilly266futon/exchange-service-contracts/gen/pb/order"
+	pb "github.com/chilly266futon/orderService/gen/pb/order"
 	"github.com/chilly266futon/orderService/internal/domain"
 	"github.com/chilly266futon/orderService/internal/dto/order"
 	"github.com/chilly266futon/orderService/internal/mappers"
 	"github.com/chilly266futon/orderService/internal/service"
 	"github.com/shopspring/decimal"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -25,15 +26,28 @@ func NewOrderServer(useCase *service.OrderUseCase) *OrderServer {
 
 func (s *OrderServer) CreateOrder(ctx context.Context, pbReq *pb.CreateOrderRequest) (*pb.CreateOrderResponse, error) {
 	dtoReq := order.CreateOrderRequest{
-		UserID:   pbReq.UserId,
-		MarketID: pbReq.MarketId,
+		UserID:        pbReq.UserId,
+		MarketID:      pbReq.MarketId,
+		ClientOrderID: pbReq.ClientOrderId,
+		PostOnly:      pbReq.PostOnly,
+		ReduceOnly:    pbReq.ReduceOnly,
 	}
 
-	price, err := decimal.NewFromString(pbReq.Price)
-	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "invalid price format: %v", err)
+	// отсутствующая цена остается нулем, допустимость цен для типа проверяет use case
+	if pbReq.Price != "" {
+		price, err := decimal.NewFromString(pbReq.Price)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid price format: %v", err)
+		}
+		dtoReq.Price = price
+	}
+	if pbReq.StopPrice != "" {
+		stopPrice, err := decimal.NewFromString(pbReq.StopPrice)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid stop price format: %v", err)
+		}
+		dtoReq.StopPrice = stopPrice
 	}
-	dtoReq.Price = price
 
 	quantity, err := decimal.NewFromString(pbReq.Quantity)
 	if err != nil {
@@ -42,13 +56,17 @@ func (s *OrderServer) CreateOrder(ctx context.Context, pbReq *pb.CreateOrderRequ
 	dtoReq.Quantity = quantity
 
 	dtoReq.OrderType = mappers.OrderTypeFromProto(pbReq.OrderType).String()
+	dtoReq.Side = mappers.OrderSideFromProto(pbReq.Side).String()
+	if pbReq.TimeInForce != pb.TimeInForce_TIME_IN_FORCE_UNSPECIFIED {
+		dtoReq.TimeInForce = mappers.TimeInForceFromProto(pbReq.TimeInForce).String()
+	}
+	if pbReq.ExpiresAt != nil {
+		dtoReq.ExpiresAt = pbReq.ExpiresAt.AsTime()
+	}
 
 	dtoResp, err := s.useCase.CreateOrder(ctx, dtoReq)
 	if err != nil {
-		if errors.Is(err, domain.ErrInvalidPrice) || errors.Is(err, domain.ErrInvalidQuantity) {
-			return nil, status.Error(codes.InvalidArgument, err.Error())
-		}
-		return nil, err
+		return nil, errorToStatus(err)
 	}
 
 	statusStr, err := domain.ParseOrderStatus(dtoResp.Status)
@@ -67,7 +85,7 @@ func (s *OrderServer) GetOrderStatus(ctx context.Context, pbReq *pb.GetOrderStat
 
 	resp, err := s.useCase.GetOrderStatus(ctx, dtoReq)
 	if err != nil {
-		return nil, err
+		return nil, errorToStatus(err)
 	}
 
 	statusStr, err := domain.ParseOrderStatus(resp.Status)
@@ -79,6 +97,39 @@ func (s *OrderServer) GetOrderStatus(ctx context.Context, pbReq *pb.GetOrderStat
 
 }
 
+func (s *OrderServer) GetOrder(ctx context.Context, pbReq *pb.GetOrderRequest) (*pb.GetOrderResponse, error) {
+	dtoReq := order.GetOrderRequest{
+		OrderID: pbReq.OrderId,
+		UserID:  pbReq.UserId,
+	}
+
+	resp, err := s.useCase.GetOrder(ctx, dtoReq)
+	if err != nil {
+		return nil, errorToStatus(err)
+	}
+
+	return &pb.GetOrderResponse{
+		Order: mappers.OrderToProto(resp.Order),
+	}, nil
+}
+
+func (s *OrderServer) GetOrderHistory(ctx context.Context, pbReq *pb.GetOrderHistoryRequest) (*pb.GetOrderHistoryResponse, error) {
+	dtoReq := order.GetOrderHistoryRequest{
+		OrderID: pbReq.OrderId,
+		UserID:  pbReq.UserId,
+	}
+
+	resp, err := s.useCase.GetOrderHistory(ctx, dtoReq)
+	if err != nil {
+		return nil, errorToStatus(err)
+	}
+
+	return &pb.GetOrderHistoryResponse{
+		OrderId:     resp.OrderID,
+		Transitions: mappers.OrderTransitionsToProto(resp.Transitions),
+	}, nil
+}
+
 func (s *OrderServer) CancelOrder(ctx context.Context, pbReq *pb.CancelOrderRequest) (*pb.CancelOrderResponse, error) {
 	dtoReq := order.CancelOrderRequest{
 		OrderID: pbReq.OrderId,
@@ -87,7 +138,7 @@ func (s *OrderServer) CancelOrder(ctx context.Context, pbReq *pb.CancelOrderRequ
 
 	dtoResp, err := s.useCase.CancelOrder(ctx, dtoReq)
 	if err != nil {
-		return nil, err
+		return nil, errorToStatus(err)
 	}
 
 	statusStr, err := domain.ParseOrderStatus(dtoResp.Status)
@@ -98,3 +149,159 @@ func (s *OrderServer) CancelOrder(ctx context.Context, pbReq *pb.CancelOrderRequ
 	}, nil
 
 }
+
+func (s *OrderServer) ReplaceOrder(ctx context.Context, pbReq *pb.ReplaceOrderRequest) (*pb.ReplaceOrderResponse, error) {
+	dtoReq := order.ReplaceOrderRequest{
+		OrderID: pbReq.OrderId,
+		UserID:  pbReq.UserId,
+	}
+
+	// пустые значения остаются нулем - параметр не меняется, поэтому явный ноль отклоняется
+	if pbReq.Price != "" {
+		price, err := decimal.NewFromString(pbReq.Price)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid price format: %v", err)
+		}
+		if !price.IsPositive() {
+			return nil, errorToStatus(domain.ErrInvalidPrice)
+		}
+		dtoReq.Price = price
+	}
+	if pbReq.Quantity != "" {
+		quantity, err := decimal.NewFromString(pbReq.Quantity)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid quantity format: %v", err)
+		}
+		if !quantity.IsPositive() {
+			return nil, errorToStatus(domain.ErrInvalidQuantity)
+		}
+		dtoReq.Quantity = quantity
+	}
+
+	dtoResp, err := s.useCase.ReplaceOrder(ctx, dtoReq)
+	if err != nil {
+		return nil, errorToStatus(err)
+	}
+
+	return &pb.ReplaceOrderResponse{Order: mappers.OrderToProto(dtoResp.Order)}, nil
+}
+
+func (s *OrderServer) ListOrders(ctx context.Context, pbReq *pb.ListOrdersRequest) (*pb.ListOrdersResponse, error) {
+	dtoReq := order.ListOrdersRequest{
+		UserID:    pbReq.UserId,
+		MarketID:  pbReq.MarketId,
+		PageSize:  int(pbReq.PageSize),
+		PageToken: pbReq.PageToken,
+	}
+
+	for _, st := range pbReq.Statuses {
+		dtoReq.Statuses = append(dtoReq.Statuses, mappers.OrderStatusFromProto(st).String())
+	}
+	for _, ot := range pbReq.OrderTypes {
+		dtoReq.OrderTypes = append(dtoReq.OrderTypes, mappers.OrderTypeFromProto(ot).String())
+	}
+	if pbReq.CreatedFrom != nil {
+		dtoReq.CreatedFrom = pbReq.CreatedFrom.AsTime()
+	}
+	if pbReq.CreatedTo != nil {
+		dtoReq.CreatedTo = pbReq.CreatedTo.AsTime()
+	}
+
+	dtoResp, err := s.useCase.ListOrders(ctx, dtoReq)
+	if err != nil {
+		return nil, errorToStatus(err)
+	}
+
+	return &pb.ListOrdersResponse{
+		Orders:        mappers.OrdersToProto(dtoResp.Orders),
+		NextPageToken: dtoResp.NextPageToken,
+	}, nil
+}
+
+func (s *OrderServer) SubscribeOrderUpdates(pbReq *pb.SubscribeOrderUpdatesRequest, stream grpc.ServerStreamingServer[pb.OrderUpdate]) error {
+	dtoReq := order.SubscribeOrderUpdatesRequest{
+		UserID:       pbReq.UserId,
+		OrderID:      pbReq.OrderId,
+		FromSequence: pbReq.FromSequence,
+	}
+
+	err := s.useCase.SubscribeOrderUpdates(stream.Context(), dtoReq, func(e domain.OrderEvent) error {
+		return stream.Send(mappers.OrderEventToProto(e))
+	})
+	return errorToStatus(err)
+}
+
+// errorStatusCodes коды gRPC для доменных ошибок
+var errorStatusCodes = []struct {
+	err  error
+	code codes.Code
+}{
+	{domain.ErrUnauthenticated, codes.Unauthenticated},
+	{domain.ErrAccessDenied, codes.PermissionDenied},
+
+	{domain.ErrOrderNotFound, codes.NotFound},
+	{domain.ErrMarketNotAvailable, codes.NotFound},
+
+	{domain.ErrOrderAlreadyExists, codes.AlreadyExists},
+	{domain.ErrDuplicateClientOrderID, codes.AlreadyExists},
+	{domain.ErrIdempotencyKeyReused, codes.AlreadyExists},
+	{domain.ErrDuplicateFill, codes.AlreadyExists},
+
+	{domain.ErrVersionConflict, codes.Aborted},
+
+	{domain.ErrInvalidTransition, codes.FailedPrecondition},
+	{domain.ErrOrderCannotBeCancelled, codes.FailedPrecondition},
+	{domain.ErrOrderAlreadyCancelled, codes.FailedPrecondition},
+	{domain.ErrOrderCannotBeAmended, codes.FailedPrecondition},
+	{domain.ErrQuantityBelowFilled, codes.FailedPrecondition},
+	{domain.ErrOrderNotTriggerable, codes.FailedPrecondition},
+	{domain.ErrOrderNotExpired, codes.FailedPrecondition},
+	{domain.ErrOrderNotFillable, codes.FailedPrecondition},
+	{domain.ErrFillExceedsRemaining, codes.FailedPrecondition},
+	{domain.ErrReduceOnlyViolation, codes.FailedPrecondition},
+
+	{domain.ErrInvalidOrderType, codes.InvalidArgument},
+	{domain.ErrInvalidOrderStatus, codes.InvalidArgument},
+	{domain.ErrInvalidOrderSide, codes.InvalidArgument},
+	{domain.ErrInvalidOrderEventType, codes.InvalidArgument},
+	{domain.ErrInvalidPrice, codes.InvalidArgument},
+	{domain.ErrInvalidQuantity, codes.InvalidArgument},
+	{domain.ErrPriceNotAllowed, codes.InvalidArgument},
+	{domain.ErrInvalidStopPrice, codes.InvalidArgument},
+	{domain.ErrStopPriceNotAllowed, codes.InvalidArgument},
+	{domain.ErrStopLimitPrice, codes.InvalidArgument},
+	{domain.ErrInvalidTimeInForce, codes.InvalidArgument},
+	{domain.ErrTimeInForceNotAllowed, codes.InvalidArgument},
+	{domain.ErrInvalidExpiry, codes.InvalidArgument},
+	{domain.ErrExpiryNotAllowed, codes.InvalidArgument},
+	{domain.ErrPostOnlyNotAllowed, codes.InvalidArgument},
+	{domain.ErrPriceTickSize, codes.InvalidArgument},
+	{domain.ErrQuantityStepSize, codes.InvalidArgument},
+	{domain.ErrQuantityTooSmall, codes.InvalidArgument},
+	{domain.ErrQuantityTooLarge, codes.InvalidArgument},
+	{domain.ErrNotionalTooSmall, codes.InvalidArgument},
+	{domain.ErrNothingToAmend, codes.InvalidArgument},
+	{domain.ErrInvalidFill, codes.InvalidArgument},
+	{domain.ErrInvalidPageToken, codes.InvalidArgument},
+	{domain.ErrInvalidTimeRange, codes.InvalidArgument},
+}
+
+// errorToStatus переводит доменные ошибки и ошибки контекста в коды gRPC. Ошибки со статусом gRPC
+// возвращаются как есть, неизвестные - как codes.Internal без подробностей
+func errorToStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+	for _, e := range errorStatusCodes {
+		if errors.Is(err, e.err) {
+			return status.Error(e.code, err.Error())
+		}
+	}
+	return status.Error(codes.Internal, "internal error")
+}
@@ -0,0 +1,67 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/chilly266futon/orderService/gen/pb/order"
+	"github.com/chilly266futon/orderService/internal/domain"
+)
+
+func TestErrorToStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{"not found", domain.ErrOrderNotFound, codes.NotFound},
+		{"market not available", domain.ErrMarketNotAvailable, codes.NotFound},
+		{"wrapped transition", fmt.Errorf("%w: OPEN -> CREATED", domain.ErrInvalidTransition), codes.FailedPrecondition},
+		{"idempotency key reused", domain.ErrIdempotencyKeyReused, codes.AlreadyExists},
+		{"version conflict", domain.ErrVersionConflict, codes.Aborted},
+		{"invalid price", domain.ErrInvalidPrice, codes.InvalidArgument},
+		{"access denied", domain.ErrAccessDenied, codes.PermissionDenied},
+		{"unauthenticated", domain.ErrUnauthenticated, codes.Unauthenticated},
+		{"status kept", status.Error(codes.ResourceExhausted, "slow consumer"), codes.ResourceExhausted},
+		{"context cancelled", context.Canceled, codes.Canceled},
+		{"unknown", errors.New("boom"), codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := status.Code(errorToStatus(tt.err)); got != tt.want {
+				t.Fatalf("errorToStatus(%v) code = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+
+	if err := errorToStatus(nil); err != nil {
+		t.Fatalf("errorToStatus(nil) = %v, want nil", err)
+	}
+}
+
+func TestReplaceOrderRejectsZero(t *testing.T) {
+	srv := NewOrderServer(nil)
+
+	tests := []struct {
+		name string
+		req  *pb.ReplaceOrderRequest
+	}{
+		{"zero price", &pb.ReplaceOrderRequest{Price: "0"}},
+		{"zero quantity", &pb.ReplaceOrderRequest{Quantity: "0.00"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := srv.ReplaceOrder(context.Background(), tt.req)
+			if got := status.Code(err); got != codes.InvalidArgument {
+				t.Fatalf("ReplaceOrder() code = %s, want %s", got, codes.InvalidArgument)
+			}
+		})
+	}
+}
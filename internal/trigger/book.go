@@ -0,0 +1,79 @@
+package trigger
+
+import (
+	"sort"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/chilly266futon/orderService/internal/domain"
+)
+
+// stop неактивированный стоп-заказ в индексе
+type stop struct {
+	orderID   string
+	marketID  string
+	side      domain.OrderSide
+	stopPrice decimal.Decimal
+}
+
+// book стоп-заказы одного рынка, упорядоченные по близости к срабатыванию.
+// Покупки срабатывают при росте цены и хранятся по возрастанию стоп-цены, продажи - по убыванию.
+// При равной стоп-цене раньше срабатывает заказ, добавленный раньше
+type book struct {
+	buys  []stop
+	sells []stop
+}
+
+func (b *book) add(s stop) {
+	if s.side == domain.OrderSideBuy {
+		i := sort.Search(len(b.buys), func(i int) bool { return b.buys[i].stopPrice.GreaterThan(s.stopPrice) })
+		b.buys = insertAt(b.buys, i, s)
+		return
+	}
+	i := sort.Search(len(b.sells), func(i int) bool { return b.sells[i].stopPrice.LessThan(s.stopPrice) })
+	b.sells = insertAt(b.sells, i, s)
+}
+
+func (b *book) remove(s stop) {
+	if s.side == domain.OrderSideBuy {
+		b.buys = removeOrder(b.buys, s.orderID)
+		return
+	}
+	b.sells = removeOrder(b.sells, s.orderID)
+}
+
+// popTriggered убирает из книги и возвращает заказы, которые активирует цена последней сделки
+func (b *book) popTriggered(lastPrice decimal.Decimal) []stop {
+	buys := sort.Search(len(b.buys), func(i int) bool { return b.buys[i].stopPrice.GreaterThan(lastPrice) })
+	sells := sort.Search(len(b.sells), func(i int) bool { return b.sells[i].stopPrice.LessThan(lastPrice) })
+	if buys == 0 && sells == 0 {
+		return nil
+	}
+
+	triggered := make([]stop, 0, buys+sells)
+	triggered = append(triggered, b.buys[:buys]...)
+	triggered = append(triggered, b.sells[:sells]...)
+	b.buys = append(b.buys[:0], b.buys[buys:]...)
+	b.sells = append(b.sells[:0], b.sells[sells:]...)
+	return triggered
+}
+
+func (b *book) empty() bool {
+	return len(b.buys) == 0 && len(b.sells) == 0
+}
+
+func insertAt(stops []stop, i int, s stop) []stop {
+	stops = append(stops, stop{})
+	copy(stops[i+1:], stops[i:])
+	stops[i] = s
+	return stops
+}
+
+func removeOrder(stops []stop, orderID string) []stop {
+	for i := range stops {
+		if stops[i].orderID == orderID {
+			return append(stops[:i], stops[i+1:]...)
+		}
+	}
+	return stops
+}
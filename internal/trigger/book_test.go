@@ -0,0 +1,87 @@
+package trigger
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/chilly266futon/orderService/internal/domain"
+)
+
+func ids(stops []stop) []string {
+	result := make([]string, len(stops))
+	for i, s := range stops {
+		result[i] = s.orderID
+	}
+	return result
+}
+
+func newStop(id string, side domain.OrderSide, stopPrice string) stop {
+	return stop{orderID: id, marketID: "market-1", side: side, stopPrice: decimal.RequireFromString(stopPrice)}
+}
+
+func TestBookPopTriggered(t *testing.T) {
+	var b book
+	for _, s := range []stop{
+		newStop("buy-102", domain.OrderSideBuy, "102"),
+		newStop("buy-100-first", domain.OrderSideBuy, "100"),
+		newStop("buy-101", domain.OrderSideBuy, "101"),
+		newStop("buy-100-second", domain.OrderSideBuy, "100"),
+		newStop("sell-97", domain.OrderSideSell, "97"),
+		newStop("sell-99-first", domain.OrderSideSell, "99"),
+		newStop("sell-98", domain.OrderSideSell, "98"),
+		newStop("sell-99-second", domain.OrderSideSell, "99"),
+	} {
+		b.add(s)
+	}
+
+	tests := []struct {
+		name      string
+		lastPrice string
+		want      []string
+	}{
+		{"between buys and sells", "99.5", nil},
+		// покупки срабатывают при цене не ниже стоп-цены, при равной - в порядке добавления
+		{"buys up to the price", "101", []string{"buy-100-first", "buy-100-second", "buy-101"}},
+		// продажи срабатывают при цене не выше стоп-цены, ближайшие к цене первыми
+		{"sells down to the price", "98", []string{"sell-99-first", "sell-99-second", "sell-98"}},
+		{"already triggered are gone", "98", nil},
+		{"rest of the book", "200", []string{"buy-102"}},
+		{"last sell", "1", []string{"sell-97"}},
+	}
+
+	for _, tt := range tests {
+		got := ids(b.popTriggered(decimal.RequireFromString(tt.lastPrice)))
+		if !slices.Equal(got, tt.want) {
+			t.Fatalf("%s: popTriggered(%s) = %v, want %v", tt.name, tt.lastPrice, got, tt.want)
+		}
+	}
+	if !b.empty() {
+		t.Fatalf("book is not empty: buys %v, sells %v", ids(b.buys), ids(b.sells))
+	}
+}
+
+func TestBookPopTriggeredBothSides(t *testing.T) {
+	var b book
+	b.add(newStop("buy", domain.OrderSideBuy, "100"))
+	b.add(newStop("sell", domain.OrderSideSell, "100"))
+
+	if got := ids(b.popTriggered(decimal.RequireFromString("100"))); !slices.Equal(got, []string{"buy", "sell"}) {
+		t.Fatalf("popTriggered(100) = %v, want both orders", got)
+	}
+}
+
+func TestBookRemoveKeepsOrder(t *testing.T) {
+	var b book
+	b.add(newStop("first", domain.OrderSideSell, "100"))
+	b.add(newStop("second", domain.OrderSideSell, "100"))
+	b.add(newStop("third", domain.OrderSideSell, "100"))
+
+	b.remove(newStop("second", domain.OrderSideSell, "100"))
+	b.remove(newStop("unknown", domain.OrderSideSell, "100"))
+
+	if got := ids(b.popTriggered(decimal.RequireFromString("100"))); !slices.Equal(got, []string{"first", "third"}) {
+		t.Fatalf("popTriggered(100) = %v, want [first third]", got)
+	}
+}
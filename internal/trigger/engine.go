@@ -0,0 +1,308 @@
+package trigger
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+
+	"github.com/chilly266futon/orderService/internal/domain"
+	"github.com/chilly266futon/orderService/internal/dto/order"
+	"github.com/chilly266futon/orderService/internal/outbox"
+	"github.com/chilly266futon/orderService/internal/storage"
+)
+
+const (
+	loadPageSize = 500
+	// resyncDelay пауза перед повторной загрузкой индекса после ошибки хранилища
+	resyncDelay = 5 * time.Second
+)
+
+// Converter активирует стоп-заказ в хранилище. Реализуется service.OrderUseCase
+type Converter interface {
+	TriggerStopOrder(ctx context.Context, req order.TriggerStopOrderRequest) (order.TriggerStopOrderResponse, error)
+}
+
+// Engine держит неактивированные стоп-заказы в индексе по рынку и стоп-цене и активирует их
+// по ценам последних сделок из Feed. Индекс загружается из хранилища при старте и поддерживается
+// по событиям заказов из Hub; при переполнении подписки индекс перезагружается целиком
+type Engine struct {
+	repo      storage.OrderRepository
+	updates   *outbox.Hub
+	feed      Feed
+	converter Converter
+	logger    *zap.Logger
+
+	books      map[string]*book
+	stops      map[string]stop
+	lastPrices map[string]decimal.Decimal
+
+	cancel context.CancelFunc
+	done   chan struct{}
+	mu     sync.Mutex
+}
+
+func NewEngine(
+	repo storage.OrderRepository,
+	updates *outbox.Hub,
+	feed Feed,
+	converter Converter,
+	logger *zap.Logger,
+) *Engine {
+	return &Engine{
+		repo:      repo,
+		updates:   updates,
+		feed:      feed,
+		converter: converter,
+		logger:    logger,
+	}
+}
+
+// Start запускает движок в отдельной горутине
+func (e *Engine) Start() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	e.cancel = cancel
+	e.done = make(chan struct{})
+
+	go func() {
+		defer close(e.done)
+		e.run(ctx)
+	}()
+}
+
+// Stop останавливает движок и ждет завершения активации, начатой по последней цене
+func (e *Engine) Stop() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.cancel == nil {
+		return
+	}
+	e.cancel()
+	<-e.done
+	e.cancel = nil
+}
+
+func (e *Engine) run(ctx context.Context) {
+	sub := e.resync(ctx)
+	if sub == nil {
+		return
+	}
+	defer func() { e.updates.Unsubscribe(sub) }()
+
+	ticks, err := e.feed.Ticks(ctx)
+	if err != nil {
+		e.logger.Error("failed to open price feed, stop orders will not be triggered", zap.Error(err))
+		return
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case tick, ok := <-ticks:
+			if !ok {
+				e.logger.Warn("price feed closed, stop orders will not be triggered")
+				ticks = nil
+				continue
+			}
+			e.onTick(ctx, tick)
+
+		case event, ok := <-sub.Events():
+			if !ok {
+				e.logger.Warn("order updates subscription overflowed, reloading stop orders")
+				if sub = e.resync(ctx); sub == nil {
+					return
+				}
+				continue
+			}
+			e.onEvent(ctx, event)
+		}
+	}
+}
+
+// resync подписывается на события и загружает индекс заново. Подписка оформляется до загрузки,
+// чтобы не потерять заказы, созданные во время нее. Возвращает nil, если ctx отменен
+func (e *Engine) resync(ctx context.Context) *outbox.Subscription {
+	for {
+		sub := e.updates.Subscribe(storage.EventFilter{})
+		err := e.load(ctx)
+		if err == nil {
+			return sub
+		}
+		e.updates.Unsubscribe(sub)
+
+		if ctx.Err() != nil {
+			return nil
+		}
+		e.logger.Error("failed to load stop orders", zap.Error(err))
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(resyncDelay):
+		}
+	}
+}
+
+func (e *Engine) load(ctx context.Context) error {
+	e.books = make(map[string]*book)
+	e.stops = make(map[string]stop)
+	if e.lastPrices == nil {
+		e.lastPrices = make(map[string]decimal.Decimal)
+	}
+
+	filter := storage.OrderFilter{
+		Statuses: []domain.OrderStatus{domain.OrderStatusCreated},
+		Types:    []domain.OrderType{domain.OrderTypeStopLimit, domain.OrderTypeStopMarket},
+		Limit:    loadPageSize,
+	}
+	for {
+		page, err := e.repo.List(ctx, filter)
+		if err != nil {
+			return err
+		}
+		for _, o := range page {
+			e.add(o)
+		}
+		if len(page) < loadPageSize {
+			break
+		}
+		cursor := storage.CursorOf(page[len(page)-1])
+		filter.After = &cursor
+	}
+
+	e.logger.Info("stop orders loaded", zap.Int("count", len(e.stops)))
+	return nil
+}
+
+func (e *Engine) add(o *domain.Order) {
+	if !o.IsAwaitingTrigger() {
+		return
+	}
+	if _, ok := e.stops[o.ID]; ok {
+		return
+	}
+
+	s := stop{
+		orderID:   o.ID,
+		marketID:  o.MarketID,
+		side:      o.Side,
+		stopPrice: o.StopPrice,
+	}
+	b := e.books[s.marketID]
+	if b == nil {
+		b = &book{}
+		e.books[s.marketID] = b
+	}
+	b.add(s)
+	e.stops[s.orderID] = s
+}
+
+func (e *Engine) remove(orderID string) {
+	s, ok := e.stops[orderID]
+	if !ok {
+		return
+	}
+	delete(e.stops, orderID)
+
+	b := e.books[s.marketID]
+	b.remove(s)
+	if b.empty() {
+		delete(e.books, s.marketID)
+	}
+}
+
+// onEvent добавляет в индекс созданные стоп-заказы и убирает заказы, вышедшие из ожидания
+func (e *Engine) onEvent(ctx context.Context, event domain.OrderEvent) {
+	// изменение цены или объема не трогает стоп-цену, заказ по-прежнему ждет активации
+	if event.Type == domain.OrderEventAmended {
+		return
+	}
+	if event.Type != domain.OrderEventCreated {
+		e.remove(event.OrderID)
+		return
+	}
+
+	o, err := e.repo.GetByID(ctx, event.OrderID)
+	if err != nil {
+		if ctx.Err() == nil {
+			e.logger.Error("failed to load created order",
+				zap.String("order_id", event.OrderID),
+				zap.Error(err),
+			)
+		}
+		return
+	}
+	if !o.IsAwaitingTrigger() {
+		return
+	}
+
+	e.add(o)
+
+	// цена могла пройти стоп-цену, пока событие о создании шло через outbox
+	if lastPrice, ok := e.lastPrices[o.MarketID]; ok {
+		e.trigger(ctx, o.MarketID, lastPrice)
+	}
+}
+
+func (e *Engine) onTick(ctx context.Context, tick Tick) {
+	e.lastPrices[tick.MarketID] = tick.Price
+	e.trigger(ctx, tick.MarketID, tick.Price)
+}
+
+// trigger активирует заказы рынка, стоп-цену которых прошла lastPrice. Заказы, которые не удалось
+// сохранить из-за ошибки хранилища, возвращаются в индекс и будут активированы следующей ценой
+func (e *Engine) trigger(ctx context.Context, marketID string, lastPrice decimal.Decimal) {
+	b := e.books[marketID]
+	if b == nil {
+		return
+	}
+
+	var retry []stop
+	for _, s := range b.popTriggered(lastPrice) {
+		delete(e.stops, s.orderID)
+
+		_, err := e.converter.TriggerStopOrder(ctx, order.TriggerStopOrderRequest{
+			OrderID:   s.orderID,
+			LastPrice: lastPrice,
+		})
+		switch {
+		case err == nil:
+		case errors.Is(err, domain.ErrOrderNotTriggerable), errors.Is(err, domain.ErrOrderNotFound):
+			// заказ уже отменен или изменен, событие об этом еще не дошло
+			e.logger.Debug("stop order no longer awaits trigger",
+				zap.String("order_id", s.orderID),
+				zap.Error(err),
+			)
+		default:
+			if ctx.Err() == nil {
+				e.logger.Error("failed to trigger stop order",
+					zap.String("order_id", s.orderID),
+					zap.String("market_id", marketID),
+					zap.Error(err),
+				)
+			}
+			retry = append(retry, s)
+		}
+	}
+
+	for _, s := range retry {
+		b.add(s)
+		e.stops[s.orderID] = s
+	}
+	if b.empty() {
+		delete(e.books, marketID)
+	}
+}
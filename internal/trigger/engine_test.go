@@ -0,0 +1,242 @@
+package trigger
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+
+	"github.com/chilly266futon/orderService/internal/domain"
+	"github.com/chilly266futon/orderService/internal/dto/order"
+	"github.com/chilly266futon/orderService/internal/outbox"
+	"github.com/chilly266futon/orderService/internal/storage"
+	"github.com/chilly266futon/orderService/internal/storage/storagetest"
+)
+
+// fakeConverter записывает активированные заказы. err возвращается для каждого вызова,
+// block, если задан, задерживает вызов до своего закрытия
+type fakeConverter struct {
+	mu      sync.Mutex
+	calls   []order.TriggerStopOrderRequest
+	err     error
+	block   chan struct{}
+	entered chan string
+}
+
+func (c *fakeConverter) TriggerStopOrder(_ context.Context, req order.TriggerStopOrderRequest) (order.TriggerStopOrderResponse, error) {
+	if c.entered != nil {
+		c.entered <- req.OrderID
+	}
+	if c.block != nil {
+		<-c.block
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = append(c.calls, req)
+	return order.TriggerStopOrderResponse{}, c.err
+}
+
+func (c *fakeConverter) setErr(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.err = err
+}
+
+func (c *fakeConverter) triggered() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	result := make([]string, len(c.calls))
+	for i, call := range c.calls {
+		result[i] = call.OrderID
+	}
+	return result
+}
+
+// listCountingRepo считает загрузки индекса
+type listCountingRepo struct {
+	storage.OrderRepository
+	lists atomic.Int64
+}
+
+func (r *listCountingRepo) List(ctx context.Context, filter storage.OrderFilter) ([]*domain.Order, error) {
+	r.lists.Add(1)
+	return r.OrderRepository.List(ctx, filter)
+}
+
+func addStopOrder(t *testing.T, repo storage.OrderRepository, side domain.OrderSide, stopPrice string) *domain.Order {
+	t.Helper()
+
+	o := storagetest.NewOrder("user-1")
+	o.MarketID = "market-1"
+	o.Type = domain.OrderTypeStopMarket
+	o.Side = side
+	o.Price = decimal.Zero
+	o.StopPrice = decimal.RequireFromString(stopPrice)
+	if err := repo.Add(context.Background(), o); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	return o
+}
+
+func newLoadedEngine(t *testing.T, repo storage.OrderRepository, converter Converter) *Engine {
+	t.Helper()
+
+	e := NewEngine(repo, outbox.NewHub(0), NewMemoryFeed(0), converter, zap.NewNop())
+	if err := e.load(context.Background()); err != nil {
+		t.Fatalf("load() error = %v", err)
+	}
+	return e
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestEngineTriggersOrderCreatedAfterLastPrice(t *testing.T) {
+	ctx := context.Background()
+	repo := storage.NewMemoryOrderRepository()
+	converter := &fakeConverter{}
+	e := newLoadedEngine(t, repo, converter)
+
+	e.onTick(ctx, Tick{MarketID: "market-1", Price: decimal.RequireFromString("105")})
+
+	// событие о создании пришло после цены, которая уже прошла стоп-цену
+	passed := addStopOrder(t, repo, domain.OrderSideBuy, "100")
+	waiting := addStopOrder(t, repo, domain.OrderSideBuy, "110")
+	e.onEvent(ctx, domain.OrderEvent{Type: domain.OrderEventCreated, OrderID: passed.ID})
+	e.onEvent(ctx, domain.OrderEvent{Type: domain.OrderEventCreated, OrderID: waiting.ID})
+
+	if got := converter.triggered(); !slices.Equal(got, []string{passed.ID}) {
+		t.Fatalf("triggered %v, want only %s", got, passed.ID)
+	}
+	if !converter.calls[0].LastPrice.Equal(decimal.RequireFromString("105")) {
+		t.Fatalf("triggered at %s, want 105", converter.calls[0].LastPrice)
+	}
+	if _, ok := e.stops[waiting.ID]; !ok {
+		t.Fatal("order with an unreached stop price is not indexed")
+	}
+	if _, ok := e.stops[passed.ID]; ok {
+		t.Fatal("triggered order is still indexed")
+	}
+}
+
+func TestEngineEventsMaintainIndex(t *testing.T) {
+	ctx := context.Background()
+	repo := storage.NewMemoryOrderRepository()
+	converter := &fakeConverter{}
+	o := addStopOrder(t, repo, domain.OrderSideSell, "90")
+	e := newLoadedEngine(t, repo, converter)
+
+	e.onEvent(ctx, domain.OrderEvent{Type: domain.OrderEventAmended, OrderID: o.ID})
+	if _, ok := e.stops[o.ID]; !ok {
+		t.Fatal("amended stop order removed from the index")
+	}
+
+	e.onEvent(ctx, domain.OrderEvent{Type: domain.OrderEventCancelled, OrderID: o.ID})
+	if _, ok := e.stops[o.ID]; ok || len(e.books) != 0 {
+		t.Fatal("cancelled stop order is still indexed")
+	}
+
+	e.onTick(ctx, Tick{MarketID: "market-1", Price: decimal.RequireFromString("80")})
+	if got := converter.triggered(); len(got) != 0 {
+		t.Fatalf("triggered %v after cancel", got)
+	}
+}
+
+func TestEngineRetriesAfterStorageError(t *testing.T) {
+	ctx := context.Background()
+	repo := storage.NewMemoryOrderRepository()
+	converter := &fakeConverter{err: errors.New("connection reset")}
+	o := addStopOrder(t, repo, domain.OrderSideBuy, "100")
+	e := newLoadedEngine(t, repo, converter)
+
+	e.onTick(ctx, Tick{MarketID: "market-1", Price: decimal.RequireFromString("100")})
+	if _, ok := e.stops[o.ID]; !ok || e.books["market-1"] == nil {
+		t.Fatal("order not re-indexed after a storage error")
+	}
+
+	// следующая цена активирует заказ снова
+	converter.setErr(nil)
+	e.onTick(ctx, Tick{MarketID: "market-1", Price: decimal.RequireFromString("101")})
+	if got := converter.triggered(); !slices.Equal(got, []string{o.ID, o.ID}) {
+		t.Fatalf("triggered %v, want %s twice", got, o.ID)
+	}
+	if _, ok := e.stops[o.ID]; ok || len(e.books) != 0 {
+		t.Fatal("order still indexed after a successful trigger")
+	}
+}
+
+func TestEngineDropsOrdersNoLongerAwaitingTrigger(t *testing.T) {
+	for _, err := range []error{domain.ErrOrderNotTriggerable, domain.ErrOrderNotFound} {
+		t.Run(err.Error(), func(t *testing.T) {
+			ctx := context.Background()
+			repo := storage.NewMemoryOrderRepository()
+			converter := &fakeConverter{err: err}
+			o := addStopOrder(t, repo, domain.OrderSideBuy, "100")
+			e := newLoadedEngine(t, repo, converter)
+
+			e.onTick(ctx, Tick{MarketID: "market-1", Price: decimal.RequireFromString("100")})
+			e.onTick(ctx, Tick{MarketID: "market-1", Price: decimal.RequireFromString("101")})
+			if got := converter.triggered(); !slices.Equal(got, []string{o.ID}) {
+				t.Fatalf("triggered %v, want %s once", got, o.ID)
+			}
+		})
+	}
+}
+
+func TestEngineResyncsAfterSubscriptionOverflow(t *testing.T) {
+	ctx := context.Background()
+	repo := &listCountingRepo{OrderRepository: storage.NewMemoryOrderRepository()}
+	hub := outbox.NewHub(1)
+	feed := NewMemoryFeed(0)
+	converter := &fakeConverter{block: make(chan struct{}), entered: make(chan string, 1)}
+
+	first := addStopOrder(t, repo, domain.OrderSideBuy, "100")
+	e := NewEngine(repo, hub, feed, converter, zap.NewNop())
+	e.Start()
+	defer e.Stop()
+	waitFor(t, "stop orders loaded", func() bool { return repo.lists.Load() == 1 })
+
+	// движок занят активацией и не читает события
+	if err := feed.Push(ctx, Tick{MarketID: "market-1", Price: decimal.RequireFromString("100")}); err != nil {
+		t.Fatalf("Push() error = %v", err)
+	}
+	if id := <-converter.entered; id != first.ID {
+		t.Fatalf("triggering %s, want %s", id, first.ID)
+	}
+
+	// событие о создании второго заказа теряется при переполнении подписки
+	second := addStopOrder(t, repo, domain.OrderSideBuy, "100")
+	for _, id := range []string{"other-1", "other-2", second.ID} {
+		if err := hub.Publish(ctx, domain.OrderEvent{Type: domain.OrderEventCreated, OrderID: id}); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	}
+
+	converter.entered = nil
+	close(converter.block)
+	waitFor(t, "stop orders reloaded", func() bool { return repo.lists.Load() == 2 })
+
+	if err := feed.Push(ctx, Tick{MarketID: "market-1", Price: decimal.RequireFromString("100")}); err != nil {
+		t.Fatalf("Push() error = %v", err)
+	}
+	waitFor(t, "second order triggered", func() bool {
+		return slices.Contains(converter.triggered(), second.ID)
+	})
+}
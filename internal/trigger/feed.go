@@ -0,0 +1,163 @@
+package trigger
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+
+	"github.com/chilly266futon/orderService/internal/domain"
+)
+
+// Tick цена последней сделки на рынке
+type Tick struct {
+	MarketID string
+	Price    decimal.Decimal
+	At       time.Time
+}
+
+// Feed источник цен последних сделок. Канал закрывается, когда ctx отменен или цены закончились
+type Feed interface {
+	Ticks(ctx context.Context) (<-chan Tick, error)
+}
+
+// MemoryFeed цены, переданные через Push. Используется в тестах и для цен собственных сделок сервиса
+type MemoryFeed struct {
+	ticks     chan Tick
+	closeOnce sync.Once
+}
+
+func NewMemoryFeed(buffer int) *MemoryFeed {
+	return &MemoryFeed{ticks: make(chan Tick, buffer)}
+}
+
+// Push передает цену движку. Блокируется, пока буфер полон: пропуск цены может пропустить срабатывание
+func (f *MemoryFeed) Push(ctx context.Context, tick Tick) error {
+	select {
+	case f.ticks <- tick:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Ticks возвращает общий канал цен, у фида может быть только один потребитель
+func (f *MemoryFeed) Ticks(context.Context) (<-chan Tick, error) {
+	return f.ticks, nil
+}
+
+// PublishTrade передает цену сделки движка сопоставления, реализует matching.TradeSink
+func (f *MemoryFeed) PublishTrade(ctx context.Context, trade domain.Trade) error {
+	return f.Push(ctx, Tick{MarketID: trade.MarketID, Price: trade.Price, At: trade.At})
+}
+
+// Close закрывает канал цен. Push после Close недопустим
+func (f *MemoryFeed) Close() {
+	f.closeOnce.Do(func() { close(f.ticks) })
+}
+
+// fileTick строка файла цен
+type fileTick struct {
+	MarketID string          `json:"market_id"`
+	Price    decimal.Decimal `json:"price"`
+	At       time.Time       `json:"at"`
+}
+
+// FileFeed читает цены из файла JSON Lines: {"market_id": "...", "price": "101.5", "at": "..."}.
+// С PollInterval > 0 после конца файла ждет дописанных строк, иначе закрывает канал
+type FileFeed struct {
+	path         string
+	pollInterval time.Duration
+	logger       *zap.Logger
+}
+
+func NewFileFeed(path string, pollInterval time.Duration, logger *zap.Logger) *FileFeed {
+	return &FileFeed{
+		path:         path,
+		pollInterval: pollInterval,
+		logger:       logger,
+	}
+}
+
+func (f *FileFeed) Ticks(ctx context.Context) (<-chan Tick, error) {
+	file, err := os.Open(f.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open price feed: %w", err)
+	}
+
+	ticks := make(chan Tick)
+	go func() {
+		defer close(ticks)
+		defer file.Close()
+		f.read(ctx, bufio.NewReader(file), ticks)
+	}()
+	return ticks, nil
+}
+
+func (f *FileFeed) read(ctx context.Context, reader *bufio.Reader, ticks chan<- Tick) {
+	var partial []byte
+	for {
+		line, err := reader.ReadBytes('\n')
+		partial = append(partial, line...)
+
+		if errors.Is(err, io.EOF) {
+			// строка без перевода может быть еще не дописана до конца
+			if f.pollInterval <= 0 {
+				f.emit(ctx, partial, ticks)
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(f.pollInterval):
+			}
+			continue
+		}
+		if err != nil {
+			f.logger.Error("failed to read price feed", zap.String("path", f.path), zap.Error(err))
+			return
+		}
+
+		if !f.emit(ctx, partial, ticks) {
+			return
+		}
+		partial = partial[:0]
+	}
+}
+
+// emit разбирает строку и отправляет цену. Возвращает false, если ctx отменен
+func (f *FileFeed) emit(ctx context.Context, line []byte, ticks chan<- Tick) bool {
+	line = bytes.TrimSpace(line)
+	if len(line) == 0 {
+		return true
+	}
+
+	var ft fileTick
+	if err := json.Unmarshal(line, &ft); err != nil || ft.MarketID == "" || !ft.Price.IsPositive() {
+		f.logger.Warn("skipping invalid price feed line",
+			zap.String("path", f.path),
+			zap.ByteString("line", line),
+			zap.Error(err),
+		)
+		return true
+	}
+	if ft.At.IsZero() {
+		ft.At = time.Now()
+	}
+
+	select {
+	case ticks <- Tick{MarketID: ft.MarketID, Price: ft.Price, At: ft.At}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
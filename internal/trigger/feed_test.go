@@ -0,0 +1,92 @@
+package trigger
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func appendFile(t *testing.T, path, data string) {
+	t.Helper()
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		t.Fatalf("OpenFile() error = %v", err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(data); err != nil {
+		t.Fatalf("WriteString() error = %v", err)
+	}
+}
+
+func TestFileFeedWaitsForPartialLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prices.jsonl")
+	appendFile(t, path, `{"market_id":"market-1","price":"100"}`+"\n"+`{"market_id":"market-1","pri`)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ticks, err := NewFileFeed(path, 5*time.Millisecond, zap.NewNop()).Ticks(ctx)
+	if err != nil {
+		t.Fatalf("Ticks() error = %v", err)
+	}
+
+	tick := <-ticks
+	if tick.MarketID != "market-1" || tick.Price.String() != "100" || tick.At.IsZero() {
+		t.Fatalf("first tick = %+v, want market-1 at 100", tick)
+	}
+
+	// недописанная строка не разбирается, пока не придет перевод строки
+	select {
+	case tick := <-ticks:
+		t.Fatalf("tick %+v from a partial line", tick)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	appendFile(t, path, `ce":"101.5","at":"2026-01-01T00:00:00Z"}`+"\n")
+	select {
+	case tick := <-ticks:
+		if tick.Price.String() != "101.5" || !tick.At.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) {
+			t.Fatalf("tick = %+v, want 101.5 at 2026-01-01", tick)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("completed line was not read")
+	}
+
+	cancel()
+	for range ticks {
+	}
+}
+
+func TestFileFeedReadsToEnd(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prices.jsonl")
+	appendFile(t, path, "\n"+
+		`{"market_id":"market-1","price":"100"}`+"\n"+
+		"not json\n"+
+		`{"market_id":"","price":"100"}`+"\n"+
+		`{"market_id":"market-1","price":"0"}`+"\n"+
+		// последняя строка без перевода: без PollInterval файл считается дочитанным
+		`{"market_id":"market-2","price":"7"}`)
+
+	ticks, err := NewFileFeed(path, 0, zap.NewNop()).Ticks(context.Background())
+	if err != nil {
+		t.Fatalf("Ticks() error = %v", err)
+	}
+
+	var got []string
+	for tick := range ticks {
+		got = append(got, tick.MarketID+"@"+tick.Price.String())
+	}
+	if len(got) != 2 || got[0] != "market-1@100" || got[1] != "market-2@7" {
+		t.Fatalf("ticks = %v, want [market-1@100 market-2@7]", got)
+	}
+}
+
+func TestFileFeedMissingFile(t *testing.T) {
+	if _, err := NewFileFeed(filepath.Join(t.TempDir(), "missing.jsonl"), 0, zap.NewNop()).Ticks(context.Background()); err == nil {
+		t.Fatal("Ticks() on a missing file succeeded")
+	}
+}